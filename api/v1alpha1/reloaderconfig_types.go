@@ -25,7 +25,7 @@ import (
 
 // ReloaderConfigSpec defines the desired state of ReloaderConfig
 type ReloaderConfigSpec struct {
-	// WatchedResources specifies which Secrets and ConfigMaps to watch for changes
+	// WatchedResources specifies which Secrets, ConfigMaps and other objects to watch for changes
 	// +optional
 	WatchedResources *WatchedResources `json:"watchedResources,omitempty"`
 
@@ -34,20 +34,25 @@ type ReloaderConfigSpec struct {
 	Targets []TargetWorkload `json:"targets,omitempty"`
 
 	// RolloutStrategy specifies how to deploy the change to workloads
-	// Valid values are: "rollout" (default), "restart"
+	// Valid values are: "rollout" (default), "restart", "notify", "volume-refresh"
 	// - rollout: Modifies pod template to trigger rolling update (uses ReloadStrategy)
 	// - restart: Deletes pods directly without modifying template (most GitOps-friendly)
-	// +kubebuilder:validation:Enum=rollout;restart
+	// - notify: Sends an HTTP request to every Ready pod so it reloads in place (uses Notify)
+	// - volume-refresh: Annotates the running pods so the kubelet resyncs mounted volumes immediately;
+	//   falls back to rollout unless the resource is only mounted as a volume without subPath
+	// +kubebuilder:validation:Enum=rollout;restart;notify;volume-refresh
 	// +kubebuilder:default=rollout
 	// +optional
 	RolloutStrategy string `json:"rolloutStrategy,omitempty"`
 
 	// ReloadStrategy specifies how to modify pod template when RolloutStrategy is "rollout"
-	// Valid values are: "env-vars" (default), "annotations"
+	// Valid values are: "env-vars" (default), "annotations", "restarted-at", "versioned-copy"
 	// - env-vars: Updates resource-specific environment variable (e.g., STAKATER_DB_CREDENTIALS_SECRET)
 	// - annotations: Updates pod template annotations (better for GitOps)
+	// - restarted-at: Sets kubectl.kubernetes.io/restartedAt like kubectl rollout restart
+	// - versioned-copy: Points the workload at an immutable copy of the changed Secret/ConfigMap
 	// This field is ignored when RolloutStrategy is "restart"
-	// +kubebuilder:validation:Enum=env-vars;annotations
+	// +kubebuilder:validation:Enum=env-vars;annotations;restarted-at;versioned-copy
 	// +kubebuilder:default=env-vars
 	// +optional
 	ReloadStrategy string `json:"reloadStrategy,omitempty"`
@@ -65,9 +70,111 @@ type ReloaderConfigSpec struct {
 	// Resources must have matching labels to trigger reload
 	// +optional
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// Alerts customizes the alerts sent for reloads triggered by this ReloaderConfig
+	// +optional
+	Alerts *AlertConfig `json:"alerts,omitempty"`
+
+	// Notify configures the HTTP reload request of the "notify" rollout strategy
+	// +optional
+	Notify *NotifyConfig `json:"notify,omitempty"`
+
+	// EnvVars customizes the variables injected by the "env-vars" reload strategy
+	// Unset fields fall back to the operator-wide --env-var-* flags
+	// +optional
+	EnvVars *EnvVarsConfig `json:"envVars,omitempty"`
+
+	// ReloaderClassName selects the operator instance that processes this ReloaderConfig
+	// It must match the instance's --reloader-class-name; when empty, only the instance without a class processes it
+	// +kubebuilder:validation:MaxLength=53
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ReloaderClassName string `json:"reloaderClassName,omitempty"`
+}
+
+// EnvVarsConfig defines the name and placement of the variables injected by the "env-vars" reload strategy
+type EnvVarsConfig struct {
+	// Prefix of the variable names, e.g. "STAKATER_" gives STAKATER_APP_CONFIG_CONFIGMAP
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Containers selects the containers that receive the variable
+	// Valid values are: "first", "all", "referencing", "named"
+	// - first: The first container of the pod template
+	// - all: Every container
+	// - referencing: The containers that reference the changed resource (env, envFrom or a mounted volume),
+	//   or the first container when none does
+	// - named: The containers listed in ContainerNames
+	// +kubebuilder:validation:Enum=first;all;referencing;named
+	// +optional
+	Containers string `json:"containers,omitempty"`
+
+	// ContainerNames lists the containers that receive the variable when Containers is "named"
+	// +optional
+	ContainerNames []string `json:"containerNames,omitempty"`
+}
+
+// NotifyConfig defines the HTTP request that makes a pod reload its configuration in place
+type NotifyConfig struct {
+	// Port of the reload endpoint on each pod (e.g., 9090)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Path of the reload endpoint (e.g., "/-/reload")
+	// +kubebuilder:default="/-/reload"
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Delay waits before notifying, so the kubelet can refresh mounted volumes first
+	// (e.g., "60s"; the kubelet sync period plus the cache TTL of the node)
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	// +optional
+	Delay string `json:"delay,omitempty"`
+
+	// Timeout of each request (default: 5s)
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// Fallback is what happens when a pod cannot be notified
+	// - rollout (default): Reload the workload with the "rollout" strategy instead
+	// - none: Only report the failure
+	// +kubebuilder:validation:Enum=rollout;none
+	// +kubebuilder:default=rollout
+	// +optional
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// AlertConfig customizes alerting for a single ReloaderConfig
+// Alert destinations are still configured operator-wide with --alert-* flags
+type AlertConfig struct {
+	// WebhookTemplate overrides the operator-wide payload template of the "webhook" alert sink
+	// It has no effect when another sink is configured
+	// +optional
+	WebhookTemplate *WebhookTemplate `json:"webhookTemplate,omitempty"`
+}
+
+// WebhookTemplate defines a custom HTTP payload for the generic webhook alert sink
+type WebhookTemplate struct {
+	// Payload is a Go text/template rendered with the alert message as data
+	// Message fields such as .WorkloadName, .ResourceName and .Error are available,
+	// as well as the json, upper and lower template functions
+	// +kubebuilder:validation:MinLength=1
+	Payload string `json:"payload"`
+
+	// ContentType is sent as the Content-Type header (default: application/json)
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// Headers are extra HTTP headers added to every alert request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
-// WatchedResources defines which Secrets and ConfigMaps to monitor
+// WatchedResources defines which Secrets, ConfigMaps and other objects to monitor
 type WatchedResources struct {
 	// Secrets is a list of Secret names to watch
 	// +optional
@@ -77,6 +184,11 @@ type WatchedResources struct {
 	// +optional
 	ConfigMaps []string `json:"configMaps,omitempty"`
 
+	// Objects is a list of objects of any other kind to watch (e.g., cert-manager Certificates,
+	// ExternalSecrets or custom resources). A change of the hashed fields triggers a reload.
+	// +optional
+	Objects []WatchedObject `json:"objects,omitempty"`
+
 	// EnableTargetedReload enables targeted reload mode for watched resources
 	// When true, resources are in "match" mode - they will only trigger reloads
 	// for targets that have EnableSearch=true AND actually reference these resources
@@ -95,6 +207,34 @@ type WatchedResources struct {
 	ResourceSelector *metav1.LabelSelector `json:"resourceSelector,omitempty"`
 }
 
+// WatchedObject selects objects of an arbitrary kind whose changes trigger reloads
+// Exactly one of Name and Selector must be set. Objects are looked up in the
+// ReloaderConfig's namespace, and the operator needs RBAC permissions to get,
+// list and watch them.
+type WatchedObject struct {
+	// APIVersion of the object (e.g., "cert-manager.io/v1")
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object (e.g., "Certificate")
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Name of the object to watch
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Selector watches all objects of this kind with matching labels
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// JSONPaths lists the fields whose values are hashed for change detection,
+	// in kubectl JSONPath syntax (e.g., "{.status.revision}" or ".spec").
+	// Defaults to ".spec"
+	// +optional
+	JSONPaths []string `json:"jsonPaths,omitempty"`
+}
+
 // TargetWorkload defines a workload that should be reloaded
 type TargetWorkload struct {
 	// Kind of the workload (Deployment, StatefulSet, DaemonSet, DeploymentConfig, Rollout)
@@ -111,13 +251,13 @@ type TargetWorkload struct {
 	Namespace string `json:"namespace,omitempty"`
 
 	// RolloutStrategy overrides the global rollout strategy for this specific workload
-	// +kubebuilder:validation:Enum=rollout;restart
+	// +kubebuilder:validation:Enum=rollout;restart;notify;volume-refresh
 	// +optional
 	RolloutStrategy string `json:"rolloutStrategy,omitempty"`
 
 	// ReloadStrategy overrides the global reload strategy for this specific workload
 	// Only applies when RolloutStrategy is "rollout"
-	// +kubebuilder:validation:Enum=env-vars;annotations
+	// +kubebuilder:validation:Enum=env-vars;annotations;restarted-at;versioned-copy
 	// +optional
 	ReloadStrategy string `json:"reloadStrategy,omitempty"`
 
@@ -127,6 +267,10 @@ type TargetWorkload struct {
 	// +optional
 	PausePeriod string `json:"pausePeriod,omitempty"`
 
+	// Notify overrides the ReloaderConfig's notify settings for this specific workload
+	// +optional
+	Notify *NotifyConfig `json:"notify,omitempty"`
+
 	// RequireReference enables targeted reload for this workload
 	// When true, this target will only be reloaded if:
 	// 1. The ReloaderConfig has EnableTargetedReload=true (resources in "match" mode)
@@ -138,8 +282,8 @@ type TargetWorkload struct {
 
 // ResourceReference identifies a specific Kubernetes resource
 type ResourceReference struct {
-	// Kind of the resource (Secret or ConfigMap)
-	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// Kind of the resource (Secret, ConfigMap or the kind of a watched object)
+	// +kubebuilder:validation:MinLength=1
 	Kind string `json:"kind"`
 
 	// Name of the resource
@@ -176,6 +320,20 @@ type ReloaderConfigStatus struct {
 	// +optional
 	WatchedResourceHashes map[string]string `json:"watchedResourceHashes,omitempty"`
 
+	// WatchedObjectPaths records which JSONPaths the hash of each watched object was computed from
+	// Key format: "namespace/kind/name", as in WatchedResourceHashes
+	// Value: digest of the object's JSONPaths; when they change, the hash is re-baselined instead of reloading
+	// +optional
+	WatchedObjectPaths map[string]string `json:"watchedObjectPaths,omitempty"`
+
+	// WatchedResources tracks the state of each watched resource
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=namespace
+	// +listMapKey=name
+	// +optional
+	WatchedResources []WatchedResourceStatus `json:"watchedResources,omitempty"`
+
 	// ReloadCount is the total number of reloads triggered by this configuration
 	// +optional
 	ReloadCount int64 `json:"reloadCount,omitempty"`
@@ -187,6 +345,109 @@ type ReloaderConfigStatus struct {
 	// ObservedGeneration reflects the generation of the most recently observed ReloaderConfig
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastHandledReloadRequest is the last token of the reloader.stakater.com/reload-requested
+	// annotation whose manual reload was triggered; a token is only handled once
+	// +optional
+	LastHandledReloadRequest string `json:"lastHandledReloadRequest,omitempty"`
+
+	// ReloadHistory lists the most recent changes that had targets to reload, newest first,
+	// including those whose targets all failed or were skipped
+	// The operator keeps at most --reload-history-limit entries.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	ReloadHistory []ReloadHistoryEntry `json:"reloadHistory,omitempty"`
+}
+
+// ReloadHistoryEntry records the reload of a ReloaderConfig's targets for one change
+type ReloadHistoryEntry struct {
+	// Time is when the reloads started
+	Time metav1.Time `json:"time"`
+
+	// Duration is how long reloading every target took
+	Duration metav1.Duration `json:"duration"`
+
+	// ResourceKind is the kind of the resource that triggered the reloads
+	// (ReloaderConfig for a manual reload request)
+	ResourceKind string `json:"resourceKind"`
+
+	// ResourceName is the name of the resource that triggered the reloads
+	ResourceName string `json:"resourceName"`
+
+	// ResourceNamespace is the namespace of the resource that triggered the reloads
+	// +optional
+	ResourceNamespace string `json:"resourceNamespace,omitempty"`
+
+	// ResourceHash is the new hash of the resource; empty when it was deleted
+	// +optional
+	ResourceHash string `json:"resourceHash,omitempty"`
+
+	// Outcome is Succeeded (every target reloaded), Scheduled (every target reloaded or left for later:
+	// notify delay or handover to another shard), PartiallySucceeded, Failed or Skipped (no target reloaded or failed)
+	// +kubebuilder:validation:Enum=Succeeded;Scheduled;PartiallySucceeded;Failed;Skipped
+	Outcome string `json:"outcome"`
+
+	// TargetCount is the number of targets of the change, including those not listed in targets
+	// +optional
+	TargetCount int32 `json:"targetCount,omitempty"`
+
+	// Targets are the outcomes of the first targets of the change
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Targets []ReloadHistoryTarget `json:"targets,omitempty"`
+}
+
+// ReloadHistoryTarget is the outcome of one target in a ReloadHistoryEntry
+type ReloadHistoryTarget struct {
+	// Kind of the workload
+	Kind string `json:"kind"`
+
+	// Name of the workload
+	Name string `json:"name"`
+
+	// Namespace of the workload
+	Namespace string `json:"namespace"`
+
+	// Strategy is the rollout strategy, followed by the reload strategy for "rollout" (e.g., "rollout/env-vars")
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// Outcome is Succeeded, Scheduled (left for later: after the notify delay or by the owning shard),
+	// Failed or Skipped (e.g., within the pause period)
+	// +kubebuilder:validation:Enum=Succeeded;Scheduled;Failed;Skipped
+	Outcome string `json:"outcome"`
+
+	// Message is why the target failed or was skipped
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// WatchedResourceStatus tracks the state of a watched Secret, ConfigMap or object
+type WatchedResourceStatus struct {
+	// Kind of the resource
+	Kind string `json:"kind"`
+
+	// Namespace of the resource
+	Namespace string `json:"namespace"`
+
+	// Name of the resource
+	Name string `json:"name"`
+
+	// State is Present, Missing (not found) or Ignored (spec.ignoreResources or the ignore annotation)
+	// +kubebuilder:validation:Enum=Present;Missing;Ignored
+	State string `json:"state"`
+
+	// Hash is the current hash of the resource data; empty when it is missing
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// LastChangeTime is when the hash last changed (including the resource being created or deleted)
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
+
+	// LastReloadTime is when a change of the resource last reloaded targets
+	// +optional
+	LastReloadTime *metav1.Time `json:"lastReloadTime,omitempty"`
 }
 
 // TargetWorkloadStatus tracks the reload status of a specific workload
@@ -215,6 +476,23 @@ type TargetWorkloadStatus struct {
 	// LastError contains the error message if the last reload failed
 	// +optional
 	LastError string `json:"lastError,omitempty"`
+
+	// PodNotifications records the outcome for each pod of the last "notify" reload
+	// +optional
+	PodNotifications []PodNotificationStatus `json:"podNotifications,omitempty"`
+}
+
+// PodNotificationStatus is the outcome of notifying one pod
+type PodNotificationStatus struct {
+	// Pod is the name of the notified pod
+	Pod string `json:"pod"`
+
+	// Succeeded is true if the pod answered with a 2xx status
+	Succeeded bool `json:"succeeded"`
+
+	// Error describes why the notification failed
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // +kubebuilder:object:root=true
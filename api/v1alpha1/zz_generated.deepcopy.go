@@ -25,6 +25,113 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConfig) DeepCopyInto(out *AlertConfig) {
+	*out = *in
+	if in.WebhookTemplate != nil {
+		in, out := &in.WebhookTemplate, &out.WebhookTemplate
+		*out = new(WebhookTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConfig.
+func (in *AlertConfig) DeepCopy() *AlertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarsConfig) DeepCopyInto(out *EnvVarsConfig) {
+	*out = *in
+	if in.ContainerNames != nil {
+		in, out := &in.ContainerNames, &out.ContainerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarsConfig.
+func (in *EnvVarsConfig) DeepCopy() *EnvVarsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotifyConfig) DeepCopyInto(out *NotifyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifyConfig.
+func (in *NotifyConfig) DeepCopy() *NotifyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotifyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodNotificationStatus) DeepCopyInto(out *PodNotificationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNotificationStatus.
+func (in *PodNotificationStatus) DeepCopy() *PodNotificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNotificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReloadHistoryEntry) DeepCopyInto(out *ReloadHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Duration = in.Duration
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]ReloadHistoryTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadHistoryEntry.
+func (in *ReloadHistoryEntry) DeepCopy() *ReloadHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ReloadHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReloadHistoryTarget) DeepCopyInto(out *ReloadHistoryTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadHistoryTarget.
+func (in *ReloadHistoryTarget) DeepCopy() *ReloadHistoryTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ReloadHistoryTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReloaderConfig) DeepCopyInto(out *ReloaderConfig) {
 	*out = *in
@@ -95,7 +202,9 @@ func (in *ReloaderConfigSpec) DeepCopyInto(out *ReloaderConfigSpec) {
 	if in.Targets != nil {
 		in, out := &in.Targets, &out.Targets
 		*out = make([]TargetWorkload, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.IgnoreResources != nil {
 		in, out := &in.IgnoreResources, &out.IgnoreResources
@@ -109,6 +218,21 @@ func (in *ReloaderConfigSpec) DeepCopyInto(out *ReloaderConfigSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(AlertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notify != nil {
+		in, out := &in.Notify, &out.Notify
+		*out = new(NotifyConfig)
+		**out = **in
+	}
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = new(EnvVarsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloaderConfigSpec.
@@ -142,6 +266,20 @@ func (in *ReloaderConfigStatus) DeepCopyInto(out *ReloaderConfigStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.WatchedObjectPaths != nil {
+		in, out := &in.WatchedObjectPaths, &out.WatchedObjectPaths
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WatchedResources != nil {
+		in, out := &in.WatchedResources, &out.WatchedResources
+		*out = make([]WatchedResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.TargetStatus != nil {
 		in, out := &in.TargetStatus, &out.TargetStatus
 		*out = make([]TargetWorkloadStatus, len(*in))
@@ -149,6 +287,13 @@ func (in *ReloaderConfigStatus) DeepCopyInto(out *ReloaderConfigStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReloadHistory != nil {
+		in, out := &in.ReloadHistory, &out.ReloadHistory
+		*out = make([]ReloadHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloaderConfigStatus.
@@ -179,6 +324,11 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetWorkload) DeepCopyInto(out *TargetWorkload) {
 	*out = *in
+	if in.Notify != nil {
+		in, out := &in.Notify, &out.Notify
+		*out = new(NotifyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetWorkload.
@@ -202,6 +352,11 @@ func (in *TargetWorkloadStatus) DeepCopyInto(out *TargetWorkloadStatus) {
 		in, out := &in.PausedUntil, &out.PausedUntil
 		*out = (*in).DeepCopy()
 	}
+	if in.PodNotifications != nil {
+		in, out := &in.PodNotifications, &out.PodNotifications
+		*out = make([]PodNotificationStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetWorkloadStatus.
@@ -214,6 +369,54 @@ func (in *TargetWorkloadStatus) DeepCopy() *TargetWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchedObject) DeepCopyInto(out *WatchedObject) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JSONPaths != nil {
+		in, out := &in.JSONPaths, &out.JSONPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchedObject.
+func (in *WatchedObject) DeepCopy() *WatchedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchedResourceStatus) DeepCopyInto(out *WatchedResourceStatus) {
+	*out = *in
+	if in.LastChangeTime != nil {
+		in, out := &in.LastChangeTime, &out.LastChangeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReloadTime != nil {
+		in, out := &in.LastReloadTime, &out.LastReloadTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchedResourceStatus.
+func (in *WatchedResourceStatus) DeepCopy() *WatchedResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchedResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WatchedResources) DeepCopyInto(out *WatchedResources) {
 	*out = *in
@@ -227,6 +430,13 @@ func (in *WatchedResources) DeepCopyInto(out *WatchedResources) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]WatchedObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NamespaceSelector != nil {
 		in, out := &in.NamespaceSelector, &out.NamespaceSelector
 		*out = new(v1.LabelSelector)
@@ -248,3 +458,25 @@ func (in *WatchedResources) DeepCopy() *WatchedResources {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTemplate) DeepCopyInto(out *WebhookTemplate) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTemplate.
+func (in *WebhookTemplate) DeepCopy() *WebhookTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
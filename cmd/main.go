@@ -21,6 +21,7 @@ import (
 	"flag"
 	"os"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -31,6 +32,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -40,6 +42,9 @@ import (
 	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
 	"github.com/stakater/Reloader/internal/controller"
 	"github.com/stakater/Reloader/internal/pkg/alerts"
+	"github.com/stakater/Reloader/internal/pkg/cloudevents"
+	"github.com/stakater/Reloader/internal/pkg/sharding"
+	"github.com/stakater/Reloader/internal/pkg/util"
 	"github.com/stakater/Reloader/internal/pkg/workload"
 	// +kubebuilder:scaffold:imports
 )
@@ -74,8 +79,39 @@ func main() {
 	var alertSink string
 	var alertWebhookURL string
 	var alertAdditionalInfo string
+	var alertPagerDutyRoutingKey string
+	var alertOpsgenieAPIKey string
+	var alertSMTPAddress string
+	var alertSMTPUsername string
+	var alertSMTPPassword string
+	var alertEmailFrom string
+	var alertEmailTo string
+	var alertQueueSize int
+	var alertMaxRetries int
+	var alertMaxAge time.Duration
+	var alertWebhookTemplateFile string
+	var alertWebhookContentType string
+	var alertWebhookHeaders string
+	var alertDigestMode string
+	var alertDigestWindow time.Duration
+	var alertDigestImmediateFailures bool
+	var cloudEventsSinkURL string
+	var cloudEventsMode string
+	var cloudEventsSource string
 	var rolloutStrategy string
 	var reloadStrategy string
+	var envVarPrefix string
+	var envVarContainers string
+	var envVarContainerNames string
+	var maxConcurrentReconciles int
+	var maxConcurrentReloads int
+	var reloadHistoryLimit int
+	var enableSharding bool
+	var shardLeaseNamespace string
+	var shardIdentity string
+	var shardLeaseDuration time.Duration
+	var annotationPrefix string
+	var reloaderClassName string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -107,15 +143,82 @@ func main() {
 	flag.BoolVar(&alertOnReload, "alert-on-reload", false,
 		"Send alerts when workloads are reloaded")
 	flag.StringVar(&alertSink, "alert-sink", "webhook",
-		"Alert sink type: 'slack', 'teams', 'gchat', or 'webhook' (default: webhook)")
+		"Alert sink type: 'slack', 'teams', 'gchat', 'webhook', 'pagerduty', 'opsgenie', 'discord', 'mattermost' "+
+			"or 'email' (default: webhook)")
 	flag.StringVar(&alertWebhookURL, "alert-webhook-url", "",
-		"Webhook URL for sending reload alerts (required if alert-on-reload is true)")
+		"Webhook URL for sending reload alerts (required for webhook-style sinks; "+
+			"overrides the API endpoint for pagerduty and opsgenie)")
+	flag.StringVar(&alertWebhookTemplateFile, "alert-webhook-template-file", "",
+		"Path to a Go text/template file used as the payload of the 'webhook' sink instead of the Slack-shaped default")
+	flag.StringVar(&alertWebhookContentType, "alert-webhook-content-type", alerts.DefaultTemplateContentType,
+		"Content-Type of the templated 'webhook' sink payload")
+	flag.StringVar(&alertWebhookHeaders, "alert-webhook-headers", "",
+		"Comma-separated list of Name=value HTTP headers added to templated 'webhook' sink requests")
+	flag.StringVar(&alertDigestMode, "alert-digest", alerts.DigestOff,
+		"Group reload alerts into digests: 'off', 'change' (one digest per resource change) "+
+			"or 'window' (one digest per alert-digest-window)")
+	flag.DurationVar(&alertDigestWindow, "alert-digest-window", alerts.DefaultDigestWindow,
+		"How long reload alerts are collected before a digest is sent")
+	flag.BoolVar(&alertDigestImmediateFailures, "alert-digest-immediate-failures", false,
+		"Send failed reloads immediately even when digests are enabled (they are still listed in the digest)")
+	flag.StringVar(&cloudEventsSinkURL, "cloudevents-sink-url", "",
+		"HTTP endpoint that receives reload lifecycle CloudEvents (e.g., a Knative Broker); disabled when empty")
+	flag.StringVar(&cloudEventsMode, "cloudevents-mode", cloudevents.ModeStructured,
+		"CloudEvents HTTP content mode: 'structured' or 'binary'")
+	flag.StringVar(&cloudEventsSource, "cloudevents-source", cloudevents.DefaultSource,
+		"CloudEvents source attribute identifying this operator instance")
+	flag.StringVar(&alertPagerDutyRoutingKey, "alert-pagerduty-routing-key", "",
+		"PagerDuty Events API v2 routing key (required for the pagerduty sink)")
+	flag.StringVar(&alertOpsgenieAPIKey, "alert-opsgenie-api-key", "",
+		"Opsgenie API integration key (required for the opsgenie sink)")
+	flag.StringVar(&alertSMTPAddress, "alert-smtp-address", "",
+		"SMTP server address as host:port (required for the email sink)")
+	flag.StringVar(&alertSMTPUsername, "alert-smtp-username", "",
+		"SMTP username; enables PLAIN authentication when set")
+	flag.StringVar(&alertSMTPPassword, "alert-smtp-password", "",
+		"SMTP password")
+	flag.StringVar(&alertEmailFrom, "alert-email-from", "",
+		"Sender address for email alerts")
+	flag.StringVar(&alertEmailTo, "alert-email-to", "",
+		"Comma-separated list of recipient addresses for email alerts")
 	flag.StringVar(&alertAdditionalInfo, "alert-additional-info", "",
 		"Additional information to include in alert messages")
+	flag.IntVar(&alertQueueSize, "alert-queue-size", alerts.DefaultQueueSize,
+		"Maximum number of pending alert deliveries; new alerts are dropped when the queue is full")
+	flag.IntVar(&alertMaxRetries, "alert-max-retries", alerts.DefaultQueueMaxRetries,
+		"Number of times a failed alert delivery is retried (with exponential backoff) before it is dead-lettered")
+	flag.DurationVar(&alertMaxAge, "alert-max-age", alerts.DefaultQueueMaxAge,
+		"Maximum time an alert may wait in the queue before it is dead-lettered")
 	flag.StringVar(&rolloutStrategy, "rollout-strategy", "rollout",
-		"Default rollout strategy: 'rollout' (modify template) or 'restart' (delete pods)")
+		"Default rollout strategy: 'rollout' (modify template), 'restart' (delete pods) or 'volume-refresh' (resync mounted volumes)")
 	flag.StringVar(&reloadStrategy, "reload-strategy", "env-vars",
-		"Default reload strategy when rollout-strategy is 'rollout': 'env-vars' or 'annotations'")
+		"Default reload strategy when rollout-strategy is 'rollout': 'env-vars', 'annotations', 'restarted-at' or 'versioned-copy'")
+	flag.StringVar(&envVarPrefix, "env-var-prefix", util.EnvVarPrefix,
+		"Prefix of the environment variables injected by the env-vars reload strategy")
+	flag.StringVar(&envVarContainers, "env-var-containers", util.EnvVarContainersFirst,
+		"Containers receiving the env-vars reload strategy's variables: 'first', 'all', 'referencing' or 'named'")
+	flag.StringVar(&envVarContainerNames, "env-var-container-names", "",
+		"Comma-separated container names receiving the variables when --env-var-containers=named")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Maximum number of concurrent reconciles per controller")
+	flag.IntVar(&maxConcurrentReloads, "max-concurrent-reloads", 5,
+		"Maximum number of workloads reloaded in parallel for one change")
+	flag.IntVar(&reloadHistoryLimit, "reload-history-limit", 10,
+		"Number of reloads kept in each ReloaderConfig's status.reloadHistory (0 disables the history, at most 100)")
+	flag.BoolVar(&enableSharding, "enable-sharding", false,
+		"Split the reconcile work and reloads of namespaces across all replicas, which coordinate through Leases "+
+			"(incompatible with --leader-elect). Informers are not sharded: every replica caches and watches all namespaces")
+	flag.StringVar(&shardLeaseNamespace, "shard-lease-namespace", os.Getenv("POD_NAMESPACE"),
+		"Namespace of the shard Leases (defaults to $POD_NAMESPACE)")
+	flag.StringVar(&shardIdentity, "shard-identity", defaultShardIdentity(),
+		"Identity of this replica in the shard group (defaults to $POD_NAME or the hostname)")
+	flag.DurationVar(&shardLeaseDuration, "shard-lease-duration", sharding.DefaultLeaseDuration,
+		"How long a replica keeps its namespaces without renewing its shard Lease")
+	flag.StringVar(&annotationPrefix, "annotation-prefix", util.DefaultAnnotationPrefix,
+		"The domain of the annotation keys (e.g., reloader.example.com to run next to Stakater Reloader)")
+	flag.StringVar(&reloaderClassName, "reloader-class-name", "",
+		"Only process ReloaderConfigs with this spec.reloaderClassName, and workloads and resources with this class "+
+			"annotation (resources without one are shared); empty means the objects without a class")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -124,6 +227,38 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// With sharding every replica works, so a single leader would defeat it
+	if enableSharding && enableLeaderElection {
+		setupLog.Error(nil, "--enable-sharding and --leader-elect are mutually exclusive")
+		os.Exit(1)
+	}
+
+	// The history is stored in the ReloaderConfig status, whose schema allows at most 100 entries
+	if reloadHistoryLimit < 0 || reloadHistoryLimit > 100 {
+		setupLog.Error(nil, "--reload-history-limit must be between 0 and 100", "limit", reloadHistoryLimit)
+		os.Exit(1)
+	}
+
+	// The keys must be final before any controller reads them
+	if err := util.ConfigureAnnotationKeys(annotationPrefix, reloaderClassName); err != nil {
+		setupLog.Error(err, "invalid annotation settings")
+		os.Exit(1)
+	}
+	if annotationPrefix != util.DefaultAnnotationPrefix || reloaderClassName != "" {
+		setupLog.Info("Annotation keys configured", "prefix", annotationPrefix, "class", reloaderClassName)
+	}
+
+	// The env-vars reload strategy settings, which a ReloaderConfig can override
+	envVarsConfig := reloaderv1alpha1.EnvVarsConfig{
+		Prefix:         envVarPrefix,
+		Containers:     envVarContainers,
+		ContainerNames: util.ParseCommaSeparatedList(envVarContainerNames),
+	}
+	if err := workload.ValidateEnvVarsConfig(envVarsConfig); err != nil {
+		setupLog.Error(err, "invalid env var settings")
+		os.Exit(1)
+	}
+
 	// Parse the resource label selector
 	var resourceSelector labels.Selector
 	if resourceLabelSelector != "" {
@@ -241,11 +376,12 @@ func main() {
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  controller.CacheOptions(),
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "7a47c3f6.stakater.com",
+		LeaderElectionID:       leaderElectionID(reloaderClassName),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -264,25 +400,139 @@ func main() {
 	}
 
 	// Initialize the controller with workload finder, updater, and alert manager
-	// Validate alert configuration
-	if alertOnReload && alertWebhookURL == "" {
-		setupLog.Error(nil, "alert-webhook-url is required when alert-on-reload is enabled")
-		os.Exit(1)
+	alertManager := alerts.NewAlertManager(mgr.GetClient(), alertOnReload, alertSink, alertWebhookURL, alertAdditionalInfo)
+	alertManager.SinkConfig = alerts.SinkConfig{
+		PagerDutyRoutingKey: alertPagerDutyRoutingKey,
+		OpsgenieAPIKey:      alertOpsgenieAPIKey,
+		SMTPAddress:         alertSMTPAddress,
+		SMTPUsername:        alertSMTPUsername,
+		SMTPPassword:        alertSMTPPassword,
+		EmailFrom:           alertEmailFrom,
+		EmailTo:             util.ParseCommaSeparatedList(alertEmailTo),
 	}
 
+	// Alerts are delivered by a background queue so reloads never wait on webhook I/O
+	if alertOnReload {
+		// Validate alert configuration
+		if err := alertManager.Validate(); err != nil {
+			setupLog.Error(err, "invalid alert configuration")
+			os.Exit(1)
+		}
+
+		// Template errors are fatal at startup rather than failing every alert later
+		if alertWebhookTemplateFile != "" {
+			webhookTemplate, err := loadWebhookTemplate(alertWebhookTemplateFile, alertWebhookContentType, alertWebhookHeaders)
+			if err != nil {
+				setupLog.Error(err, "invalid alert webhook template", "file", alertWebhookTemplateFile)
+				os.Exit(1)
+			}
+			alertManager.WebhookTemplate = webhookTemplate
+		}
+
+		// Digests replace one alert per reload with one summary per change or window
+		if err := alerts.ValidateDigestMode(alertDigestMode); err != nil {
+			setupLog.Error(err, "invalid alert configuration")
+			os.Exit(1)
+		}
+		if digest := alertManager.EnableDigest(alerts.DigestOptions{
+			Mode:              alertDigestMode,
+			Window:            alertDigestWindow,
+			ImmediateFailures: alertDigestImmediateFailures,
+		}); digest != nil {
+			if err := mgr.Add(digest); err != nil {
+				setupLog.Error(err, "unable to set up alert digest")
+				os.Exit(1)
+			}
+		}
+
+		alertManager.Queue = alerts.NewQueue(alerts.QueueOptions{
+			Size:       alertQueueSize,
+			MaxRetries: alertMaxRetries,
+			MaxAge:     alertMaxAge,
+		})
+		if err := mgr.Add(alertManager.Queue); err != nil {
+			setupLog.Error(err, "unable to set up alert queue")
+			os.Exit(1)
+		}
+	}
+
+	// CloudEvents share the alert queue settings but use their own queue
+	var eventEmitter *cloudevents.Emitter
+	if cloudEventsSinkURL != "" {
+		eventEmitter, err = cloudevents.NewEmitter(cloudEventsSinkURL, cloudEventsSource, cloudEventsMode)
+		if err != nil {
+			setupLog.Error(err, "invalid CloudEvents configuration")
+			os.Exit(1)
+		}
+
+		eventEmitter.Queue = alerts.NewQueue(alerts.QueueOptions{
+			Name:       cloudevents.QueueName,
+			Size:       alertQueueSize,
+			MaxRetries: alertMaxRetries,
+			MaxAge:     alertMaxAge,
+		})
+		if err := mgr.Add(eventEmitter.Queue); err != nil {
+			setupLog.Error(err, "unable to set up CloudEvents queue")
+			os.Exit(1)
+		}
+	}
+
+	// Sharded replicas each own a slice of the namespaces instead of electing a leader
+	var shardCoordinator *sharding.Coordinator
+	if enableSharding {
+		// Leases are read directly: caching them would watch every Lease in the cluster
+		leaseClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+		if err != nil {
+			setupLog.Error(err, "unable to create shard lease client")
+			os.Exit(1)
+		}
+
+		shardCoordinator = sharding.NewCoordinator(leaseClient, shardLeaseNamespace, shardIdentity)
+		shardCoordinator.LeaseDuration = shardLeaseDuration
+		if reloaderClassName != "" {
+			// Instances of different classes own their namespaces independently
+			shardCoordinator.Group = sharding.DefaultGroup + "-" + reloaderClassName
+		}
+		if err := shardCoordinator.Validate(); err != nil {
+			setupLog.Error(err, "invalid sharding configuration")
+			os.Exit(1)
+		}
+		if err := mgr.Add(shardCoordinator); err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+		setupLog.Info("Sharding enabled", "identity", shardIdentity, "leaseNamespace", shardLeaseNamespace)
+	}
+
+	// Versioned copies need the full Secret/ConfigMap data, which the cache strips
+	workloadUpdater := workload.NewUpdater(mgr.GetClient())
+	workloadUpdater.APIReader = mgr.GetAPIReader()
+	workloadUpdater.EnvVars = envVarsConfig
+
+	// SecretProviderClasses are read as unstructured objects, which the client does not cache
+	workloadFinder := workload.NewFinder(mgr.GetClient())
+	workloadFinder.ClassName = reloaderClassName
+	workloadFinder.SecretProviderClassReader = mgr.GetCache()
+
 	reconciler := &controller.ReloaderConfigReconciler{
-		Client:                mgr.GetClient(),
-		Scheme:                mgr.GetScheme(),
-		WorkloadFinder:        workload.NewFinder(mgr.GetClient()),
-		WorkloadUpdater:       workload.NewUpdater(mgr.GetClient()),
-		AlertManager:          alerts.NewAlertManager(mgr.GetClient(), alertOnReload, alertSink, alertWebhookURL, alertAdditionalInfo),
-		ReloadOnCreate:        reloadOnCreate,
-		ReloadOnDelete:        reloadOnDelete,
-		RolloutStrategy:       rolloutStrategy,
-		ReloadStrategy:        reloadStrategy,
-		ResourceLabelSelector: resourceSelector,
-		NamespaceSelector:     namespaceFilter,
-		IgnoredNamespaces:     ignoredNamespaces,
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		WorkloadFinder:          workloadFinder,
+		WorkloadUpdater:         workloadUpdater,
+		AlertManager:            alertManager,
+		EventEmitter:            eventEmitter,
+		Sharding:                shardCoordinator,
+		ReloadOnCreate:          reloadOnCreate,
+		ReloadOnDelete:          reloadOnDelete,
+		RolloutStrategy:         rolloutStrategy,
+		ReloadStrategy:          reloadStrategy,
+		ResourceLabelSelector:   resourceSelector,
+		NamespaceSelector:       namespaceFilter,
+		IgnoredNamespaces:       ignoredNamespaces,
+		ClassName:               reloaderClassName,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		MaxConcurrentReloads:    maxConcurrentReloads,
+		ReloadHistoryLimit:      reloadHistoryLimit,
 	}
 
 	if err := reconciler.SetupWithManager(mgr); err != nil {
@@ -306,3 +556,35 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// leaderElectionID returns the leader election Lease name, so instances of different classes each elect a leader
+func leaderElectionID(className string) string {
+	if className == "" {
+		return "7a47c3f6.stakater.com"
+	}
+	return className + ".7a47c3f6.stakater.com"
+}
+
+// defaultShardIdentity returns the pod name, falling back to the hostname
+func defaultShardIdentity() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// loadWebhookTemplate reads and validates the payload template for the webhook alert sink
+func loadWebhookTemplate(path, contentType, headerList string) (*alerts.WebhookTemplate, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := alerts.ParseHeaders(util.ParseCommaSeparatedList(headerList))
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts.NewWebhookTemplate(string(payload), contentType, headers)
+}
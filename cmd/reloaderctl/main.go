@@ -0,0 +1,371 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// reloaderctl answers questions about the Reloader operator against the live cluster.
+// Installed on the PATH as kubectl-reloader, it also runs as `kubectl reloader`.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/controller"
+	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
+)
+
+const usage = `reloaderctl inspects what the Reloader operator would do.
+
+Usage:
+  reloaderctl explain <secret|configmap>/<name> [-n <namespace>] [flags]
+
+Commands:
+  explain   List the workloads a change of a Secret or ConfigMap would reload, and why
+
+Run "reloaderctl explain -h" for the flags.
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(reloaderv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	// The shared code logs through controller-runtime; only the command's output matters here
+	log.SetLogger(logr.Discard())
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "explain":
+		err = runExplain(context.Background(), os.Args[2:], os.Stdout)
+	case "help", "-h", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// explainOptions are the flags of the explain command
+// The operator flags must match the running operator for the skip reasons to be accurate.
+type explainOptions struct {
+	kubeconfig  string
+	kubeContext string
+	namespace   string
+	output      string
+
+	annotationPrefix      string
+	reloaderClassName     string
+	rolloutStrategy       string
+	reloadStrategy        string
+	resourceLabelSelector string
+	namespaceSelector     string
+	namespacesToIgnore    string
+}
+
+// runExplain implements `reloaderctl explain <kind>/<name>`
+func runExplain(ctx context.Context, args []string, out io.Writer) error {
+	opts := explainOptions{}
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	fs.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	fs.StringVar(&opts.kubeContext, "context", "", "The kubeconfig context to use")
+	fs.StringVar(&opts.namespace, "namespace", "", "Namespace of the resource (default: the context's namespace)")
+	fs.StringVar(&opts.namespace, "n", "", "Shorthand for --namespace")
+	fs.StringVar(&opts.output, "output", "table", "Output format: table or json")
+	fs.StringVar(&opts.output, "o", "table", "Shorthand for --output")
+	fs.StringVar(&opts.annotationPrefix, "annotation-prefix", util.DefaultAnnotationPrefix, "The operator's --annotation-prefix")
+	fs.StringVar(&opts.reloaderClassName, "reloader-class-name", "", "The operator's --reloader-class-name")
+	fs.StringVar(&opts.rolloutStrategy, "rollout-strategy", util.RolloutStrategyRollout, "The operator's --rollout-strategy")
+	fs.StringVar(&opts.reloadStrategy, "reload-strategy", util.ReloadStrategyEnvVars, "The operator's --reload-strategy")
+	fs.StringVar(&opts.resourceLabelSelector, "resource-label-selector", "", "The operator's --resource-label-selector")
+	fs.StringVar(&opts.namespaceSelector, "namespace-selector", "", "The operator's --namespace-selector")
+	fs.StringVar(&opts.namespacesToIgnore, "namespaces-to-ignore", "", "The operator's --namespaces-to-ignore")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: reloaderctl explain <secret|configmap>/<name> [-n <namespace>] [flags]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	// Flags may come before or after the resource, like in kubectl
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected exactly one resource, got %d", len(positional))
+	}
+	if opts.output != "table" && opts.output != "json" {
+		return fmt.Errorf("unsupported output format %q (use table or json)", opts.output)
+	}
+
+	kind, name, err := parseResourceRef(positional[0])
+	if err != nil {
+		return err
+	}
+	if err := util.ConfigureAnnotationKeys(opts.annotationPrefix, opts.reloaderClassName); err != nil {
+		return err
+	}
+
+	c, namespace, err := newClient(opts)
+	if err != nil {
+		return err
+	}
+
+	reconciler, err := newExplainReconciler(c, opts)
+	if err != nil {
+		return err
+	}
+
+	explanation, err := reconciler.ExplainResourceChange(ctx, kind, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		return printExplanationJSON(out, explanation)
+	}
+	return printExplanationTable(out, explanation)
+}
+
+// parseResourceRef parses <kind>/<name>, accepting the kubectl spellings of the kinds
+func parseResourceRef(ref string) (string, string, error) {
+	kind, name, ok := strings.Cut(ref, "/")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid resource %q: expected <secret|configmap>/<name>", ref)
+	}
+
+	switch strings.ToLower(kind) {
+	case "secret", "secrets":
+		return util.KindSecret, name, nil
+	case "configmap", "configmaps", "cm":
+		return util.KindConfigMap, name, nil
+	}
+	return "", "", fmt.Errorf("unsupported resource kind %q: expected secret or configmap", kind)
+}
+
+// newClient creates an uncached client from the kubeconfig and resolves the namespace
+func newClient(opts explainOptions) (client.Client, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = opts.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{CurrentContext: opts.kubeContext})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	namespace := opts.namespace
+	if namespace == "" {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	return c, namespace, err
+}
+
+// newExplainReconciler configures a reconciler like the operator, but without a manager: it only reads
+func newExplainReconciler(c client.Client, opts explainOptions) (*controller.ReloaderConfigReconciler, error) {
+	resourceSelector, err := labels.Parse(opts.resourceLabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --resource-label-selector: %w", err)
+	}
+	namespaceSelector, err := labels.Parse(opts.namespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --namespace-selector: %w", err)
+	}
+	ignoredNamespaces := map[string]bool{}
+	for _, namespace := range util.ParseCommaSeparatedList(opts.namespacesToIgnore) {
+		ignoredNamespaces[namespace] = true
+	}
+
+	finder := workload.NewFinder(c)
+	finder.ClassName = opts.reloaderClassName
+
+	return &controller.ReloaderConfigReconciler{
+		Client:                c,
+		Scheme:                scheme,
+		WorkloadFinder:        finder,
+		WorkloadUpdater:       workload.NewUpdater(c),
+		RolloutStrategy:       opts.rolloutStrategy,
+		ReloadStrategy:        opts.reloadStrategy,
+		ResourceLabelSelector: resourceSelector,
+		NamespaceSelector:     namespaceSelector,
+		IgnoredNamespaces:     ignoredNamespaces,
+		ClassName:             opts.reloaderClassName,
+	}, nil
+}
+
+// explainedResource is the JSON output of the explain command
+type explainedResource struct {
+	Kind            string            `json:"kind"`
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	Found           bool              `json:"found"`
+	SkipReason      string            `json:"skipReason,omitempty"`
+	IgnoringConfigs []string          `json:"ignoringConfigs,omitempty"`
+	Targets         []explainedTarget `json:"targets"`
+}
+
+// explainedTarget is one target in the JSON output of the explain command
+type explainedTarget struct {
+	Kind            string `json:"kind"`
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	Rule            string `json:"rule"`
+	Config          string `json:"config,omitempty"`
+	RolloutStrategy string `json:"rolloutStrategy"`
+	ReloadStrategy  string `json:"reloadStrategy,omitempty"`
+	PausePeriod     string `json:"pausePeriod,omitempty"`
+	Paused          bool   `json:"paused"`
+	Reload          bool   `json:"reload"`
+	SkipReason      string `json:"skipReason,omitempty"`
+}
+
+func printExplanationJSON(out io.Writer, explanation *controller.ResourceExplanation) error {
+	result := explainedResource{
+		Kind:            explanation.Kind,
+		Name:            explanation.Name,
+		Namespace:       explanation.Namespace,
+		Found:           explanation.Found,
+		SkipReason:      explanation.SkipReason,
+		IgnoringConfigs: explanation.IgnoringConfigs,
+		Targets:         []explainedTarget{},
+	}
+	for _, target := range explanation.Targets {
+		explained := explainedTarget{
+			Kind:            target.Kind,
+			Name:            target.Name,
+			Namespace:       target.Namespace,
+			Rule:            target.Rule,
+			Config:          targetConfigName(target),
+			RolloutStrategy: target.RolloutStrategy,
+			PausePeriod:     target.PausePeriod,
+			Paused:          target.Paused,
+			Reload:          target.SkipReason == "",
+			SkipReason:      target.SkipReason,
+		}
+		if target.RolloutStrategy == util.RolloutStrategyRollout {
+			explained.ReloadStrategy = target.ReloadStrategy
+		}
+		result.Targets = append(result.Targets, explained)
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func printExplanationTable(out io.Writer, explanation *controller.ResourceExplanation) error {
+	fmt.Fprintf(out, "%s %s/%s", explanation.Kind, explanation.Namespace, explanation.Name)
+	if !explanation.Found {
+		fmt.Fprint(out, " (not found: the targets below apply once it is created)")
+	}
+	fmt.Fprintln(out)
+	if explanation.SkipReason != "" {
+		fmt.Fprintf(out, "Changes are not processed: %s\n", explanation.SkipReason)
+	}
+	for _, config := range explanation.IgnoringConfigs {
+		fmt.Fprintf(out, "ReloaderConfig %s watches it but lists it in spec.ignoreResources\n", config)
+	}
+	fmt.Fprintln(out)
+
+	if len(explanation.Targets) == 0 {
+		fmt.Fprintln(out, "No workloads would be reloaded.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WORKLOAD\tNAMESPACE\tRULE\tCONFIG\tSTRATEGY\tPAUSE\tRESULT")
+	for _, target := range explanation.Targets {
+		strategy := target.RolloutStrategy
+		if target.RolloutStrategy == util.RolloutStrategyRollout {
+			strategy += "/" + target.ReloadStrategy
+		}
+
+		pause := valueOrDash(target.PausePeriod)
+		if target.Paused {
+			pause += " (paused)"
+		}
+
+		result := "reload"
+		if target.SkipReason != "" {
+			result = "skip: " + target.SkipReason
+		}
+
+		fmt.Fprintf(w, "%s/%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			target.Kind, target.Name, target.Namespace, target.Rule,
+			valueOrDash(targetConfigName(target)), strategy, pause, result)
+	}
+	return w.Flush()
+}
+
+// targetConfigName returns <namespace>/<name> of the ReloaderConfig that selected a target, or ""
+func targetConfigName(target controller.TargetExplanation) string {
+	if target.Config == nil {
+		return ""
+	}
+	return target.Config.Namespace + "/" + target.Config.Name
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/stakater/Reloader/internal/pkg/alerts"
+	"github.com/stakater/Reloader/internal/pkg/cloudevents"
+	"github.com/stakater/Reloader/internal/pkg/workload"
+)
+
+// emitEvent emits a reload lifecycle CloudEvent if an event sink is configured
+//
+// Business Logic:
+// CloudEvents are a side channel for automation (cache warmers, smoke tests).
+// Like alerts, a delivery problem must never affect the reload itself, so
+// errors are only logged.
+func (r *ReloaderConfigReconciler) emitEvent(ctx context.Context, eventType string, message *alerts.Message) {
+	if r.EventEmitter == nil {
+		return
+	}
+
+	if err := r.EventEmitter.Emit(ctx, eventType, message); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to emit CloudEvent", "type", eventType)
+	}
+}
+
+// emitResourceChanged emits a resource-changed event for a Secret/ConfigMap
+// previousHash is empty for creations, and both hashes are empty for deletions.
+func (r *ReloaderConfigReconciler) emitResourceChanged(
+	ctx context.Context,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	currentHash string,
+) {
+	if r.EventEmitter == nil {
+		return
+	}
+
+	r.emitEvent(ctx, cloudevents.TypeResourceChanged, &alerts.Message{
+		Title:                "Resource Changed",
+		Text:                 fmt.Sprintf("%s %s/%s changed", resourceKind, resourceNamespace, resourceName),
+		Timestamp:            time.Now(),
+		ResourceKind:         resourceKind,
+		ResourceName:         resourceName,
+		ResourceNamespace:    resourceNamespace,
+		ResourceHash:         currentHash,
+		PreviousResourceHash: previousHash,
+		Fields:               make(map[string]string),
+	})
+}
+
+// emitReloadStarted emits a reload-started event for a workload about to be reloaded
+// Unlike the other reload events, it has no alert counterpart.
+func (r *ReloaderConfigReconciler) emitReloadStarted(
+	ctx context.Context,
+	target workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
+) {
+	if r.EventEmitter == nil {
+		return
+	}
+
+	message := &alerts.Message{
+		Title:                "Reload Started",
+		Text:                 fmt.Sprintf("Reloading %s/%s due to %s change", target.Kind, target.Name, resourceKind),
+		Timestamp:            time.Now(),
+		WorkloadKind:         target.Kind,
+		WorkloadName:         target.Name,
+		WorkloadNamespace:    target.Namespace,
+		ResourceKind:         resourceKind,
+		ResourceName:         resourceName,
+		ResourceNamespace:    resourceNamespace,
+		ResourceHash:         resourceHash,
+		PreviousResourceHash: previousHash,
+		ReloadStrategy:       target.ReloadStrategy,
+		Fields:               make(map[string]string),
+	}
+	if target.Config != nil {
+		message.Fields["ReloaderConfig"] = target.Config.Name
+	}
+
+	r.emitEvent(ctx, cloudevents.TypeReloadStarted, message)
+}
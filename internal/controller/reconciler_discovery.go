@@ -56,6 +56,32 @@ func (r *ReloaderConfigReconciler) discoverTargets(
 	resourceKind string,
 	resourceName string,
 	resourceNamespace string,
+) ([]workload.Target, []*reloaderv1alpha1.ReloaderConfig, error) {
+	// Get the resource to access its annotations for targeted reload (search + match)
+	var resourceAnnotations map[string]string
+	if resourceKind == util.KindSecret {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Name: resourceName, Namespace: resourceNamespace}, secret); err == nil {
+			resourceAnnotations = secret.Annotations
+		}
+	} else if resourceKind == util.KindConfigMap {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Name: resourceName, Namespace: resourceNamespace}, cm); err == nil {
+			resourceAnnotations = cm.Annotations
+		}
+	}
+
+	return r.discoverTargetsWithAnnotations(ctx, resourceKind, resourceName, resourceNamespace, resourceAnnotations)
+}
+
+// discoverTargetsWithAnnotations is discoverTargets for a resource whose annotations are already known
+// (e.g., a deleted resource, whose annotations come from its tombstone)
+func (r *ReloaderConfigReconciler) discoverTargetsWithAnnotations(
+	ctx context.Context,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	resourceAnnotations map[string]string,
 ) ([]workload.Target, []*reloaderv1alpha1.ReloaderConfig, error) {
 	logger := log.FromContext(ctx)
 
@@ -81,20 +107,6 @@ func (r *ReloaderConfigReconciler) discoverTargets(
 	}
 	reloaderConfigs = filteredConfigs
 
-	// Get the resource to access its annotations for targeted reload (search + match)
-	var resourceAnnotations map[string]string
-	if resourceKind == util.KindSecret {
-		secret := &corev1.Secret{}
-		if err := r.Get(ctx, client.ObjectKey{Name: resourceName, Namespace: resourceNamespace}, secret); err == nil {
-			resourceAnnotations = secret.Annotations
-		}
-	} else if resourceKind == util.KindConfigMap {
-		cm := &corev1.ConfigMap{}
-		if err := r.Get(ctx, client.ObjectKey{Name: resourceName, Namespace: resourceNamespace}, cm); err == nil {
-			resourceAnnotations = cm.Annotations
-		}
-	}
-
 	// Find workloads with annotation-based config
 	annotatedWorkloads, err := r.WorkloadFinder.FindWorkloadsWithAnnotations(
 		ctx, resourceKind, resourceName, resourceNamespace, resourceAnnotations)
@@ -233,20 +245,15 @@ func (r *ReloaderConfigReconciler) workloadReferencesResource(
 		return false, err
 	}
 
-	// Extract pod spec using consolidated utility function
-	podSpec, err := util.GetPodSpec(obj)
+	// Extract pod template using consolidated utility function
+	template, err := util.GetPodTemplate(obj)
 	if err != nil {
 		return false, err
 	}
 
-	// Check if pod spec references the resource
-	return workloadPodSpecReferencesResource(podSpec, resourceKind, resourceName), nil
-}
-
-// workloadPodSpecReferencesResource checks if a pod spec references a specific resource
-// This is the same logic used in annotation-based targeted reload
-func workloadPodSpecReferencesResource(podSpec *corev1.PodSpec, resourceKind, resourceName string) bool {
-	return util.CheckPodSpecReferencesResource(podSpec, resourceKind, resourceName)
+	// Check if the template references the resource (directly, through a SecretProviderClass
+	// or through a versioned copy). This is the same logic used in annotation-based targeted reload
+	return util.PodTemplateReferencesResource(ctx, r.WorkloadFinder.SecretProviderClasses(), template, target.Namespace, resourceKind, resourceName)
 }
 
 // workloadExists checks if a workload of the given kind exists
@@ -256,6 +263,11 @@ func (r *ReloaderConfigReconciler) workloadExists(ctx context.Context, kind, nam
 
 // shouldProcessNamespace checks if a namespace should be processed based on filters
 func (r *ReloaderConfigReconciler) shouldProcessNamespace(ctx context.Context, namespace string) bool {
+	// Namespaces owned by other replicas are handled there
+	if !r.ownsNamespace(namespace) {
+		return false
+	}
+
 	// Check if namespace is in the ignored list
 	if r.IgnoredNamespaces[namespace] {
 		return false
@@ -280,6 +292,14 @@ func (r *ReloaderConfigReconciler) shouldProcessNamespace(ctx context.Context, n
 	return true
 }
 
+// targetNotifyConfig returns the notify settings of a target, falling back to the ReloaderConfig's
+func targetNotifyConfig(config *reloaderv1alpha1.ReloaderConfig, target reloaderv1alpha1.TargetWorkload) *reloaderv1alpha1.NotifyConfig {
+	if target.Notify != nil {
+		return target.Notify
+	}
+	return config.Spec.Notify
+}
+
 // mergeTargets merges targets from ReloaderConfigs and annotation-based workloads
 func (r *ReloaderConfigReconciler) mergeTargets(
 	configs []*reloaderv1alpha1.ReloaderConfig,
@@ -303,6 +323,9 @@ func (r *ReloaderConfigReconciler) mergeTargets(
 				PausePeriod:      target.PausePeriod,
 				RequireReference: target.RequireReference,
 				Config:           config,
+				Notify:           targetNotifyConfig(config, target),
+				EnvVars:          config.Spec.EnvVars,
+				Rule:             workload.ReloadRuleReloaderConfig,
 			})
 		}
 	}
@@ -745,4 +745,113 @@ var _ = Describe("Discovery Functions", func() {
 			Expect(len(targets)).To(BeNumerically(">=", 2)) // Should find both targets
 		})
 	})
+
+	Context("When explaining a resource change", func() {
+		ctx := context.Background()
+
+		It("Should report the rule and skip reason of every target", func() {
+			// Paused annotated deployment
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "explain-paused",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationSecretReload:          "explain-secret",
+						util.AnnotationDeploymentPausePeriod: "1h",
+						util.AnnotationLastReload:            time.Now().UTC().Format(time.RFC3339),
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "explain"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "explain"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "app",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// ReloaderConfig targeting a workload that does not exist
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "explain-config",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Secrets: []string{"explain-secret"},
+					},
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "explain-missing",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			// ReloaderConfig ignoring the secret
+			ignoringConfig := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "explain-ignoring",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Secrets: []string{"explain-secret"},
+					},
+					IgnoreResources: []reloaderv1alpha1.ResourceReference{
+						{Kind: util.KindSecret, Name: "explain-secret"},
+					},
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "explain-paused",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, ignoringConfig)).To(Succeed())
+			defer k8sClient.Delete(ctx, ignoringConfig)
+
+			time.Sleep(2 * time.Second)
+
+			// The secret does not exist: the targets still apply once it is created
+			explanation, err := reconciler.ExplainResourceChange(ctx, util.KindSecret, "explain-secret", "default")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.Found).To(BeFalse())
+			Expect(explanation.SkipReason).To(BeEmpty())
+			Expect(explanation.IgnoringConfigs).To(ConsistOf("default/explain-ignoring"))
+			Expect(explanation.Targets).To(HaveLen(2))
+
+			for _, target := range explanation.Targets {
+				switch target.Name {
+				case "explain-missing":
+					Expect(target.Rule).To(Equal(workload.ReloadRuleReloaderConfig))
+					Expect(target.SkipReason).To(Equal("workload not found"))
+				case "explain-paused":
+					Expect(target.Rule).To(Equal(workload.ReloadRuleNamedReload))
+					Expect(target.Paused).To(BeTrue())
+					Expect(target.SkipReason).To(Equal("workload is in pause period"))
+				default:
+					Fail("unexpected target " + target.Name)
+				}
+			}
+		})
+	})
 })
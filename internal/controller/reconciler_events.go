@@ -19,16 +19,46 @@ package controller
 import (
 	"context"
 
-	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/stakater/Reloader/internal/pkg/util"
 )
 
-// reconcileResourceUpdate is a generic function that handles Secret/ConfigMap updates
+// reconcileResourceUpdate handles Secret/ConfigMap changes and triggers workload reloads
+//
+// Business Logic Flow:
+// This is the core reload logic that runs when a Secret or ConfigMap changes.
+//
+// 1. Hash Comparison:
+//   - Calculate SHA256 hash of Secret.Data (ConfigMap.Data and ConfigMap.BinaryData)
+//   - Compare with previously stored hash (in annotation)
+//   - If hashes match, no actual change occurred → skip reload (prevents reload storms)
+//
+// 2. Target Discovery:
+//   - Find ReloaderConfigs that watch this resource (CRD-based)
+//   - Find Deployments/StatefulSets/DaemonSets with reload annotations (annotation-based)
+//   - Merge both lists (supports hybrid configuration)
+//
+// 3. Reload Execution:
+//   - For each target workload:
+//     a. Check if it's in pause period (rate limiting)
+//     b. Trigger rolling restart (via env-vars or annotations strategy)
+//     c. Send alerts on success/failure
+//     d. Update status tracking
+//
+// 4. Status Persistence:
+//   - Update ReloaderConfig status (reload count, timestamps)
+//   - Store new hash in the resource annotation (for next comparison)
+//
+// Why this design:
+// - Hash-based change detection prevents unnecessary reloads
+// - Dual discovery supports both declarative (CRD) and imperative (annotations) config
+// - Pause periods prevent reload storms during multiple rapid changes
+// - Status tracking provides observability and audit trail
 func (r *ReloaderConfigReconciler) reconcileResourceUpdate(
 	ctx context.Context,
 	resourceKind string,
@@ -45,6 +75,13 @@ func (r *ReloaderConfigReconciler) reconcileResourceUpdate(
 		logger.V(1).Info(resourceTypeName+" marked as ignored, skipping reload",
 			"name", resourceName,
 			"namespace", resourceNamespace)
+		r.recordWatchedResourceState(ctx, resourceKind, obj)
+		return ctrl.Result{}, nil
+	}
+	if !util.ResourceInClass(annotations, r.ClassName) {
+		logger.V(1).Info(resourceTypeName+" belongs to another class, skipping reload",
+			"name", resourceName,
+			"namespace", resourceNamespace)
 		return ctrl.Result{}, nil
 	}
 
@@ -58,10 +95,13 @@ func (r *ReloaderConfigReconciler) reconcileResourceUpdate(
 	if currentHash == storedHash {
 		// Hash matches - no actual change, skip reload
 		logger.V(1).Info(resourceTypeName+" data unchanged, skipping reload", "hash", currentHash)
+		// The ignore annotation may have been removed
+		r.recordWatchedResourceState(ctx, resourceKind, obj)
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info(resourceTypeName+" data changed", "oldHash", storedHash, "newHash", currentHash)
+	r.emitResourceChanged(ctx, resourceKind, resourceName, resourceNamespace, storedHash, currentHash)
 
 	// Phase 2: Discover all workloads that need to be reloaded
 	allTargets, reloaderConfigs, err := r.discoverTargets(ctx, resourceKind, resourceName, resourceNamespace)
@@ -82,75 +122,20 @@ func (r *ReloaderConfigReconciler) reconcileResourceUpdate(
 		"after", len(filteredTargets))
 
 	// Phase 3: Execute reloads for filtered targets
-	successCount := r.executeReloads(ctx, filteredTargets, resourceKind, resourceName, resourceNamespace, currentHash)
+	successCount := r.executeReloads(ctx, filteredTargets, resourceKind, resourceName, resourceNamespace, storedHash, currentHash)
 
-	// Phase 4: Update ReloaderConfig statuses (only if at least one reload succeeded)
-	if successCount > 0 {
-		r.updateReloaderConfigStatuses(ctx, reloaderConfigs, resourceNamespace, resourceKind, resourceName, currentHash)
-	}
+	// Phase 4: Update ReloaderConfig statuses (a reload is only counted if at least one succeeded)
+	r.recordResourceChange(ctx, reloaderConfigs, resourceNamespace, resourceKind, resourceName, currentHash, "", successCount > 0)
 
 	// Phase 5: Persist new hash in resource annotation for future comparisons
 	if err := r.updateResourceHash(ctx, obj, currentHash); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	logger.Info(resourceTypeName+" reconciliation complete", "hash", currentHash, "reloadedTargets", len(allTargets))
+	logger.Info(resourceTypeName+" reconciliation complete", "hash", currentHash, "reloadedTargets", successCount)
 	return ctrl.Result{}, nil
 }
 
-// reconcileSecret handles Secret changes and triggers workload reloads
-//
-// Business Logic Flow:
-// This is the core reload logic that runs when a Secret changes.
-//
-// 1. Hash Comparison:
-//   - Calculate SHA256 hash of Secret.Data
-//   - Compare with previously stored hash (in annotation)
-//   - If hashes match, no actual change occurred → skip reload (prevents reload storms)
-//
-// 2. Target Discovery:
-//   - Find ReloaderConfigs that watch this Secret (CRD-based)
-//   - Find Deployments/StatefulSets/DaemonSets with reload annotations (annotation-based)
-//   - Merge both lists (supports hybrid configuration)
-//
-// 3. Reload Execution:
-//   - For each target workload:
-//     a. Check if it's in pause period (rate limiting)
-//     b. Trigger rolling restart (via env-vars or annotations strategy)
-//     c. Send alerts on success/failure
-//     d. Update status tracking
-//
-// 4. Status Persistence:
-//   - Update ReloaderConfig status (reload count, timestamps)
-//   - Store new hash in Secret annotation (for next comparison)
-//
-// Why this design:
-// - Hash-based change detection prevents unnecessary reloads
-// - Dual discovery supports both declarative (CRD) and imperative (annotations) config
-// - Pause periods prevent reload storms during multiple rapid changes
-// - Status tracking provides observability and audit trail
-func (r *ReloaderConfigReconciler) reconcileSecret(
-	ctx context.Context,
-	secret *corev1.Secret,
-) (ctrl.Result, error) {
-	return r.reconcileResourceUpdate(ctx, util.KindSecret, secret)
-}
-
-// reconcileConfigMap handles ConfigMap changes and triggers workload reloads
-//
-// Business Logic Flow:
-// Identical to reconcileSecret, but for ConfigMaps.
-// Key difference: ConfigMaps have both Data (string) and BinaryData ([]byte) fields,
-// so we merge them before hash calculation.
-//
-// See reconcileSecret for detailed flow explanation.
-func (r *ReloaderConfigReconciler) reconcileConfigMap(
-	ctx context.Context,
-	configMap *corev1.ConfigMap,
-) (ctrl.Result, error) {
-	return r.reconcileResourceUpdate(ctx, util.KindConfigMap, configMap)
-}
-
 // reconcileResourceCreated is a generic function that handles Secret/ConfigMap CREATE events
 func (r *ReloaderConfigReconciler) reconcileResourceCreated(
 	ctx context.Context,
@@ -170,6 +155,12 @@ func (r *ReloaderConfigReconciler) reconcileResourceCreated(
 			"namespace", resourceNamespace)
 		return ctrl.Result{}, nil
 	}
+	if !util.ResourceInClass(annotations, r.ClassName) {
+		logger.V(1).Info(resourceTypeName+" belongs to another class, skipping reload on create",
+			"name", resourceName,
+			"namespace", resourceNamespace)
+		return ctrl.Result{}, nil
+	}
 
 	logger.Info(resourceTypeName+" created", "name", resourceName, "namespace", resourceNamespace)
 
@@ -179,6 +170,8 @@ func (r *ReloaderConfigReconciler) reconcileResourceCreated(
 		return ctrl.Result{}, err
 	}
 
+	r.emitResourceChanged(ctx, resourceKind, resourceName, resourceNamespace, "", currentHash)
+
 	// Discover all workloads that need to be reloaded
 	allTargets, reloaderConfigs, err := r.discoverTargets(ctx, resourceKind, resourceName, resourceNamespace)
 	if err != nil {
@@ -190,9 +183,6 @@ func (r *ReloaderConfigReconciler) reconcileResourceCreated(
 		"totalTargets", len(allTargets),
 		"fromCRD", len(reloaderConfigs))
 
-	// Always update ReloaderConfig statuses to track the new resource
-	r.updateReloaderConfigStatuses(ctx, reloaderConfigs, resourceNamespace, resourceKind, resourceName, currentHash)
-
 	// Only trigger workload reloads if ReloadOnCreate flag is enabled
 	successCount := 0
 	if r.ReloadOnCreate {
@@ -200,9 +190,12 @@ func (r *ReloaderConfigReconciler) reconcileResourceCreated(
 		filteredTargets := r.filterTargetsForTargetedReload(ctx, allTargets, resourceKind, resourceName, resourceNamespace)
 
 		// Execute reloads for filtered targets
-		successCount = r.executeReloads(ctx, filteredTargets, resourceKind, resourceName, resourceNamespace, currentHash)
+		successCount = r.executeReloads(ctx, filteredTargets, resourceKind, resourceName, resourceNamespace, "", currentHash)
 	}
 
+	// Always update ReloaderConfig statuses to track the new resource
+	r.recordResourceChange(ctx, reloaderConfigs, resourceNamespace, resourceKind, resourceName, currentHash, "", successCount > 0)
+
 	// Persist hash in resource annotation for future update events
 	if err := r.updateResourceHash(ctx, obj, currentHash); err != nil {
 		return ctrl.Result{}, err
@@ -212,122 +205,88 @@ func (r *ReloaderConfigReconciler) reconcileResourceCreated(
 	return ctrl.Result{}, nil
 }
 
-// reconcileSecretCreated handles Secret CREATE events when --reload-on-create is enabled
-//
-// Business Logic:
-// When a new Secret is created, this function triggers workload reloads for all workloads
-// that are configured to watch this Secret (via annotations or ReloaderConfig).
-// Unlike updates, there's no previous hash to compare, so we always trigger the reload.
-func (r *ReloaderConfigReconciler) reconcileSecretCreated(
-	ctx context.Context,
-	secret *corev1.Secret,
-) (ctrl.Result, error) {
-	return r.reconcileResourceCreated(ctx, util.KindSecret, secret)
-}
-
 // reconcileResourceDeleted is a generic function that handles Secret/ConfigMap DELETE events
+// The deleted object can't be read anymore; its final state comes from the tombstone.
 func (r *ReloaderConfigReconciler) reconcileResourceDeleted(
 	ctx context.Context,
 	resourceKind string,
 	resourceKey client.ObjectKey,
+	tombstone resourceTombstone,
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	resourceTypeName := resourceKind // "Secret" or "ConfigMap" for logging
 
-	logger.Info(resourceTypeName+" deleted", "name", resourceKey.Name, "namespace", resourceKey.Namespace)
+	// Check if resource was marked as ignored
+	if tombstone.Annotations[util.AnnotationIgnore] == "true" {
+		logger.V(1).Info(resourceTypeName+" marked as ignored, skipping reload on delete",
+			"name", resourceKey.Name,
+			"namespace", resourceKey.Namespace)
+		return ctrl.Result{}, nil
+	}
+	if !util.ResourceInClass(tombstone.Annotations, r.ClassName) {
+		logger.V(1).Info(resourceTypeName+" belongs to another class, skipping reload on delete",
+			"name", resourceKey.Name,
+			"namespace", resourceKey.Namespace)
+		return ctrl.Result{}, nil
+	}
 
 	// Discover all workloads that were watching this resource
 	// Note: We can still find these because the workload annotations/ReloaderConfigs still exist
-	allTargets, reloaderConfigs, err := r.discoverTargets(ctx, resourceKind, resourceKey.Name, resourceKey.Namespace)
+	allTargets, reloaderConfigs, err := r.discoverTargetsWithAnnotations(
+		ctx, resourceKind, resourceKey.Name, resourceKey.Namespace, tombstone.Annotations)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	logger.Info("Found targets for reload on delete",
-		"resource", resourceTypeName+"/"+resourceKey.Name,
-		"totalTargets", len(allTargets),
-		"fromCRD", len(reloaderConfigs))
-
-	// Filter targets based on targeted reload settings
-	filteredTargets := r.filterTargetsForTargetedReload(ctx, allTargets, resourceKind, resourceKey.Name, resourceKey.Namespace)
-
-	// Execute delete-specific reloads for filtered targets
-	successCount := r.executeDeleteReloads(ctx, filteredTargets, resourceKind, resourceKey.Name)
-
-	// Update ReloaderConfig statuses (only if at least one reload succeeded)
-	// For delete events, we remove the hash entry from the status
-	if successCount > 0 {
-		r.removeReloaderConfigStatusEntries(ctx, reloaderConfigs, resourceKey.Namespace, resourceKind, resourceKey.Name)
+	// Nothing depended on the resource - deleting it is not a change worth reporting
+	if len(allTargets) == 0 && len(reloaderConfigs) == 0 {
+		return ctrl.Result{}, nil
 	}
 
-	logger.Info(resourceTypeName+" delete reconciliation complete", "reloadedTargets", successCount)
-	return ctrl.Result{}, nil
-}
+	// Reloads only happen with reload-on-delete (flag or resource annotation); the status is updated regardless
+	successCount := 0
+	if util.ShouldReloadOnDelete(r.ReloadOnDelete, tombstone.Annotations) && r.controllersInitialized.Load() {
+		logger.Info(resourceTypeName+" deleted", "name", resourceKey.Name, "namespace", resourceKey.Namespace)
+		r.emitResourceChanged(ctx, resourceKind, resourceKey.Name, resourceKey.Namespace, tombstone.LastHash, "")
 
-// reconcileSecretDeleted handles Secret DELETE events when --reload-on-delete is enabled
-//
-// Business Logic:
-// When a Secret is deleted, this function triggers workload reloads using the delete strategy.
-// The delete strategy either removes the environment variable or sets the annotation to an empty hash.
-// Note: The Secret object no longer exists, so we work with the NamespacedName only.
-func (r *ReloaderConfigReconciler) reconcileSecretDeleted(
-	ctx context.Context,
-	secretKey client.ObjectKey,
-) (ctrl.Result, error) {
-	return r.reconcileResourceDeleted(ctx, util.KindSecret, secretKey)
-}
+		logger.Info("Found targets for reload on delete",
+			"resource", resourceTypeName+"/"+resourceKey.Name,
+			"totalTargets", len(allTargets),
+			"fromCRD", len(reloaderConfigs))
 
-// reconcileConfigMapCreated handles ConfigMap CREATE events
-func (r *ReloaderConfigReconciler) reconcileConfigMapCreated(
-	ctx context.Context,
-	configMap *corev1.ConfigMap,
-) (ctrl.Result, error) {
-	return r.reconcileResourceCreated(ctx, util.KindConfigMap, configMap)
-}
-
-// reconcileConfigMapDeleted handles ConfigMap DELETE events when --reload-on-delete is enabled
-func (r *ReloaderConfigReconciler) reconcileConfigMapDeleted(
-	ctx context.Context,
-	configMapKey client.ObjectKey,
-) (ctrl.Result, error) {
-	return r.reconcileResourceDeleted(ctx, util.KindConfigMap, configMapKey)
-}
+		// Filter targets based on targeted reload settings
+		filteredTargets := r.filterTargetsForTargetedReload(ctx, allTargets, resourceKind, resourceKey.Name, resourceKey.Namespace)
 
-// mapSecretToRequests maps a Secret to reconcile requests
-// This function enqueues the Secret itself for reconciliation
-func (r *ReloaderConfigReconciler) mapSecretToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
-	secret, ok := obj.(*corev1.Secret)
-	if !ok {
-		return []reconcile.Request{}
+		// Execute delete-specific reloads for filtered targets
+		successCount = r.executeDeleteReloads(ctx, filteredTargets, resourceKind, resourceKey.Name, resourceKey.Namespace, tombstone.LastHash)
 	}
 
-	// Enqueue the Secret for reconciliation
-	return []reconcile.Request{
-		{
-			NamespacedName: client.ObjectKey{
-				Namespace: secret.Namespace,
-				Name:      secret.Name,
-			},
-		},
-	}
-}
+	// Drop the hash entry from the ReloaderConfig statuses, counting a reload if any target reloaded
+	r.removeReloaderConfigStatusEntries(ctx, reloaderConfigs, resourceKey.Namespace, resourceKind, resourceKey.Name, successCount > 0)
 
-// mapConfigMapToRequests maps a ConfigMap to reconcile requests
-// This function enqueues the ConfigMap itself for reconciliation
-func (r *ReloaderConfigReconciler) mapConfigMapToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
-	configMap, ok := obj.(*corev1.ConfigMap)
-	if !ok {
-		return []reconcile.Request{}
-	}
+	logger.Info(resourceTypeName+" delete reconciliation complete", "reloadedTargets", successCount)
+	return ctrl.Result{}, nil
+}
 
-	// Enqueue the ConfigMap for reconciliation
-	return []reconcile.Request{
-		{
-			NamespacedName: client.ObjectKey{
-				Namespace: configMap.Namespace,
-				Name:      configMap.Name,
-			},
-		},
+// mapTargetWorkloadToRequests maps a workload to the ReloaderConfigs targeting it
+// Their status (e.g., a Degraded TargetNotFound condition) depends on whether it exists.
+func (r *ReloaderConfigReconciler) mapTargetWorkloadToRequests(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		configs, err := r.WorkloadFinder.FindReloaderConfigsTargetingWorkload(ctx, kind, obj.GetNamespace(), obj.GetName())
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to find ReloaderConfigs targeting workload",
+				"kind", kind, "name", obj.GetName(), "namespace", obj.GetNamespace())
+			return []reconcile.Request{}
+		}
+
+		requests := []reconcile.Request{}
+		for _, config := range configs {
+			if !r.ownsNamespace(config.Namespace) || config.Spec.ReloaderClassName != r.ClassName {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+		}
+		return requests
 	}
 }
 
@@ -335,7 +294,8 @@ func (r *ReloaderConfigReconciler) mapConfigMapToRequests(ctx context.Context, o
 //
 // Business Logic:
 // The last known hash of a Secret/ConfigMap is stored in its annotations
-// under the key "reloader.stakater.com/last-hash". This allows us to
+// under util.AnnotationLastHash (reloader.stakater.com/last-hash by default,
+// one key per class when --reloader-class-name is set). This allows us to
 // detect actual data changes vs. metadata-only changes.
 func (r *ReloaderConfigReconciler) getStoredHash(annotations map[string]string) string {
 	if annotations == nil {
@@ -350,7 +310,7 @@ func (r *ReloaderConfigReconciler) getStoredHash(annotations map[string]string)
 // After processing a resource change, we store the new hash in the resource's
 // annotations. This serves as the baseline for future change detection.
 //
-// The annotation key is "reloader.stakater.com/last-hash".
+// The annotation key is util.AnnotationLastHash (see getStoredHash).
 //
 // This function is generic and works with any resource that has annotations
 // (Secrets, ConfigMaps, etc.).
@@ -361,6 +321,11 @@ func (r *ReloaderConfigReconciler) updateResourceHash(
 ) error {
 	logger := log.FromContext(ctx)
 
+	// The cached object has no payload (see util.TransformResourceData) - an Update
+	// would erase the data, so only the annotation change is sent as a patch.
+	// The merge patch carries no resourceVersion, so it cannot conflict.
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+
 	// Get current annotations
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
@@ -372,7 +337,7 @@ func (r *ReloaderConfigReconciler) updateResourceHash(
 	obj.SetAnnotations(annotations)
 
 	// Persist update
-	if err := r.Update(ctx, obj); err != nil {
+	if err := r.Patch(ctx, obj, patch, client.FieldOwner(util.FieldManager)); err != nil {
 		logger.Error(err, "Failed to update resource hash annotation",
 			"kind", obj.GetObjectKind(),
 			"name", obj.GetName())
@@ -18,6 +18,9 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -28,7 +31,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/alerts"
+	"github.com/stakater/Reloader/internal/pkg/sharding"
 	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
 )
 
 var _ = Describe("Event Handlers", func() {
@@ -415,6 +421,90 @@ var _ = Describe("Event Handlers", func() {
 		})
 	})
 
+	Context("When one change reloads many targets", func() {
+		ctx := context.Background()
+
+		It("Should reload every target with parallel workers", func() {
+			const targetCount = 6
+			targets := []reloaderv1alpha1.TargetWorkload{}
+			for i := range targetCount {
+				name := fmt.Sprintf("test-parallel-app-%d", i)
+				deployment := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec: appsv1.DeploymentSpec{
+						Replicas: int32Ptr(1),
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": name},
+						},
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "nginx", Image: "nginx:latest"}},
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+				defer k8sClient.Delete(ctx, deployment)
+
+				targets = append(targets, reloaderv1alpha1.TargetWorkload{Kind: util.KindDeployment, Name: name})
+			}
+
+			initialData := map[string]string{"config": "value1"}
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-parallel-configmap",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationLastHash: util.CalculateHash(util.MergeDataMaps(initialData, nil)),
+					},
+				},
+				Data: initialData,
+			}
+			Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+			defer k8sClient.Delete(ctx, cm)
+
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-parallel-config", Namespace: "default"},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						ConfigMaps: []string{"test-parallel-configmap"},
+					},
+					Targets:        targets,
+					ReloadStrategy: "env-vars",
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			time.Sleep(2 * time.Second)
+
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-parallel-configmap", Namespace: "default"}, cm); err != nil {
+					return err
+				}
+				cm.Data["config"] = "value2"
+				return k8sClient.Update(ctx, cm)
+			}, timeout, interval).Should(Succeed())
+
+			expectedEnvVar := util.GetEnvVarName(util.KindConfigMap, "test-parallel-configmap")
+			for _, target := range targets {
+				Eventually(func() bool {
+					deployment := &appsv1.Deployment{}
+					if err := k8sClient.Get(ctx, types.NamespacedName{Name: target.Name, Namespace: "default"}, deployment); err != nil {
+						return false
+					}
+					for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+						if env.Name == expectedEnvVar {
+							return true
+						}
+					}
+					return false
+				}, timeout, interval).Should(BeTrue(), "deployment %s was not reloaded", target.Name)
+			}
+		})
+	})
+
 	Context("When Secret has ignore annotation", func() {
 		ctx := context.Background()
 
@@ -644,4 +734,467 @@ var _ = Describe("Event Handlers", func() {
 			}
 		})
 	})
+
+	Context("When a Secret with the reload-on-delete annotation is deleted", func() {
+		ctx := context.Background()
+
+		It("Should reload targets using the annotation captured from the delete event", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-delete-secret-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "delete-test"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "delete-test"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// --reload-on-delete is off in this suite; the annotation opts this Secret in
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-delete-secret",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"reloader.stakater.com/reload-on-delete": "true",
+						util.AnnotationLastHash: util.CalculateHash(map[string][]byte{
+							"password": []byte("test123"),
+						}),
+					},
+				},
+				Data: map[string][]byte{
+					"password": []byte("test123"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-delete-secret-config",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Secrets: []string{"test-delete-secret"},
+					},
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "test-delete-secret-app",
+						},
+					},
+					ReloadStrategy: "env-vars",
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-delete-secret-config", Namespace: "default"}, config)
+				return err == nil && util.IsConditionTrue(config.Status.Conditions, util.ConditionAvailable)
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+
+			// The delete strategy marks the resource env var as deleted
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-delete-secret-app", Namespace: "default"}, deployment); err != nil {
+					return false
+				}
+
+				expectedEnvVar := util.GetEnvVarName(util.KindSecret, "test-delete-secret")
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					for _, env := range container.Env {
+						if env.Name == expectedEnvVar && strings.HasPrefix(env.Value, "deleted-") {
+							return true
+						}
+					}
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When a pod notification is pending on a workload", func() {
+		ctx := context.Background()
+
+		It("Should send a notification stored before a restart and remove it", func() {
+			// As stored by scheduleNotification of an earlier operator process, already due
+			notification, err := json.Marshal(pendingReload{
+				NotifyAt:          time.Now().Add(-time.Minute),
+				ResourceKind:      util.KindSecret,
+				ResourceName:      "test-pending-notify-secret",
+				ResourceNamespace: "default",
+				ResourceHash:      "hash-1",
+				ReloadStrategy:    util.ReloadStrategyRestartedAt,
+				Rule:              workload.ReloadRuleNamedReload,
+				Notify:            &reloaderv1alpha1.NotifyConfig{Port: 9090},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			pending, err := json.Marshal(map[string]json.RawMessage{
+				util.MakeResourceKey("default", util.KindSecret, "test-pending-notify-secret"): notification,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pending-notify-app",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationReloadPending: string(pending),
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "pending-notify-test"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "pending-notify-test"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// envtest runs no pods, so the notification falls back to a rollout
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-pending-notify-app",
+					Namespace: "default",
+				}, deployment)
+				if err != nil {
+					return false
+				}
+				_, pending := deployment.Annotations[util.AnnotationReloadPending]
+				return !pending && deployment.Spec.Template.Annotations[util.AnnotationRestartedAt] != ""
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When a target lives in a namespace owned by another replica", func() {
+		ctx := context.Background()
+
+		It("Should hand the reload over to the owner instead of patching the workload", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-handover-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "handover-test"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "handover-test"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// A sharded replica that has not joined its group yet owns no namespace
+			nonOwner := &ReloaderConfigReconciler{
+				Client:          k8sClient,
+				Scheme:          k8sClient.Scheme(),
+				WorkloadFinder:  workload.NewFinder(k8sClient),
+				WorkloadUpdater: workload.NewUpdater(k8sClient),
+				AlertManager:    alerts.NewAlertManager(k8sClient, false, "webhook", "", ""),
+				Sharding:        sharding.NewCoordinator(k8sClient, "default", "other-replica"),
+			}
+			target := workload.Target{
+				Kind:            util.KindDeployment,
+				Name:            "test-handover-app",
+				Namespace:       "default",
+				RolloutStrategy: util.RolloutStrategyRollout,
+				ReloadStrategy:  util.ReloadStrategyRestartedAt,
+				Rule:            workload.ReloadRuleReloaderConfig,
+			}
+			result := nonOwner.reloadTarget(ctx, target, util.KindSecret, "test-handover-secret", "other", "", "hash-1")
+			Expect(result.outcome).To(Equal(util.ReloadOutcomeScheduled))
+
+			// The owner (the suite's reconciler, without sharding) does the reload and removes it
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-handover-app",
+					Namespace: "default",
+				}, deployment)
+				if err != nil {
+					return false
+				}
+				_, pending := deployment.Annotations[util.AnnotationReloadPending]
+				return !pending && deployment.Spec.Template.Annotations[util.AnnotationRestartedAt] != ""
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When an update and a delete reload the same workload at once", func() {
+		ctx := context.Background()
+
+		It("Should serialize both reloads on the workload lock and keep both changes", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-update-delete-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "update-delete-test"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "update-delete-test"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx:latest"}},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			target := workload.Target{
+				Kind:            util.KindDeployment,
+				Name:            "test-update-delete-app",
+				Namespace:       "default",
+				RolloutStrategy: util.RolloutStrategyRollout,
+				ReloadStrategy:  "env-vars",
+			}
+
+			// Hold the workload lock so both reloads are waiting for it at the same time
+			unlock := reconciler.workloadLocks.Lock(util.MakeResourceKey(target.Namespace, target.Kind, target.Name))
+
+			updated := make(chan int, 1)
+			deleted := make(chan int, 1)
+			go func() {
+				updated <- reconciler.executeReloads(ctx, []workload.Target{target},
+					util.KindConfigMap, "test-update-delete-configmap", "default", "", "hash-1")
+			}()
+			go func() {
+				deleted <- reconciler.executeDeleteReloads(ctx, []workload.Target{target},
+					util.KindSecret, "test-update-delete-secret", "default", "hash-0")
+			}()
+
+			Consistently(updated, time.Second).ShouldNot(Receive())
+			Consistently(deleted, 100*time.Millisecond).ShouldNot(Receive())
+			unlock()
+
+			Eventually(updated, timeout).Should(Receive(Equal(1)))
+			Eventually(deleted, timeout).Should(Receive(Equal(1)))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-update-delete-app", Namespace: "default"}, deployment)).To(Succeed())
+			env := map[string]string{}
+			for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+				env[envVar.Name] = envVar.Value
+			}
+			Expect(env).To(HaveKey(util.GetEnvVarName(util.KindConfigMap, "test-update-delete-configmap")))
+			Expect(env[util.GetEnvVarName(util.KindSecret, "test-update-delete-secret")]).To(HavePrefix("deleted-"))
+		})
+	})
+
+	Context("When a reload is requested with the reload-requested annotation", func() {
+		ctx := context.Background()
+
+		hasEnvVar := func(deployment *appsv1.Deployment, name string) bool {
+			for _, container := range deployment.Spec.Template.Spec.Containers {
+				for _, env := range container.Env {
+					if env.Name == name {
+						return true
+					}
+				}
+			}
+			return false
+		}
+
+		It("Should reload an annotated workload once and record the handled token", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-reload-request-app",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationReloadRequested: "first",
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "reload-request-test"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "reload-request-test"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// env-vars targets are restarted instead of getting a variable named after the workload
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-reload-request-app",
+					Namespace: "default",
+				}, deployment)
+				if err != nil {
+					return false
+				}
+				return deployment.Spec.Template.Annotations[util.AnnotationRestartedAt] != "" &&
+					deployment.Annotations[util.AnnotationReloadRequestHandled] == "first"
+			}, timeout, interval).Should(BeTrue())
+			Expect(pendingReloadRequest(deployment.Annotations)).To(BeEmpty())
+			Expect(hasEnvVar(deployment, util.GetEnvVarName(util.KindDeployment, "test-reload-request-app"))).To(BeFalse())
+		})
+
+		It("Should reload the targets of a ReloaderConfig and record the token in status", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-reload-request-target",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "reload-request-target"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "reload-request-target"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-reload-request-config",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationReloadRequested: "2025-01-01T00:00:00Z",
+					},
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "test-reload-request-target",
+						},
+					},
+					ReloadStrategy: "env-vars",
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-reload-request-config",
+					Namespace: "default",
+				}, config); err != nil {
+					return ""
+				}
+				return config.Status.LastHandledReloadRequest
+			}, timeout, interval).Should(Equal("2025-01-01T00:00:00Z"))
+			Expect(config.Status.ReloadCount).To(BeEquivalentTo(1))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      "test-reload-request-target",
+				Namespace: "default",
+			}, deployment)).To(Succeed())
+			Expect(deployment.Spec.Template.Annotations).To(HaveKey(util.AnnotationRestartedAt))
+			Expect(hasEnvVar(deployment, util.GetEnvVarName(kindReloaderConfig, "test-reload-request-config"))).To(BeFalse())
+		})
+
+		It("Should not record a reload request whose target failed", func() {
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-reload-request-failed",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationReloadRequested: "2025-01-01T00:00:00Z",
+					},
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "test-reload-request-missing",
+						},
+					},
+					ReloadStrategy: "env-vars",
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			// The target does not exist: the request stays pending and is retried
+			Consistently(func() string {
+				if err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-reload-request-failed",
+					Namespace: "default",
+				}, config); err != nil {
+					return ""
+				}
+				return config.Status.LastHandledReloadRequest
+			}, 2*time.Second, interval).Should(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
+)
+
+// ResourceExplanation describes what a change of a Secret or ConfigMap would reload
+type ResourceExplanation struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Found     bool // Whether the resource exists (a missing one can still reload on create)
+
+	// SkipReason is why the change would not reload anything at all; empty otherwise
+	SkipReason string
+
+	// IgnoringConfigs are the ReloaderConfigs watching the resource that list it in spec.ignoreResources
+	IgnoringConfigs []string
+
+	Targets []TargetExplanation
+}
+
+// TargetExplanation describes what a resource change would do to one target workload
+type TargetExplanation struct {
+	workload.Target
+
+	// Paused is set when the workload is within its pause period
+	Paused bool
+
+	// SkipReason is why the target would not be reloaded; empty when it would be
+	SkipReason string
+}
+
+// ExplainResourceChange reports the workloads a change of a Secret or ConfigMap would reload, without reloading them
+//
+// Business Logic:
+// The targets are found exactly like a real change: discoverTargets (ReloaderConfigs
+// and annotations, minus spec.ignoreResources), then filterTargetsForTargetedReload.
+// Instead of reloading, every target is reported with the rule that selected it
+// and, when it would not be reloaded, the reason:
+// - the resource itself is filtered out (namespace filters, --resource-label-selector, ignore annotation, class)
+// - the target does not reference the resource although its ReloaderConfig requires it
+// - the workload does not exist
+// - the workload is within its pause period (or the pause period is invalid)
+//
+// Only metadata of the resource is read, so explaining a Secret does not need access to its data.
+func (r *ReloaderConfigReconciler) ExplainResourceChange(
+	ctx context.Context,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+) (*ResourceExplanation, error) {
+	if !util.IsSupportedResourceKind(resourceKind) {
+		return nil, fmt.Errorf("unsupported resource kind %q", resourceKind)
+	}
+
+	explanation := &ResourceExplanation{
+		Kind:      resourceKind,
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+
+	resource := &metav1.PartialObjectMetadata{}
+	resource.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(resourceKind))
+	err := r.Get(ctx, client.ObjectKey{Name: resourceName, Namespace: resourceNamespace}, resource)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	explanation.Found = err == nil
+	explanation.SkipReason = r.resourceSkipReason(ctx, resource)
+
+	// Same lookup as discoverTargets, keeping track of the configs dropped by ignoreResources
+	configs, err := r.WorkloadFinder.FindReloaderConfigsWatchingResource(ctx, resourceKind, resourceName, resourceNamespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if r.shouldIgnoreResource(config, resourceKind, resourceName, resourceNamespace) {
+			explanation.IgnoringConfigs = append(explanation.IgnoringConfigs, config.Namespace+"/"+config.Name)
+		}
+	}
+
+	targets, _, err := r.discoverTargetsWithAnnotations(ctx, resourceKind, resourceName, resourceNamespace, resource.GetAnnotations())
+	if err != nil {
+		return nil, err
+	}
+
+	referencing := map[string]bool{}
+	for _, target := range r.filterTargetsForTargetedReload(ctx, targets, resourceKind, resourceName, resourceNamespace) {
+		referencing[explainedTargetKey(target)] = true
+	}
+
+	for _, target := range targets {
+		explained := TargetExplanation{Target: target, SkipReason: explanation.SkipReason}
+		if !referencing[explainedTargetKey(target)] && explained.SkipReason == "" {
+			explained.SkipReason = "does not reference the resource (requireReference)"
+		}
+
+		exists, err := r.workloadExists(ctx, target.Kind, target.Name, target.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			if explained.SkipReason == "" {
+				explained.SkipReason = "workload not found"
+			}
+			explanation.Targets = append(explanation.Targets, explained)
+			continue
+		}
+
+		paused, err := r.WorkloadUpdater.IsPaused(ctx, target)
+		explained.Paused = paused
+		if explained.SkipReason == "" {
+			if err != nil {
+				explained.SkipReason = err.Error()
+			} else if paused {
+				explained.SkipReason = "workload is in pause period"
+			}
+		}
+		explanation.Targets = append(explanation.Targets, explained)
+	}
+
+	return explanation, nil
+}
+
+// resourceSkipReason returns why no change of a resource would be processed, mirroring the
+// predicates and checks of the Secret/ConfigMap controllers; empty when it would be processed
+func (r *ReloaderConfigReconciler) resourceSkipReason(ctx context.Context, resource client.Object) string {
+	switch {
+	case !r.shouldProcessNamespace(ctx, resource.GetNamespace()):
+		return "namespace is filtered out (--namespace-selector, --namespaces-to-ignore)"
+	case r.ResourceLabelSelector != nil && !r.ResourceLabelSelector.Matches(labels.Set(resource.GetLabels())):
+		return "resource does not match --resource-label-selector"
+	case resource.GetAnnotations()[util.AnnotationIgnore] == "true":
+		return "resource has the " + util.AnnotationIgnore + " annotation"
+	case !util.ResourceInClass(resource.GetAnnotations(), r.ClassName):
+		return "resource belongs to class " + resource.GetAnnotations()[util.AnnotationClass]
+	}
+	return ""
+}
+
+// explainedTargetKey identifies a target by its workload and the ReloaderConfig that selected it
+func explainedTargetKey(target workload.Target) string {
+	key := util.MakeResourceKey(target.Namespace, target.Kind, target.Name)
+	if target.Config != nil {
+		key += "|" + target.Config.Namespace + "/" + target.Config.Name
+	}
+	return key
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
+)
+
+const (
+	// maxHistoryTargets is the number of targets listed in one reload history entry
+	maxHistoryTargets = 10
+
+	// maxHistoryMessageLength truncates the failure or skip reason of a listed target
+	maxHistoryMessageLength = 256
+)
+
+// reloadResult is the outcome of reloading one target
+type reloadResult struct {
+	target  workload.Target
+	outcome string // util.ReloadOutcome*
+	message string // Why the target failed or was skipped
+}
+
+// reloaded reports whether the target was reloaded, counting a reload left to be done later
+// (a delayed pod notification or a reload handed over to another replica, see reconcilePendingReloads)
+func (r reloadResult) reloaded() bool {
+	return r.outcome == util.ReloadOutcomeSucceeded || r.outcome == util.ReloadOutcomeScheduled
+}
+
+// recordReloadHistory queues a reload history entry for every ReloaderConfig with targets of a change
+//
+// Business Logic:
+// The targets of a change can come from several ReloaderConfigs; each config
+// only records its own targets. Annotation-based targets have no ReloaderConfig
+// and are not recorded. An entry lists at most maxHistoryTargets targets (the
+// total is kept in targetCount) and the status keeps at most ReloadHistoryLimit
+// entries, so the history cannot grow the object without bound.
+func (r *ReloaderConfigReconciler) recordReloadHistory(
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	resourceHash string,
+	started time.Time,
+	results []reloadResult,
+) {
+	if r.ReloadHistoryLimit <= 0 {
+		return
+	}
+
+	duration := metav1.Duration{Duration: time.Since(started).Round(time.Millisecond)}
+	var configKeys []client.ObjectKey
+	resultsByConfig := map[client.ObjectKey][]reloadResult{}
+	for _, result := range results {
+		if result.target.Config == nil {
+			continue
+		}
+		key := client.ObjectKeyFromObject(result.target.Config)
+		if _, ok := resultsByConfig[key]; !ok {
+			configKeys = append(configKeys, key)
+		}
+		resultsByConfig[key] = append(resultsByConfig[key], result)
+	}
+
+	for _, key := range configKeys {
+		entry := newReloadHistoryEntry(resourceKind, resourceName, resourceNamespace, resourceHash, started, resultsByConfig[key])
+		entry.Duration = duration
+		r.statusQueue.Add(statusUpdateWorkItem{
+			updateType:   statusUpdateTypeReloadHistory,
+			configKey:    key,
+			historyEntry: entry,
+		})
+	}
+}
+
+// newReloadHistoryEntry builds the reload history entry of a change from the results of one config's targets
+// Targets are listed by kind, namespace and name, not in the order the reload workers finished them.
+func newReloadHistoryEntry(
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	resourceHash string,
+	started time.Time,
+	results []reloadResult,
+) *reloaderv1alpha1.ReloadHistoryEntry {
+	entry := &reloaderv1alpha1.ReloadHistoryEntry{
+		Time:              metav1.NewTime(started),
+		ResourceKind:      resourceKind,
+		ResourceName:      resourceName,
+		ResourceNamespace: resourceNamespace,
+		ResourceHash:      resourceHash,
+		TargetCount:       int32(len(results)),
+	}
+
+	results = slices.SortedStableFunc(slices.Values(results), func(a, b reloadResult) int {
+		return cmp.Or(
+			cmp.Compare(a.target.Kind, b.target.Kind),
+			cmp.Compare(a.target.Namespace, b.target.Namespace),
+			cmp.Compare(a.target.Name, b.target.Name),
+		)
+	})
+
+	counts := map[string]int{}
+	for i, result := range results {
+		counts[result.outcome]++
+		if i >= maxHistoryTargets {
+			continue
+		}
+		entry.Targets = append(entry.Targets, reloaderv1alpha1.ReloadHistoryTarget{
+			Kind:      result.target.Kind,
+			Name:      result.target.Name,
+			Namespace: result.target.Namespace,
+			Strategy:  historyStrategy(result.target),
+			Outcome:   result.outcome,
+			Message:   util.TruncateString(result.message, maxHistoryMessageLength),
+		})
+	}
+
+	reloaded := counts[util.ReloadOutcomeSucceeded] + counts[util.ReloadOutcomeScheduled]
+	switch {
+	case counts[util.ReloadOutcomeSucceeded] == len(results):
+		entry.Outcome = util.ReloadOutcomeSucceeded
+	case reloaded == len(results):
+		entry.Outcome = util.ReloadOutcomeScheduled
+	case reloaded > 0:
+		entry.Outcome = util.ReloadOutcomePartiallySucceeded
+	case counts[util.ReloadOutcomeFailed] > 0:
+		entry.Outcome = util.ReloadOutcomeFailed
+	default:
+		entry.Outcome = util.ReloadOutcomeSkipped
+	}
+	return entry
+}
+
+// historyStrategy describes how a target is reloaded: its rollout strategy, and for "rollout" its reload strategy
+func historyStrategy(target workload.Target) string {
+	if target.RolloutStrategy != "" && target.RolloutStrategy != util.RolloutStrategyRollout {
+		return target.RolloutStrategy
+	}
+	if target.ReloadStrategy == "" {
+		return util.RolloutStrategyRollout
+	}
+	return util.RolloutStrategyRollout + "/" + target.ReloadStrategy
+}
@@ -0,0 +1,546 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
+)
+
+// objectRequest identifies a watched object of an arbitrary kind
+// Unlike reconcile.Request it carries the kind, since many kinds share one controller.
+type objectRequest struct {
+	GVK schema.GroupVersionKind
+	client.ObjectKey
+}
+
+// setupObjectController creates the controller for spec.watchedResources.objects
+//
+// Business Logic:
+// The kinds to watch are only known once ReloaderConfigs are read, so the
+// controller starts without sources. ensureObjectWatch adds an informer per
+// kind the first time a ReloaderConfig references it.
+func (r *ReloaderConfigReconciler) setupObjectController(mgr ctrl.Manager) error {
+	objectController, err := controller.NewTyped("reloaderconfig-objects", mgr, controller.TypedOptions[objectRequest]{
+		Reconciler:              reconcile.TypedFunc[objectRequest](r.reconcileObject),
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sharded replicas re-enqueue the watched objects of the namespaces they gain
+	if resync := r.objectResyncSource(); resync != nil {
+		if err := objectController.Watch(resync); err != nil {
+			return err
+		}
+	}
+
+	r.objectController = objectController
+	r.objectCache = mgr.GetCache()
+	r.restMapper = mgr.GetRESTMapper()
+	r.objectWatches = make(map[schema.GroupVersionKind]bool)
+	return nil
+}
+
+// ensureObjectWatch starts watching a kind if it is not watched yet
+//
+// Business Logic:
+// The kind must be served by the API server, and the operator must be allowed
+// to list and watch it in all namespaces: otherwise the informer would retry
+// (and log errors) forever. Watches are never removed: controller-runtime
+// cannot detach a source from a running controller. Once no ReloaderConfig
+// references the kind anymore, its informer keeps caching the kind until the
+// operator restarts, and its events simply match nothing.
+func (r *ReloaderConfigReconciler) ensureObjectWatch(ctx context.Context, gvk schema.GroupVersionKind) error {
+	if r.objectController == nil {
+		return fmt.Errorf("watching %s is not supported: object controller is not set up", gvk.Kind)
+	}
+
+	r.objectWatchesMu.Lock()
+	defer r.objectWatchesMu.Unlock()
+
+	if r.objectWatches[gvk] {
+		return nil
+	}
+
+	mapping, err := r.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	if err := r.checkObjectAccess(ctx, mapping.Resource); err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+
+	src := source.TypedKind(r.objectCache, obj,
+		handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, o *unstructured.Unstructured) []objectRequest {
+			return []objectRequest{{GVK: gvk, ObjectKey: client.ObjectKeyFromObject(o)}}
+		}),
+		r.objectPredicates(gvk),
+	)
+	if err := r.objectController.Watch(src); err != nil {
+		return err
+	}
+
+	r.objectWatches[gvk] = true
+	log.FromContext(ctx).Info("Started watching object kind", "gvk", gvk.String())
+	return nil
+}
+
+// objectPredicates filters the events of a watched kind by namespace
+// Like for Secrets and ConfigMaps, deletes leave a tombstone with the object's
+// annotations, which decide whether the delete is ignored or reloads.
+func (r *ReloaderConfigReconciler) objectPredicates(gvk schema.GroupVersionKind) predicate.TypedPredicate[*unstructured.Unstructured] {
+	statusKind := util.QualifiedKind(gvk)
+	return predicate.TypedFuncs[*unstructured.Unstructured]{
+		CreateFunc: func(e event.TypedCreateEvent[*unstructured.Unstructured]) bool {
+			return r.shouldProcessNamespace(context.Background(), e.Object.GetNamespace())
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*unstructured.Unstructured]) bool {
+			return r.shouldProcessNamespace(context.Background(), e.ObjectNew.GetNamespace())
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*unstructured.Unstructured]) bool {
+			if !r.shouldProcessNamespace(context.Background(), e.Object.GetNamespace()) {
+				return false
+			}
+			r.storeTombstone(statusKind, e.Object)
+			return true
+		},
+		GenericFunc: func(e event.TypedGenericEvent[*unstructured.Unstructured]) bool {
+			return r.shouldProcessNamespace(context.Background(), e.Object.GetNamespace())
+		},
+	}
+}
+
+// errObjectAccessDenied is returned by checkObjectAccess when RBAC does not allow watching a kind
+var errObjectAccessDenied = errors.New("access denied")
+
+// checkObjectAccess checks with SelfSubjectAccessReviews that the operator may list and watch a resource cluster-wide
+func (r *ReloaderConfigReconciler) checkObjectAccess(ctx context.Context, resource schema.GroupVersionResource) error {
+	for _, verb := range []string{"list", "watch"} {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    resource.Group,
+					Version:  resource.Version,
+					Resource: resource.Resource,
+				},
+			},
+		}
+		if err := r.Create(ctx, review); err != nil {
+			return fmt.Errorf("failed to check access to %s: %w", resource.GroupResource(), err)
+		}
+		if !review.Status.Allowed {
+			return fmt.Errorf("%w: the operator is not allowed to %s %s", errObjectAccessDenied, verb, resource.GroupResource())
+		}
+	}
+	return nil
+}
+
+// initializeWatchedObjects validates spec.watchedResources.objects, starts the
+// needed watches and records a baseline hash for every matching object
+//
+// Business Logic:
+// Unlike Secrets and ConfigMaps, arbitrary objects are never annotated by the
+// operator (they are usually owned by another controller), so the last hash
+// lives in the ReloaderConfig status instead. Existing entries are kept: they
+// are the baseline of a change that may not have been processed yet. An entry
+// hashed from other JSONPaths than the spec's current ones is re-baselined
+// instead, since comparing hashes of different fields would reload for nothing.
+//
+// Returns false if the spec is invalid or a kind is not served by the cluster.
+func (r *ReloaderConfigReconciler) initializeWatchedObjects(
+	ctx context.Context,
+	config *reloaderv1alpha1.ReloaderConfig,
+) bool {
+	logger := log.FromContext(ctx)
+	valid := true
+
+	for _, watched := range config.Spec.WatchedResources.Objects {
+		gvk, err := watchedObjectGVK(watched)
+		if err == nil {
+			err = util.ValidateJSONPaths(watched.JSONPaths)
+		}
+		if err != nil {
+			logger.Error(err, "Invalid watched object", "kind", watched.Kind)
+			util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+				util.ReasonInvalidSpec, fmt.Sprintf("Invalid watched object %s: %v", watched.Kind, err))
+			valid = false
+			continue
+		}
+
+		if err := r.ensureObjectWatch(ctx, gvk); err != nil {
+			logger.Error(err, "Failed to watch object kind", "gvk", gvk.String())
+			reason := util.ReasonInvalidSpec
+			if errors.Is(err, errObjectAccessDenied) {
+				reason = util.ReasonForbidden
+			}
+			util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+				reason, fmt.Sprintf("Cannot watch %s: %v", gvk.String(), err))
+			valid = false
+			continue
+		}
+
+		objects, err := r.listWatchedObjects(ctx, watched, gvk, config.Namespace)
+		if err != nil {
+			logger.Error(err, "Failed to get watched objects", "kind", watched.Kind, "name", watched.Name)
+			if apierrors.IsNotFound(err) && watched.Name != "" {
+				// Reported together with the other missing resources (see missingWatchedResources)
+				setWatchedResourceStatus(&config.Status, util.QualifiedKind(gvk), config.Namespace, watched.Name, util.WatchedResourceMissing, "", false)
+			} else {
+				util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+					util.ReasonResourceNotFound, fmt.Sprintf("%s %s not found", watched.Kind, watched.Name))
+			}
+			continue
+		}
+
+		statusKind := util.QualifiedKind(gvk)
+		pathsDigest := util.JSONPathsDigest(watched.JSONPaths)
+		for i := range objects {
+			obj := &objects[i]
+			resourceKey := util.MakeResourceKey(obj.GetNamespace(), statusKind, obj.GetName())
+			hash, exists := config.Status.WatchedResourceHashes[resourceKey]
+			if !exists || config.Status.WatchedObjectPaths[resourceKey] != pathsDigest {
+				hash, err = util.CalculateObjectHash(obj, watched.JSONPaths)
+				if err != nil {
+					logger.Error(err, "Failed to hash watched object", "object", resourceKey)
+					continue
+				}
+				config.Status.WatchedResourceHashes[resourceKey] = hash
+				setWatchedObjectPaths(&config.Status, resourceKey, pathsDigest)
+				r.objectHashes.Store(objectHashCacheKey(config, resourceKey), objectHash{hash: hash, pathsDigest: pathsDigest})
+				logger.V(1).Info("Initialized object hash", "object", resourceKey, "hash", hash, "rebaselined", exists)
+			}
+
+			// The entry shows the baseline hash until the object controller processes the change
+			setWatchedResourceStatus(&config.Status, statusKind, obj.GetNamespace(), obj.GetName(),
+				r.watchedResourceState(config, gvk.Kind, obj), hash, false)
+		}
+	}
+
+	return valid
+}
+
+// listWatchedObjects returns the objects selected by a watched object entry
+func (r *ReloaderConfigReconciler) listWatchedObjects(
+	ctx context.Context,
+	watched reloaderv1alpha1.WatchedObject,
+	gvk schema.GroupVersionKind,
+	namespace string,
+) ([]unstructured.Unstructured, error) {
+	if watched.Name != "" {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := r.objectCache.Get(ctx, client.ObjectKey{Namespace: namespace, Name: watched.Name}, obj); err != nil {
+			return nil, err
+		}
+		return []unstructured.Unstructured{*obj}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(watched.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.objectCache.List(ctx, list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// reconcileObject handles changes of watched objects of arbitrary kinds
+//
+// Business Logic Flow:
+//  1. Find the ReloaderConfigs whose spec.watchedResources.objects select the object
+//  2. For each, hash the configured JSONPaths and compare with the last known hash
+//  3. On a change, reload the ReloaderConfig's targets through the same pipeline
+//     as Secrets and ConfigMaps (targeted reload filter, pause periods, alerts)
+//  4. Record the new hash in the ReloaderConfig status
+//
+// Hashes are computed per ReloaderConfig because each one may hash different
+// fields of the same object.
+func (r *ReloaderConfigReconciler) reconcileObject(ctx context.Context, req objectRequest) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("gvk", req.GVK.String(), "object", req.ObjectKey.String())
+	ctx = log.IntoContext(ctx, logger)
+
+	// Requests queued before a rebalance may belong to another replica by now
+	if !r.ownsNamespace(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(req.GVK)
+	tombstoneKey := util.MakeResourceKey(req.Namespace, util.QualifiedKind(req.GVK), req.Name)
+	if err := r.objectCache.Get(ctx, req.ObjectKey, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		result, err := r.reconcileObjectDeleted(ctx, req)
+		if err == nil {
+			r.tombstones.Delete(tombstoneKey)
+		}
+		return result, err
+	}
+	// A tombstone left by an earlier delete is stale once the name is reused
+	r.tombstones.Delete(tombstoneKey)
+
+	if obj.GetAnnotations()[util.AnnotationIgnore] == "true" {
+		logger.V(1).Info("Object marked as ignored, skipping reload")
+		return ctrl.Result{}, nil
+	}
+	if !util.ResourceInClass(obj.GetAnnotations(), r.ClassName) {
+		logger.V(1).Info("Object belongs to another class, skipping reload")
+		return ctrl.Result{}, nil
+	}
+
+	watches, err := r.WorkloadFinder.FindReloaderConfigsWatchingObject(ctx, req.GVK, obj.GetName(), obj.GetNamespace(), obj.GetLabels())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, watch := range watches {
+		r.reconcileObjectWatch(ctx, watch, obj)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileObjectWatch processes an object change for a single ReloaderConfig
+func (r *ReloaderConfigReconciler) reconcileObjectWatch(
+	ctx context.Context,
+	watch workload.ObjectWatch,
+	obj *unstructured.Unstructured,
+) {
+	logger := log.FromContext(ctx)
+	config := watch.Config
+	resourceKind, resourceName, resourceNamespace := obj.GetKind(), obj.GetName(), obj.GetNamespace()
+
+	if r.shouldIgnoreResource(config, resourceKind, resourceName, resourceNamespace) {
+		logger.Info("Ignoring object due to ignoreResources configuration", "config", config.Name)
+		return
+	}
+
+	currentHash, err := util.CalculateObjectHash(obj, watch.Object.JSONPaths)
+	if err != nil {
+		logger.Error(err, "Failed to hash watched object", "config", config.Name)
+		return
+	}
+
+	// Hashes and status entries are keyed by the group-qualified kind, reloads and alerts use the kind
+	statusKind := util.QualifiedKind(obj.GroupVersionKind())
+	resourceKey := util.MakeResourceKey(resourceNamespace, statusKind, resourceName)
+	cacheKey := objectHashCacheKey(config, resourceKey)
+
+	// The in-memory hash wins over the status, which is updated asynchronously.
+	// A hash of other JSONPaths (the spec changed) is no baseline for this one.
+	pathsDigest := util.JSONPathsDigest(watch.Object.JSONPaths)
+	storedHash, known := config.Status.WatchedResourceHashes[resourceKey]
+	known = known && config.Status.WatchedObjectPaths[resourceKey] == pathsDigest
+	if cached, ok := r.objectHashes.Load(cacheKey); ok && cached.(objectHash).pathsDigest == pathsDigest {
+		storedHash, known = cached.(objectHash).hash, true
+	}
+
+	if known && storedHash == currentHash {
+		logger.V(1).Info("Watched object fields unchanged, skipping reload", "config", config.Name, "hash", currentHash)
+		return
+	}
+	r.objectHashes.Store(cacheKey, objectHash{hash: currentHash, pathsDigest: pathsDigest})
+
+	if !known {
+		// First time this object is seen with these JSONPaths (e.g., created after the ReloaderConfig) - record its baseline
+		logger.Info("Recording baseline hash for watched object", "config", config.Name, "hash", currentHash)
+		r.queueResourceHashUpdate(config, resourceNamespace, statusKind, resourceName, currentHash, pathsDigest)
+		return
+	}
+
+	logger.Info("Watched object changed", "config", config.Name, "oldHash", storedHash, "newHash", currentHash)
+	r.emitResourceChanged(ctx, resourceKind, resourceName, resourceNamespace, storedHash, currentHash)
+
+	configs := []*reloaderv1alpha1.ReloaderConfig{config}
+	targets := r.mergeTargets(configs, nil)
+	filteredTargets := r.filterTargetsForTargetedReload(ctx, targets, resourceKind, resourceName, resourceNamespace)
+	successCount := r.executeReloads(ctx, filteredTargets, resourceKind, resourceName, resourceNamespace, storedHash, currentHash)
+
+	r.recordResourceChange(ctx, configs, resourceNamespace, statusKind, resourceName, currentHash, pathsDigest, successCount > 0)
+}
+
+// reconcileObjectDeleted handles deletion of a watched object
+//
+// Business Logic:
+// The object's labels are gone, so the ReloaderConfigs that watched it are
+// the ones tracking its hash. With reload-on-delete (the flag, or the
+// annotation kept in the object's tombstone) their targets are reloaded using
+// the delete strategy, unless the object was ignored or of another class; in
+// any case the hash entry is removed.
+func (r *ReloaderConfigReconciler) reconcileObjectDeleted(ctx context.Context, req objectRequest) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	resourceKind, resourceName, resourceNamespace := req.GVK.Kind, req.Name, req.Namespace
+	statusKind := util.QualifiedKind(req.GVK)
+	resourceKey := util.MakeResourceKey(resourceNamespace, statusKind, resourceName)
+
+	// The delete predicate leaves a tombstone for deletes it saw
+	tombstone, _ := r.loadTombstone(statusKind, req.ObjectKey)
+	reload := util.ShouldReloadOnDelete(r.ReloadOnDelete, tombstone.Annotations)
+	if tombstone.Annotations[util.AnnotationIgnore] == "true" {
+		logger.V(1).Info("Object marked as ignored, skipping reload on delete")
+		reload = false
+	} else if !util.ResourceInClass(tombstone.Annotations, r.ClassName) {
+		logger.V(1).Info("Object belongs to another class, skipping reload on delete")
+		reload = false
+	}
+
+	configList := &reloaderv1alpha1.ReloaderConfigList{}
+	if err := r.List(ctx, configList, client.InNamespace(resourceNamespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	configs := []*reloaderv1alpha1.ReloaderConfig{}
+	previousHash := ""
+	for i := range configList.Items {
+		config := &configList.Items[i]
+		if config.Spec.ReloaderClassName != r.ClassName {
+			continue
+		}
+		cacheKey := objectHashCacheKey(config, resourceKey)
+
+		// The in-memory hash wins over the status, like for updates
+		lastHash, tracked := config.Status.WatchedResourceHashes[resourceKey]
+		if cachedHash, cached := r.objectHashes.LoadAndDelete(cacheKey); cached {
+			lastHash, tracked = cachedHash.(objectHash).hash, true
+		}
+		if tracked {
+			configs = append(configs, config)
+			previousHash = lastHash
+		}
+	}
+	if len(configs) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Watched object deleted", "configs", len(configs))
+
+	successCount := 0
+	if reload && r.controllersInitialized.Load() {
+		r.emitResourceChanged(ctx, resourceKind, resourceName, resourceNamespace, previousHash, "")
+		targets := r.filterTargetsForTargetedReload(ctx, r.mergeTargets(configs, nil), resourceKind, resourceName, resourceNamespace)
+		successCount = r.executeDeleteReloads(ctx, targets, resourceKind, resourceName, resourceNamespace, previousHash)
+	}
+
+	r.removeReloaderConfigStatusEntries(ctx, configs, resourceNamespace, statusKind, resourceName, successCount > 0)
+	return ctrl.Result{}, nil
+}
+
+// coreWatchedResourceFields maps the core kinds that cannot be watched as objects to their spec.watchedResources field
+var coreWatchedResourceFields = map[string]string{
+	util.KindSecret:    "secrets",
+	util.KindConfigMap: "configMaps",
+}
+
+// watchedObjectGVK validates a watched object entry and returns its GroupVersionKind
+func watchedObjectGVK(watched reloaderv1alpha1.WatchedObject) (schema.GroupVersionKind, error) {
+	gv, err := schema.ParseGroupVersion(watched.APIVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	if watched.Kind == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("kind is required")
+	}
+	// A generic informer would cache their full payload, bypassing the digest-only cache of the resource controllers
+	if field, ok := coreWatchedResourceFields[watched.Kind]; ok && gv.Group == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("%ss cannot be watched as objects, use watchedResources.%s instead", watched.Kind, field)
+	}
+	if (watched.Name == "") == (watched.Selector == nil) {
+		return schema.GroupVersionKind{}, fmt.Errorf("exactly one of name and selector must be set")
+	}
+	if watched.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(watched.Selector); err != nil {
+			return schema.GroupVersionKind{}, err
+		}
+	}
+	return gv.WithKind(watched.Kind), nil
+}
+
+// objectHash is the last hash of a watched object seen by one ReloaderConfig, with the JSONPaths it covers
+type objectHash struct {
+	hash        string
+	pathsDigest string
+}
+
+// objectHashCacheKey identifies the hash of an object as seen by one ReloaderConfig
+func objectHashCacheKey(config *reloaderv1alpha1.ReloaderConfig, resourceKey string) string {
+	return client.ObjectKeyFromObject(config).String() + "|" + resourceKey
+}
+
+// forgetObjectHashes drops the in-memory hashes of objects a ReloaderConfig no longer watches
+// A nil config (the ReloaderConfig was deleted) drops all of its hashes.
+func (r *ReloaderConfigReconciler) forgetObjectHashes(configKey client.ObjectKey, config *reloaderv1alpha1.ReloaderConfig) {
+	prefix := configKey.String() + "|"
+	r.objectHashes.Range(func(key, _ any) bool {
+		resourceKey, found := strings.CutPrefix(key.(string), prefix)
+		if !found {
+			return true
+		}
+		_, kind, name, err := util.ParseResourceKey(resourceKey)
+		if config == nil || err != nil || !watchesObject(config, kind, name) {
+			r.objectHashes.Delete(key)
+		}
+		return true
+	})
+}
+
+// watchesObject checks if a spec.watchedResources.objects entry of a ReloaderConfig may select an object
+// kind is the group-qualified kind (see util.QualifiedKind); a selector entry may select any name.
+func watchesObject(config *reloaderv1alpha1.ReloaderConfig, kind, name string) bool {
+	if config.Spec.WatchedResources == nil {
+		return false
+	}
+	return slices.ContainsFunc(config.Spec.WatchedResources.Objects, func(object reloaderv1alpha1.WatchedObject) bool {
+		gv, err := schema.ParseGroupVersion(object.APIVersion)
+		if err != nil || util.QualifiedKind(gv.WithKind(object.Kind)) != kind {
+			return false
+		}
+		return object.Name == "" || object.Name == name
+	})
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/alerts"
+	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
+)
+
+// pendingReload is a reload left to be done later, stored in the util.AnnotationReloadPending annotation of its workload
+// under the key of the changed resource. It keeps what the reload needs from the target, and the change that triggered it.
+type pendingReload struct {
+	// NotifyAt is when a delayed pod notification is sent. A reload handed over
+	// by another replica has none: it is done as soon as possible, pause check included.
+	NotifyAt time.Time `json:"notifyAt,omitzero"`
+	StoredAt time.Time `json:"storedAt"`        // Tells apart identical reloads stored one after the other
+	Class    string    `json:"class,omitempty"` // --reloader-class-name of the instance that does it
+
+	ResourceKind      string `json:"resourceKind"`
+	ResourceName      string `json:"resourceName"`
+	ResourceNamespace string `json:"resourceNamespace,omitempty"`
+	ResourceDeleted   bool   `json:"resourceDeleted,omitempty"` // A delete reload (--reload-on-delete)
+	PreviousHash      string `json:"previousHash,omitempty"`
+	ResourceHash      string `json:"resourceHash,omitempty"`
+
+	ConfigName      string                          `json:"configName,omitempty"` // ReloaderConfig of the target, if any
+	ConfigNamespace string                          `json:"configNamespace,omitempty"`
+	RolloutStrategy string                          `json:"rolloutStrategy,omitempty"`
+	ReloadStrategy  string                          `json:"reloadStrategy,omitempty"`
+	PausePeriod     string                          `json:"pausePeriod,omitempty"`
+	Rule            string                          `json:"rule,omitempty"`
+	Notify          *reloaderv1alpha1.NotifyConfig  `json:"notify,omitempty"`
+	EnvVars         *reloaderv1alpha1.EnvVarsConfig `json:"envVars,omitempty"`
+}
+
+// storePendingReload stores a reload of target on its workload, replacing the one pending for the same resource
+func (r *ReloaderConfigReconciler) storePendingReload(ctx context.Context, target workload.Target, reload pendingReload) error {
+	reload.StoredAt = time.Now().UTC()
+	reload.Class = r.ClassName
+	reload.RolloutStrategy = target.RolloutStrategy
+	reload.ReloadStrategy = target.ReloadStrategy
+	reload.PausePeriod = target.PausePeriod
+	reload.Rule = target.Rule
+	reload.Notify = target.Notify
+	reload.EnvVars = target.EnvVars
+	if target.Config != nil {
+		reload.ConfigName = target.Config.Name
+		reload.ConfigNamespace = target.Config.Namespace
+	}
+
+	encoded, err := json.Marshal(reload)
+	if err != nil {
+		return err
+	}
+	key := util.MakeResourceKey(reload.ResourceNamespace, reload.ResourceKind, reload.ResourceName)
+	return r.WorkloadUpdater.SetPendingReload(ctx, target, key, encoded)
+}
+
+// scheduleNotification stores the pod notification of a target with a notify delay on its workload
+//
+// Business Logic:
+// The delay gives the kubelet time to refresh mounted volumes. Waiting for it
+// in reloadTarget would hold the workload lock and a reload worker (and so the
+// reconcile) for the whole delay. Keeping it on a timer would lose it on a
+// restart, crash or shard rebalance, while the resource hash is already
+// stored, so nothing would retry it. The notification is therefore stored on
+// the workload and sent by its reload request controller (see
+// reconcilePendingReloads), which requeues it until the delay has passed.
+// The change records the target as Scheduled.
+func (r *ReloaderConfigReconciler) scheduleNotification(
+	ctx context.Context,
+	target workload.Target,
+	delay time.Duration,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
+) reloadResult {
+	err := r.storePendingReload(ctx, target, pendingReload{
+		NotifyAt:          time.Now().Add(delay).UTC(),
+		ResourceKind:      resourceKind,
+		ResourceName:      resourceName,
+		ResourceNamespace: resourceNamespace,
+		PreviousHash:      previousHash,
+		ResourceHash:      resourceHash,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to schedule pod notification: %w", err)
+		return r.finishReload(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash, err)
+	}
+
+	log.FromContext(ctx).Info("Scheduled pod notification",
+		"kind", target.Kind,
+		"name", target.Name,
+		"namespace", target.Namespace,
+		"delay", delay)
+	return reloadResult{target: target, outcome: util.ReloadOutcomeScheduled,
+		message: fmt.Sprintf("pods are notified in %s", delay)}
+}
+
+// handOverReload stores the reload of a target in a namespace owned by another replica on its workload
+//
+// Business Logic:
+// With --enable-sharding a replica only reloads the workloads of its own
+// namespaces, so the per-workload lock (which only spans one process) covers
+// every reload of a workload: two replicas never both pass its pause check or
+// patch its template at once. The target of a change can still live in
+// another namespace (a ReloaderConfig target in another namespace, or a
+// manual reload request of a ReloaderConfig), so the replica handling the
+// change hands the reload over to the owner of the workload's namespace,
+// which does it from its reload request controller (see
+// reconcilePendingReloads). Storing it only writes an annotation with
+// optimistic locking, which cannot overwrite a concurrent reload.
+// The change records the target as Scheduled.
+func (r *ReloaderConfigReconciler) handOverReload(
+	ctx context.Context,
+	target workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	resourceDeleted bool,
+	previousHash string,
+	resourceHash string,
+) reloadResult {
+	err := r.storePendingReload(ctx, target, pendingReload{
+		ResourceKind:      resourceKind,
+		ResourceName:      resourceName,
+		ResourceNamespace: resourceNamespace,
+		ResourceDeleted:   resourceDeleted,
+		PreviousHash:      previousHash,
+		ResourceHash:      resourceHash,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to hand over the reload to the owner of namespace %s: %w", target.Namespace, err)
+		return r.finishReload(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash, err)
+	}
+
+	log.FromContext(ctx).Info("Handed over reload to the owner of the namespace",
+		"kind", target.Kind,
+		"name", target.Name,
+		"namespace", target.Namespace)
+	return reloadResult{target: target, outcome: util.ReloadOutcomeScheduled,
+		message: fmt.Sprintf("reloaded by the replica owning namespace %s", target.Namespace)}
+}
+
+// reconcilePendingReloads does the pending reloads of a workload once they are due
+//
+// Business Logic:
+// A pending pod notification is sent once its notify delay has passed; until
+// then how long to wait is returned for the caller to requeue. A handed-over
+// reload is done right away, like by the replica that handed it over (pause
+// check, rollout or notification). Either reports its outcome like an
+// immediate reload (alerts, events, target status) and adds its own reload
+// history entry. It is removed from the workload only once it was done, so a
+// crash in between does it again rather than never. Reloads of another
+// operator class are left alone, and those of a deleted ReloaderConfig are
+// dropped.
+func (r *ReloaderConfigReconciler) reconcilePendingReloads(ctx context.Context, kind string, obj client.Object) (time.Duration, error) {
+	target := workload.Target{Kind: kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}
+
+	reloads, err := workload.PendingReloads(obj.GetAnnotations())
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Dropping invalid pending reloads", "kind", kind, "name", obj.GetName())
+		return 0, r.WorkloadUpdater.ClearPendingReload(ctx, target, "", nil)
+	}
+
+	var wait time.Duration
+	for _, key := range slices.Sorted(maps.Keys(reloads)) {
+		after, err := r.runPendingReload(ctx, target, key, reloads[key])
+		if err != nil {
+			return 0, err
+		}
+		if after > 0 && (wait == 0 || after < wait) {
+			wait = after
+		}
+	}
+	return wait, nil
+}
+
+// runPendingReload does one pending reload of a workload if it is due, or returns how long to wait for it
+func (r *ReloaderConfigReconciler) runPendingReload(ctx context.Context, target workload.Target, key string, encoded json.RawMessage) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	var reload pendingReload
+	if err := json.Unmarshal(encoded, &reload); err != nil {
+		logger.Error(err, "Dropping invalid pending reload", "kind", target.Kind, "name", target.Name, "key", key)
+		return 0, r.WorkloadUpdater.ClearPendingReload(ctx, target, key, encoded)
+	}
+	if reload.Class != r.ClassName {
+		return 0, nil
+	}
+	if wait := time.Until(reload.NotifyAt); !reload.NotifyAt.IsZero() && wait > 0 {
+		return wait, nil
+	}
+
+	target.RolloutStrategy = reload.RolloutStrategy
+	target.ReloadStrategy = reload.ReloadStrategy
+	target.PausePeriod = reload.PausePeriod
+	target.Rule = reload.Rule
+	target.Notify = reload.Notify
+	target.EnvVars = reload.EnvVars
+	if reload.ConfigName != "" {
+		config := &reloaderv1alpha1.ReloaderConfig{}
+		configKey := client.ObjectKey{Namespace: reload.ConfigNamespace, Name: reload.ConfigName}
+		if err := r.Get(ctx, configKey, config); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return 0, err
+			}
+			logger.Info("Dropping pending reload of a deleted ReloaderConfig",
+				"kind", target.Kind, "name", target.Name, "config", configKey.String())
+			return 0, r.WorkloadUpdater.ClearPendingReload(ctx, target, key, encoded)
+		}
+		target.Config = config
+	}
+
+	started := time.Now()
+	var result reloadResult
+	switch {
+	case !reload.NotifyAt.IsZero():
+		target.RolloutStrategy = util.RolloutStrategyNotify
+		unlock := r.workloadLocks.Lock(util.MakeResourceKey(target.Namespace, target.Kind, target.Name))
+		err := r.notifyTarget(ctx, &target, reload.ResourceKind, reload.ResourceName, reload.ResourceNamespace, reload.ResourceHash)
+		result = r.finishReload(ctx, target, reload.ResourceKind, reload.ResourceName, reload.ResourceNamespace,
+			reload.PreviousHash, reload.ResourceHash, err)
+		unlock()
+	case reload.ResourceDeleted:
+		result = r.deleteReloadTarget(ctx, target, reload.ResourceKind, reload.ResourceName, reload.ResourceNamespace, reload.PreviousHash)
+	default:
+		result = r.reloadTarget(ctx, target, reload.ResourceKind, reload.ResourceName, reload.ResourceNamespace,
+			reload.PreviousHash, reload.ResourceHash)
+	}
+
+	r.AlertManager.FlushDigest(ctx, alerts.ChangeKey(reload.ResourceKind, reload.ResourceNamespace, reload.ResourceName, reload.ResourceHash))
+	r.recordReloadHistory(reload.ResourceKind, reload.ResourceName, reload.ResourceNamespace,
+		reload.ResourceHash, started, []reloadResult{result})
+
+	// A handed-over reload that scheduled a notification replaced itself, which is kept
+	return 0, r.WorkloadUpdater.ClearPendingReload(ctx, target, key, encoded)
+}
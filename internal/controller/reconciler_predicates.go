@@ -20,8 +20,12 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
 )
 
 // secretPredicates returns predicate functions for Secret event filtering
@@ -61,8 +65,11 @@ func (r *ReloaderConfigReconciler) secretPredicates() predicate.Funcs {
 			if r.ResourceLabelSelector != nil && !r.ResourceLabelSelector.Matches(labels.Set(e.Object.GetLabels())) {
 				return false
 			}
-			// Only process deletes if flag is enabled and controllers are initialized
-			return r.ReloadOnDelete && r.controllersInitialized.Load()
+			// Deletes are processed even without reload-on-delete, to update the status of
+			// ReloaderConfigs (see reconcileResourceDeleted). The object is gone once the
+			// request is reconciled - keep its final state
+			r.storeTombstone(util.KindSecret, e.Object)
+			return true
 		},
 	}
 }
@@ -104,8 +111,46 @@ func (r *ReloaderConfigReconciler) configMapPredicates() predicate.Funcs {
 			if r.ResourceLabelSelector != nil && !r.ResourceLabelSelector.Matches(labels.Set(e.Object.GetLabels())) {
 				return false
 			}
-			// Only process deletes if flag is enabled and controllers are initialized
-			return r.ReloadOnDelete && r.controllersInitialized.Load()
+			// Deletes are processed even without reload-on-delete, to update the status of
+			// ReloaderConfigs (see reconcileResourceDeleted). The object is gone once the
+			// request is reconciled - keep its final state
+			r.storeTombstone(util.KindConfigMap, e.Object)
+			return true
+		},
+	}
+}
+
+// configClassPredicates filters out the ReloaderConfigs of other operator instances
+func (r *ReloaderConfigReconciler) configClassPredicates() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		config, ok := obj.(*reloaderv1alpha1.ReloaderConfig)
+		return ok && config.Spec.ReloaderClassName == r.ClassName
+	})
+}
+
+// resourceClassPredicates filters out the Secrets and ConfigMaps assigned to other operator instances
+// Resources without a class are shared by every instance (see util.ResourceInClass).
+func (r *ReloaderConfigReconciler) resourceClassPredicates() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return util.ResourceInClass(obj.GetAnnotations(), r.ClassName)
+	})
+}
+
+// targetWorkloadPredicates returns predicate functions for target workload event filtering
+// Only creation and deletion change whether a ReloaderConfig's targets exist.
+func (r *ReloaderConfigReconciler) targetWorkloadPredicates() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return r.controllersInitialized.Load()
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
 		},
 	}
 }
@@ -18,13 +18,18 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
 	"github.com/stakater/Reloader/internal/pkg/alerts"
+	"github.com/stakater/Reloader/internal/pkg/cloudevents"
+	"github.com/stakater/Reloader/internal/pkg/util"
 	"github.com/stakater/Reloader/internal/pkg/workload"
 )
 
@@ -51,11 +56,17 @@ import (
 // 4. Status Updates:
 //   - Updates target-specific status in ReloaderConfig
 //   - Tracks reload count, timestamp, and any errors
+//   - Records the outcome of every target in the reload history (see recordReloadHistory)
 //
 // Why we handle errors gracefully:
 // If one target fails to reload, we continue with other targets.
 // This prevents one bad workload from blocking all reloads.
 //
+// Concurrency:
+// Targets are reloaded by up to MaxConcurrentReloads workers, since each one
+// costs several API round trips. A workload is only ever mutated by one
+// goroutine at a time (see reloadTarget), also across concurrent reconciles.
+//
 // Returns the number of successful reloads.
 func (r *ReloaderConfigReconciler) executeReloads(
 	ctx context.Context,
@@ -63,70 +74,213 @@ func (r *ReloaderConfigReconciler) executeReloads(
 	resourceKind string,
 	resourceName string,
 	resourceNamespace string,
+	previousHash string,
 	resourceHash string,
 ) int {
-	logger := log.FromContext(ctx)
 	successCount := 0
+	for _, result := range r.reloadTargets(ctx, targets, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash) {
+		if result.reloaded() {
+			successCount++
+		}
+	}
+	return successCount
+}
 
-	for _, target := range targets {
-		// Refetch the ReloaderConfig to get the latest status (including PausedUntil)
-		// This ensures we have fresh pause period information from the API server
-		if target.Config != nil {
-			freshConfig := &reloaderv1alpha1.ReloaderConfig{}
-			configKey := client.ObjectKey{
-				Name:      target.Config.Name,
-				Namespace: target.Config.Namespace,
-			}
-			if err := r.Get(ctx, configKey, freshConfig); err != nil {
-				logger.Error(err, "Failed to fetch fresh ReloaderConfig for pause check",
-					"config", target.Config.Name)
-				// Continue with existing config rather than blocking the reload
-			} else {
-				// Update the target's Config reference with fresh data
-				target.Config = freshConfig
+// reloadTargets reloads the targets of a change like executeReloads, returning the outcome of each target
+func (r *ReloaderConfigReconciler) reloadTargets(
+	ctx context.Context,
+	targets []workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
+) []reloadResult {
+	started := time.Now()
+	results := r.runReloadWorkers(targets, func(target workload.Target) reloadResult {
+		return r.reloadTarget(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash)
+	})
+
+	// All targets of this change are processed - send its alert digest (if enabled)
+	r.AlertManager.FlushDigest(ctx, alerts.ChangeKey(resourceKind, resourceNamespace, resourceName, resourceHash))
+	r.recordReloadHistory(resourceKind, resourceName, resourceNamespace, resourceHash, started, results)
+
+	return results
+}
+
+// runReloadWorkers runs reload for every target on up to MaxConcurrentReloads workers, returning the outcome of each target
+func (r *ReloaderConfigReconciler) runReloadWorkers(targets []workload.Target, reload func(workload.Target) reloadResult) []reloadResult {
+	var results []reloadResult
+	var resultsMu sync.Mutex
+
+	workers := min(max(r.MaxConcurrentReloads, 1), len(targets))
+	pending := make(chan workload.Target)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range pending {
+				result := reload(target)
+				resultsMu.Lock()
+				results = append(results, result)
+				resultsMu.Unlock()
 			}
-		}
+		}()
+	}
+	for _, target := range targets {
+		pending <- target
+	}
+	close(pending)
+	wg.Wait()
 
-		// Check if workload is in pause period (rate limiting)
-		isPaused, err := r.WorkloadUpdater.IsPaused(ctx, target)
-		if err != nil {
-			logger.Error(err, "Failed to check pause status", "workload", target.Name)
-			continue
-		}
+	return results
+}
+
+// reloadTarget runs the pause check and reload of one target, returning its outcome
+//
+// Business Logic:
+// The workload stays locked from the pause check until its status update is
+// queued, so two changes reloading the same workload at once cannot both pass
+// the pause check or overwrite each other's template change. The lock only
+// spans this process, so with sharding the reload of a workload in a namespace
+// owned by another replica is handed over to that replica (see handOverReload).
+func (r *ReloaderConfigReconciler) reloadTarget(
+	ctx context.Context,
+	target workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
+) reloadResult {
+	logger := log.FromContext(ctx)
+
+	if !r.ownsNamespace(target.Namespace) {
+		return r.handOverReload(ctx, target, resourceKind, resourceName, resourceNamespace, false, previousHash, resourceHash)
+	}
+
+	unlock := r.workloadLocks.Lock(util.MakeResourceKey(target.Namespace, target.Kind, target.Name))
+	defer unlock()
 
-		if isPaused {
-			logger.Info("Skipping reload - workload is in pause period",
-				"kind", target.Kind,
-				"name", target.Name,
-				"namespace", target.Namespace)
-			continue
+	// Refetch the ReloaderConfig to get the latest status (including PausedUntil)
+	// This ensures we have fresh pause period information from the API server
+	if target.Config != nil {
+		freshConfig := &reloaderv1alpha1.ReloaderConfig{}
+		configKey := client.ObjectKey{
+			Name:      target.Config.Name,
+			Namespace: target.Config.Namespace,
+		}
+		if err := r.Get(ctx, configKey, freshConfig); err != nil {
+			logger.Error(err, "Failed to fetch fresh ReloaderConfig for pause check",
+				"config", target.Config.Name)
+			// Continue with existing config rather than blocking the reload
+		} else {
+			// Update the target's Config reference with fresh data
+			target.Config = freshConfig
 		}
+	}
 
-		// Trigger the reload (rolling restart)
-		err = r.WorkloadUpdater.TriggerReload(ctx, target, resourceKind, resourceName, resourceNamespace, resourceHash)
-		if err != nil {
-			// Reload failed - log error, send alert, update status
-			logger.Error(err, "Failed to reload workload",
-				"kind", target.Kind,
-				"name", target.Name,
-				"namespace", target.Namespace)
-
-			r.handleReloadError(ctx, target, resourceKind, resourceName, err)
-			continue
+	// Check if workload is in pause period (rate limiting)
+	isPaused, err := r.WorkloadUpdater.IsPaused(ctx, target)
+	if err != nil {
+		logger.Error(err, "Failed to check pause status", "workload", target.Name)
+		return reloadResult{target: target, outcome: util.ReloadOutcomeFailed, message: err.Error()}
+	}
+
+	if isPaused {
+		logger.Info("Skipping reload - workload is in pause period",
+			"kind", target.Kind,
+			"name", target.Name,
+			"namespace", target.Namespace)
+		r.handleReloadSkipped(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash, "workload is in pause period")
+		return reloadResult{target: target, outcome: util.ReloadOutcomeSkipped, message: "workload is in pause period"}
+	}
+
+	r.emitReloadStarted(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash)
+
+	// Trigger the reload (rolling restart, or in-place notification)
+	if target.RolloutStrategy == util.RolloutStrategyNotify {
+		if delay := workload.NotifyDelay(target); delay > 0 {
+			return r.scheduleNotification(ctx, target, delay, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash)
 		}
+		err = r.notifyTarget(ctx, &target, resourceKind, resourceName, resourceNamespace, resourceHash)
+	} else {
+		err = r.WorkloadUpdater.TriggerReload(ctx, target, resourceKind, resourceName, resourceNamespace, resourceHash)
+	}
+	return r.finishReload(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash, err)
+}
 
-		// Reload succeeded
-		logger.Info("Successfully triggered reload",
+// finishReload reports the outcome of a triggered reload (alerts, CloudEvents, target status)
+func (r *ReloaderConfigReconciler) finishReload(
+	ctx context.Context,
+	target workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
+	err error,
+) reloadResult {
+	logger := log.FromContext(ctx)
+
+	if err != nil {
+		// Reload failed - log error, send alert, update status
+		logger.Error(err, "Failed to reload workload",
 			"kind", target.Kind,
 			"name", target.Name,
-			"namespace", target.Namespace,
-			"strategy", target.ReloadStrategy)
+			"namespace", target.Namespace)
 
-		r.handleReloadSuccess(ctx, target, resourceKind, resourceName)
-		successCount++
+		r.handleReloadError(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash, err)
+		return reloadResult{target: target, outcome: util.ReloadOutcomeFailed, message: err.Error()}
 	}
 
-	return successCount
+	// Reload succeeded
+	logger.Info("Successfully triggered reload",
+		"kind", target.Kind,
+		"name", target.Name,
+		"namespace", target.Namespace,
+		"strategy", target.ReloadStrategy)
+
+	r.handleReloadSuccess(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, resourceHash)
+	return reloadResult{target: target, outcome: util.ReloadOutcomeSucceeded}
+}
+
+// notifyTarget reloads a target with the notify rollout strategy
+//
+// Business Logic:
+// The Ready pods are asked to reload in place (see Updater.NotifyPods) and the
+// per-pod outcome is kept on the target for its status. If any pod could not
+// be notified, the fallback decides: "rollout" (the default) reloads the
+// workload with the rollout strategy and the target's reload strategy, so pods
+// that missed the notification still get the new configuration; "none" only
+// reports the failure.
+func (r *ReloaderConfigReconciler) notifyTarget(
+	ctx context.Context,
+	target *workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	resourceHash string,
+) error {
+	results, err := r.WorkloadUpdater.NotifyPods(ctx, *target)
+	target.PodNotifications = results
+	if err == nil || workload.NotifyFallback(*target) != util.NotifyFallbackRollout {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Notification failed, falling back to a rollout",
+		"kind", target.Kind,
+		"name", target.Name,
+		"namespace", target.Namespace,
+		"reason", err.Error())
+
+	rollout := *target
+	rollout.RolloutStrategy = util.RolloutStrategyRollout
+	if rolloutErr := r.WorkloadUpdater.TriggerReload(ctx, rollout, resourceKind, resourceName, resourceNamespace, resourceHash); rolloutErr != nil {
+		return fmt.Errorf("%w; fallback rollout failed: %w", err, rolloutErr)
+	}
+	return nil
 }
 
 // handleReloadError handles failed reload attempts
@@ -134,7 +288,8 @@ func (r *ReloaderConfigReconciler) executeReloads(
 // Business Logic:
 // When a reload fails (e.g., workload not found, API error):
 // 1. Send error alert to configured channels (Slack, Teams, Google Chat)
-// 2. Update target status with error message
+// 2. Emit a reload-failed CloudEvent (if configured) with the same message
+// 3. Update target status with error message
 //
 // This provides immediate notification to operators when reloads fail.
 func (r *ReloaderConfigReconciler) handleReloadError(
@@ -142,6 +297,9 @@ func (r *ReloaderConfigReconciler) handleReloadError(
 	target workload.Target,
 	resourceKind string,
 	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
 	reloadErr error,
 ) {
 	logger := log.FromContext(ctx)
@@ -157,10 +315,14 @@ func (r *ReloaderConfigReconciler) handleReloadError(
 		reloadErr.Error(),
 	)
 	message.Timestamp = time.Now()
+	message.ResourceNamespace = resourceNamespace
+	message.ResourceHash = resourceHash
+	message.PreviousResourceHash = previousHash
 
-	if alertErr := r.AlertManager.SendReloadAlert(ctx, message); alertErr != nil {
+	if alertErr := r.sendReloadAlert(ctx, target.Config, message); alertErr != nil {
 		logger.Error(alertErr, "Failed to send error alerts", "workload", target.Name)
 	}
+	r.emitEvent(ctx, cloudevents.TypeReloadFailed, message)
 
 	// Update target status with error message
 	if target.Config != nil {
@@ -173,7 +335,8 @@ func (r *ReloaderConfigReconciler) handleReloadError(
 // Business Logic:
 // When a reload succeeds:
 // 1. Send success alert to configured channels (optional, for audit trail)
-// 2. Update target status (reload count, timestamp, clear any previous errors)
+// 2. Emit a reload-succeeded CloudEvent (if configured) with the same message
+// 3. Update target status (reload count, timestamp, clear any previous errors)
 //
 // Success alerts are useful for audit trails and monitoring reload frequency.
 func (r *ReloaderConfigReconciler) handleReloadSuccess(
@@ -181,6 +344,9 @@ func (r *ReloaderConfigReconciler) handleReloadSuccess(
 	target workload.Target,
 	resourceKind string,
 	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
 ) {
 	logger := log.FromContext(ctx)
 
@@ -194,10 +360,14 @@ func (r *ReloaderConfigReconciler) handleReloadSuccess(
 		target.ReloadStrategy,
 	)
 	message.Timestamp = time.Now()
+	message.ResourceNamespace = resourceNamespace
+	message.ResourceHash = resourceHash
+	message.PreviousResourceHash = previousHash
 
-	if err := r.AlertManager.SendReloadAlert(ctx, message); err != nil {
+	if err := r.sendReloadAlert(ctx, target.Config, message); err != nil {
 		logger.Error(err, "Failed to send success alerts", "workload", target.Name)
 	}
+	r.emitEvent(ctx, cloudevents.TypeReloadSucceeded, message)
 
 	// Update target status (clears any previous error)
 	if target.Config != nil {
@@ -205,6 +375,98 @@ func (r *ReloaderConfigReconciler) handleReloadSuccess(
 	}
 }
 
+// handleReloadSkipped handles targets that were not reloaded (e.g., pause period)
+//
+// Business Logic:
+// Skipped reloads are reported in alert digests (they are dropped when digests
+// are disabled, matching the previous behavior) and as reload-skipped CloudEvents.
+func (r *ReloaderConfigReconciler) handleReloadSkipped(
+	ctx context.Context,
+	target workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+	resourceHash string,
+	reason string,
+) {
+	message := alerts.NewReloadSkippedMessage(
+		target.Kind,
+		target.Name,
+		target.Namespace,
+		resourceKind,
+		resourceName,
+		target.ReloadStrategy,
+		reason,
+	)
+	message.Timestamp = time.Now()
+	message.ResourceNamespace = resourceNamespace
+	message.ResourceHash = resourceHash
+	message.PreviousResourceHash = previousHash
+
+	if err := r.sendReloadAlert(ctx, target.Config, message); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to send skipped alert", "workload", target.Name)
+	}
+	r.emitEvent(ctx, cloudevents.TypeReloadSkipped, message)
+}
+
+// sendReloadAlert sends a reload alert, applying the ReloaderConfig's webhook template if it defines one
+//
+// The template was already validated when the ReloaderConfig was reconciled (see
+// validateAlertTemplate), so a parse failure here only happens if the spec changed
+// in between. In that case the alert falls back to the global payload rather than
+// being lost.
+func (r *ReloaderConfigReconciler) sendReloadAlert(
+	ctx context.Context,
+	config *reloaderv1alpha1.ReloaderConfig,
+	message *alerts.Message,
+) error {
+	webhookTemplate, err := r.configWebhookTemplate(config)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Ignoring invalid alert webhook template", "reloaderConfig", config.Name)
+		webhookTemplate = nil
+	}
+
+	return r.AlertManager.SendReloadAlertWithTemplate(ctx, message, webhookTemplate)
+}
+
+// parsedWebhookTemplate is the webhook template of one generation of a ReloaderConfig
+type parsedWebhookTemplate struct {
+	uid        types.UID
+	generation int64
+	template   *alerts.WebhookTemplate
+	err        error
+}
+
+// configWebhookTemplate returns the webhook payload template defined by a ReloaderConfig
+// Returns nil (and no error) when the config has no template or is nil (annotation-based targets).
+//
+// Parsing includes rendering a sample message (see alerts.NewWebhookTemplate), so
+// the result is cached per ReloaderConfig until its spec (generation) changes.
+func (r *ReloaderConfigReconciler) configWebhookTemplate(config *reloaderv1alpha1.ReloaderConfig) (*alerts.WebhookTemplate, error) {
+	if config == nil || config.Spec.Alerts == nil || config.Spec.Alerts.WebhookTemplate == nil {
+		return nil, nil
+	}
+
+	key := client.ObjectKeyFromObject(config).String()
+	if cached, ok := r.webhookTemplates.Load(key); ok {
+		parsed := cached.(parsedWebhookTemplate)
+		if parsed.uid == config.UID && parsed.generation == config.Generation {
+			return parsed.template, parsed.err
+		}
+	}
+
+	spec := config.Spec.Alerts.WebhookTemplate
+	webhookTemplate, err := alerts.NewWebhookTemplate(spec.Payload, spec.ContentType, spec.Headers)
+	r.webhookTemplates.Store(key, parsedWebhookTemplate{
+		uid:        config.UID,
+		generation: config.Generation,
+		template:   webhookTemplate,
+		err:        err,
+	})
+	return webhookTemplate, err
+}
+
 // executeDeleteReloads executes delete-specific reloads for all target workloads
 //
 // Business Logic:
@@ -213,68 +475,106 @@ func (r *ReloaderConfigReconciler) handleReloadSuccess(
 // - annotations strategy: Sets the annotation to the hash of empty data
 //
 // This is called when a Secret/ConfigMap is deleted and --reload-on-delete is enabled.
+// previousHash is the last known hash of the resource, for the reload events.
+// Targets are reloaded on the same worker pool and under the same per-workload
+// lock as executeReloads, so a delete never races an update on one workload.
 func (r *ReloaderConfigReconciler) executeDeleteReloads(
 	ctx context.Context,
 	targets []workload.Target,
 	resourceKind string,
 	resourceName string,
+	resourceNamespace string,
+	previousHash string,
 ) int {
-	logger := log.FromContext(ctx)
-	successCount := 0
+	started := time.Now()
+	results := r.runReloadWorkers(targets, func(target workload.Target) reloadResult {
+		return r.deleteReloadTarget(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash)
+	})
 
-	for _, target := range targets {
-		// Refetch the ReloaderConfig to get the latest status (including PausedUntil)
-		if target.Config != nil {
-			freshConfig := &reloaderv1alpha1.ReloaderConfig{}
-			configKey := client.ObjectKey{
-				Name:      target.Config.Name,
-				Namespace: target.Config.Namespace,
-			}
-			if err := r.Get(ctx, configKey, freshConfig); err != nil {
-				logger.Error(err, "Failed to fetch fresh ReloaderConfig for pause check",
-					"config", target.Config.Name)
-			} else {
-				target.Config = freshConfig
-			}
-		}
+	r.AlertManager.FlushDigest(ctx, alerts.ChangeKey(resourceKind, resourceNamespace, resourceName, ""))
+	r.recordReloadHistory(resourceKind, resourceName, resourceNamespace, "", started, results)
 
-		// Check if workload is in pause period
-		isPaused, err := r.WorkloadUpdater.IsPaused(ctx, target)
-		if err != nil {
-			logger.Error(err, "Failed to check pause status", "workload", target.Name)
-			continue
+	successCount := 0
+	for _, result := range results {
+		if result.reloaded() {
+			successCount++
 		}
+	}
+	return successCount
+}
 
-		if isPaused {
-			logger.Info("Skipping delete reload - workload is in pause period",
-				"kind", target.Kind,
-				"name", target.Name,
-				"namespace", target.Namespace)
-			continue
-		}
+// deleteReloadTarget runs the pause check and delete reload of one target, returning its outcome
+//
+// The workload is locked, or the reload handed over to the owner of its namespace, like in reloadTarget.
+func (r *ReloaderConfigReconciler) deleteReloadTarget(
+	ctx context.Context,
+	target workload.Target,
+	resourceKind string,
+	resourceName string,
+	resourceNamespace string,
+	previousHash string,
+) reloadResult {
+	logger := log.FromContext(ctx)
 
-		// Trigger the delete reload (using delete strategy)
-		err = r.WorkloadUpdater.TriggerDeleteReload(ctx, target, resourceKind, resourceName)
-		if err != nil {
-			logger.Error(err, "Failed to reload workload on delete",
-				"kind", target.Kind,
-				"name", target.Name,
-				"namespace", target.Namespace)
+	if !r.ownsNamespace(target.Namespace) {
+		return r.handOverReload(ctx, target, resourceKind, resourceName, resourceNamespace, true, previousHash, "")
+	}
 
-			r.handleReloadError(ctx, target, resourceKind, resourceName, err)
-			continue
+	unlock := r.workloadLocks.Lock(util.MakeResourceKey(target.Namespace, target.Kind, target.Name))
+	defer unlock()
+
+	// Refetch the ReloaderConfig to get the latest status (including PausedUntil)
+	if target.Config != nil {
+		freshConfig := &reloaderv1alpha1.ReloaderConfig{}
+		configKey := client.ObjectKey{
+			Name:      target.Config.Name,
+			Namespace: target.Config.Namespace,
 		}
+		if err := r.Get(ctx, configKey, freshConfig); err != nil {
+			logger.Error(err, "Failed to fetch fresh ReloaderConfig for pause check",
+				"config", target.Config.Name)
+		} else {
+			target.Config = freshConfig
+		}
+	}
 
-		// Reload succeeded
-		logger.Info("Successfully triggered delete reload",
+	// Check if workload is in pause period
+	isPaused, err := r.WorkloadUpdater.IsPaused(ctx, target)
+	if err != nil {
+		logger.Error(err, "Failed to check pause status", "workload", target.Name)
+		return reloadResult{target: target, outcome: util.ReloadOutcomeFailed, message: err.Error()}
+	}
+
+	if isPaused {
+		logger.Info("Skipping delete reload - workload is in pause period",
 			"kind", target.Kind,
 			"name", target.Name,
-			"namespace", target.Namespace,
-			"strategy", target.ReloadStrategy)
+			"namespace", target.Namespace)
+		r.handleReloadSkipped(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, "", "workload is in pause period")
+		return reloadResult{target: target, outcome: util.ReloadOutcomeSkipped, message: "workload is in pause period"}
+	}
 
-		r.handleReloadSuccess(ctx, target, resourceKind, resourceName)
-		successCount++
+	r.emitReloadStarted(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, "")
+
+	// Trigger the delete reload (using delete strategy)
+	err = r.WorkloadUpdater.TriggerDeleteReload(ctx, target, resourceKind, resourceName)
+	if err != nil {
+		logger.Error(err, "Failed to reload workload on delete",
+			"kind", target.Kind,
+			"name", target.Name,
+			"namespace", target.Namespace)
+
+		r.handleReloadError(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, "", err)
+		return reloadResult{target: target, outcome: util.ReloadOutcomeFailed, message: err.Error()}
 	}
 
-	return successCount
+	// Reload succeeded
+	logger.Info("Successfully triggered delete reload",
+		"kind", target.Kind,
+		"name", target.Name,
+		"namespace", target.Namespace,
+		"strategy", target.ReloadStrategy)
+
+	r.handleReloadSuccess(ctx, target, resourceKind, resourceName, resourceNamespace, previousHash, "")
+	return reloadResult{target: target, outcome: util.ReloadOutcomeSucceeded}
 }
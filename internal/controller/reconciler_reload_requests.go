@@ -0,0 +1,325 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
+)
+
+// reloadRequestRetryInterval is how long a reload request waits before retrying the targets that failed or were skipped
+const reloadRequestRetryInterval = time.Minute
+
+// reloadRequestState is the in-memory progress of a reload request token
+type reloadRequestState struct {
+	token string
+
+	// handled is set once every target was reloaded, until then retryAt delays the next attempt
+	handled bool
+	retryAt time.Time
+
+	// reloaded holds the targets already reloaded for the token, by resource key
+	reloaded map[string]bool
+}
+
+// reloadRequestReconciler handles manual reload requests and pending pod notifications on workloads of one kind
+type reloadRequestReconciler struct {
+	*ReloaderConfigReconciler
+	kind      string
+	newObject func() client.Object
+}
+
+func newDeployment() client.Object  { return &appsv1.Deployment{} }
+func newStatefulSet() client.Object { return &appsv1.StatefulSet{} }
+func newDaemonSet() client.Object   { return &appsv1.DaemonSet{} }
+
+// setupReloadRequestControllers registers one reload request controller per annotated workload kind
+func (r *ReloaderConfigReconciler) setupReloadRequestControllers(mgr ctrl.Manager) error {
+	for _, workloadKind := range []struct {
+		name      string
+		kind      string
+		newObject func() client.Object
+	}{
+		{"reload-requests-deployments", util.KindDeployment, newDeployment},
+		{"reload-requests-statefulsets", util.KindStatefulSet, newStatefulSet},
+		{"reload-requests-daemonsets", util.KindDaemonSet, newDaemonSet},
+	} {
+		bldr := ctrl.NewControllerManagedBy(mgr).
+			For(workloadKind.newObject(), builder.WithPredicates(r.reloadRequestPredicates(), r.shardPredicates())).
+			Named(workloadKind.name).
+			WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles})
+
+		if resync := r.resyncSource(workloadKind.kind); resync != nil {
+			bldr = bldr.WatchesRawSource(resync)
+		}
+		err := bldr.Complete(&reloadRequestReconciler{
+			ReloaderConfigReconciler: r,
+			kind:                     workloadKind.kind,
+			newObject:                workloadKind.newObject,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reloadRequestPredicates only lets through workloads of this class with an unhandled reload request,
+// and workloads with pending reloads (see reconcilePendingReloads)
+//
+// Business Logic:
+// Unlike targetWorkloadPredicates, creates are accepted before the caches are
+// synced: the handled token and the pending reloads are stored on the
+// workload, so a request made while the operator was down is handled once at
+// startup and never again, and a reload left pending before a restart is
+// still done.
+func (r *ReloaderConfigReconciler) reloadRequestPredicates() predicate.Funcs {
+	pending := func(obj client.Object) bool {
+		return r.hasPendingWork(obj.GetAnnotations())
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return pending(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return pending(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return pending(e.Object)
+		},
+	}
+}
+
+// Reconcile reloads a workload once for each distinct token of its reload-requested annotation
+//
+// Business Logic:
+// The pending reloads of the workload are done first: delayed pod
+// notifications once their notify delay has passed (until then the workload
+// is requeued) and reloads handed over by other replicas (see
+// reconcilePendingReloads).
+//
+// The workload is reloaded like an annotation-based target of a resource
+// change (its rollout strategy, pause period and notify settings, alerts and
+// events), with the workload itself as the trigger. The handled token is then
+// stored in the reload-request-handled annotation. It is also kept in memory
+// first, so a failure to store it only retries storing it, not the reload.
+// A reload that failed or was skipped (pause period) leaves the token
+// unhandled, and is retried after reloadRequestRetryInterval.
+func (r *reloadRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Requests queued before a rebalance may belong to another replica by now
+	if !r.ownsNamespace(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+
+	obj := r.newObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	pendingAfter, err := r.reconcilePendingReloads(ctx, r.kind, obj)
+	if err != nil {
+		logger.Error(err, "Failed to do pending reloads", "kind", r.kind, "name", req.Name)
+		return ctrl.Result{}, err
+	}
+	// requeue waits for the pending reloads as well as for the reload request
+	requeue := func(after time.Duration) ctrl.Result {
+		if pendingAfter > 0 && (after == 0 || pendingAfter < after) {
+			after = pendingAfter
+		}
+		return ctrl.Result{RequeueAfter: after}
+	}
+
+	annotations := obj.GetAnnotations()
+	token := pendingReloadRequest(annotations)
+	if token == "" || !util.InClass(annotations, r.ClassName) {
+		return requeue(0), nil
+	}
+	if annotations[util.AnnotationIgnore] == "true" || !r.shouldProcessNamespace(ctx, req.Namespace) {
+		logger.V(1).Info("Ignoring reload request of filtered "+r.kind, "name", req.Name, "namespace", req.Namespace)
+		return requeue(0), nil
+	}
+
+	target := workload.AnnotatedTarget(r.kind, obj)
+	target.Rule = workload.ReloadRuleReloadRequested
+
+	requestKey := util.MakeResourceKey(req.Namespace, r.kind, req.Name)
+	targets := reloadRequestTargets([]workload.Target{target})
+	if _, retryAfter := r.attemptReloadRequest(ctx, requestKey, token, targets, r.kind, req.Name, req.Namespace); retryAfter > 0 {
+		return requeue(retryAfter), nil
+	}
+
+	if err := r.WorkloadUpdater.MarkReloadRequestHandled(ctx, target, token); err != nil {
+		logger.Error(err, "Failed to record handled reload request", "kind", r.kind, "name", req.Name)
+		return ctrl.Result{}, err
+	}
+	return requeue(0), nil
+}
+
+// handleConfigReloadRequest reloads every target of a ReloaderConfig once for each distinct
+// token of its reload-requested annotation
+//
+// Business Logic:
+// The spec.targets are reloaded like on a change of a watched resource (their
+// strategies, pause periods and requireReference aside, since no resource is
+// involved), with the ReloaderConfig as the trigger. Workloads only selected
+// by autoReloadAll have no resource to reference, so they are not reloaded.
+//
+// The token is recorded in status.lastHandledReloadRequest through the status
+// queue once every target was reloaded. Until the status shows it, the token
+// kept in memory prevents the status updates of the reload itself from
+// triggering a second reload. Targets that failed or were skipped (pause
+// period) are retried after reloadRequestRetryInterval, which is returned to
+// be requeued; the targets already reloaded are not reloaded again.
+func (r *ReloaderConfigReconciler) handleConfigReloadRequest(ctx context.Context, config *reloaderv1alpha1.ReloaderConfig) time.Duration {
+	token := config.Annotations[util.AnnotationReloadRequested]
+	if token == "" || token == config.Status.LastHandledReloadRequest || config.Annotations[util.AnnotationIgnore] == "true" {
+		return 0
+	}
+
+	requestKey := util.MakeResourceKey(config.Namespace, kindReloaderConfig, config.Name)
+	targets := reloadRequestTargets(r.mergeTargets([]*reloaderv1alpha1.ReloaderConfig{config}, nil))
+	reloaded, retryAfter := r.attemptReloadRequest(ctx, requestKey, token, targets, kindReloaderConfig, config.Name, config.Namespace)
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	r.statusQueue.Add(statusUpdateWorkItem{
+		updateType:    statusUpdateTypeReloadRequest,
+		configKey:     client.ObjectKeyFromObject(config),
+		reloadRequest: token,
+		reloaded:      reloaded > 0,
+	})
+	return 0
+}
+
+// attemptReloadRequest reloads the targets of a reload request token that were not reloaded yet
+//
+// Business Logic:
+// Once every target was reloaded the token is handled: the number of targets
+// it reloaded is returned (0 on later calls, while the caller records it).
+// Otherwise how long to wait before the next attempt is returned, and the
+// targets already reloaded are remembered so a retry only reloads the others.
+func (r *ReloaderConfigReconciler) attemptReloadRequest(
+	ctx context.Context,
+	requestKey string,
+	token string,
+	targets []workload.Target,
+	triggerKind string,
+	triggerName string,
+	triggerNamespace string,
+) (reloaded int, retryAfter time.Duration) {
+	logger := log.FromContext(ctx)
+
+	state := reloadRequestState{token: token, reloaded: map[string]bool{}}
+	if value, ok := r.reloadRequests.Load(requestKey); ok && value.(reloadRequestState).token == token {
+		state = value.(reloadRequestState)
+	}
+	if state.handled {
+		return 0, 0
+	}
+	if wait := time.Until(state.retryAt); wait > 0 {
+		return 0, wait
+	}
+
+	pending := []workload.Target{}
+	for _, target := range targets {
+		if !state.reloaded[util.MakeResourceKey(target.Namespace, target.Kind, target.Name)] {
+			pending = append(pending, target)
+		}
+	}
+
+	logger.Info("Handling reload request", "kind", triggerKind, "name", triggerName, "namespace", triggerNamespace,
+		"token", token, "targets", len(pending))
+	unfinished := 0
+	for _, result := range r.reloadTargets(ctx, pending, triggerKind, triggerName, triggerNamespace, "", reloadRequestHash(token)) {
+		if result.reloaded() {
+			state.reloaded[util.MakeResourceKey(result.target.Namespace, result.target.Kind, result.target.Name)] = true
+		} else {
+			unfinished++
+		}
+	}
+
+	if unfinished > 0 {
+		logger.Info("Reload request not fully handled, retrying", "kind", triggerKind, "name", triggerName,
+			"namespace", triggerNamespace, "token", token, "unfinished", unfinished, "retryAfter", reloadRequestRetryInterval)
+		state.retryAt = time.Now().Add(reloadRequestRetryInterval)
+		r.reloadRequests.Store(requestKey, state)
+		return 0, reloadRequestRetryInterval
+	}
+
+	reloaded = len(state.reloaded)
+	state.handled = true
+	state.reloaded = nil
+	r.reloadRequests.Store(requestKey, state)
+	return reloaded, 0
+}
+
+// hasPendingWork reports whether a workload has an unhandled reload request of this class or pending reloads
+// The class of a pending reload is checked when it is done: it is the class of the target's instance, not of the workload.
+func (r *ReloaderConfigReconciler) hasPendingWork(annotations map[string]string) bool {
+	if annotations[util.AnnotationReloadPending] != "" {
+		return true
+	}
+	return pendingReloadRequest(annotations) != "" && util.InClass(annotations, r.ClassName)
+}
+
+// pendingReloadRequest returns the reload request token of an object that was not handled yet, or ""
+func pendingReloadRequest(annotations map[string]string) string {
+	token := annotations[util.AnnotationReloadRequested]
+	if token == annotations[util.AnnotationReloadRequestHandled] {
+		return ""
+	}
+	return token
+}
+
+// reloadRequestHash is the hash a reload request is applied with (e.g., the env-vars strategy's value)
+// Each token gives a new value, so a new request changes the pod template again.
+func reloadRequestHash(token string) string {
+	return util.CalculateHashFromStringMap(map[string]string{"reload-requested": token})
+}
+
+// reloadRequestTargets adapts targets to a reload without a changed resource
+// Targets of the strategies that need a changed Secret or ConfigMap are restarted instead: versioned-copy
+// has nothing to copy, and env-vars would add a variable named after the trigger that no reload removes.
+func reloadRequestTargets(targets []workload.Target) []workload.Target {
+	for i := range targets {
+		targets[i].Rule = workload.ReloadRuleReloadRequested
+		switch targets[i].ReloadStrategy {
+		case "", util.ReloadStrategyEnvVars, util.ReloadStrategyVersionedCopy:
+			targets[i].ReloadStrategy = util.ReloadStrategyRestartedAt
+		}
+	}
+	return targets
+}
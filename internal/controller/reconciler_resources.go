@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+// resourceReconciler reconciles Secrets or ConfigMaps for a ReloaderConfigReconciler
+//
+// Business Logic:
+// A reconcile request only carries a NamespacedName. With one controller for
+// all kinds, the kind had to be guessed by trying each of them, so a
+// ReloaderConfig and a ConfigMap sharing a name were indistinguishable. Each
+// kind therefore has its own controller, and a request is always for the kind
+// its controller watches.
+type resourceReconciler struct {
+	*ReloaderConfigReconciler
+	kind      string
+	newObject func() client.Object
+}
+
+// setupResourceController registers the controller for one watched resource kind
+func (r *ReloaderConfigReconciler) setupResourceController(
+	mgr ctrl.Manager,
+	name, kind string,
+	newObject func() client.Object,
+	predicates predicate.Funcs,
+) error {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(newObject(), builder.WithPredicates(r.resourceClassPredicates(), predicates)).
+		Named(name).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles})
+
+	if resync := r.resyncSource(kind); resync != nil {
+		bldr = bldr.WatchesRawSource(resync)
+	}
+	return bldr.Complete(&resourceReconciler{
+		ReloaderConfigReconciler: r,
+		kind:                     kind,
+		newObject:                newObject,
+	})
+}
+
+// Reconcile handles a create, update or delete of a Secret or ConfigMap
+//
+// Business Logic:
+// - Exists without a last-hash annotation and --reload-on-create: CREATE
+// - Exists otherwise: UPDATE (the hash check decides whether anything changed)
+// - Gone, and the delete predicate accepted the delete or --reload-on-delete: DELETE
+func (r *resourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Requests queued before a rebalance may belong to another replica by now
+	if !r.ownsNamespace(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+
+	obj := r.newObject()
+	err := r.Get(ctx, req.NamespacedName, obj)
+
+	if err == nil {
+		// A tombstone left by an earlier delete is stale once the name is reused
+		r.tombstones.Delete(util.MakeResourceKey(req.Namespace, r.kind, req.Name))
+
+		// No last-hash annotation means this is a newly created resource
+		if obj.GetAnnotations()[util.AnnotationLastHash] == "" && r.ReloadOnCreate {
+			logger.Info("Reconciling "+r.kind+" (CREATE)", "name", req.Name, "namespace", req.Namespace)
+			return r.reconcileResourceCreated(ctx, r.kind, obj)
+		}
+
+		// Has last-hash annotation or ReloadOnCreate is disabled - treat as update
+		logger.Info("Reconciling "+r.kind+" (UPDATE)", "name", req.Name, "namespace", req.Namespace)
+		return r.reconcileResourceUpdate(ctx, r.kind, obj)
+	}
+
+	if !apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to get "+r.kind)
+		return ctrl.Result{}, err
+	}
+
+	// The delete predicate leaves a tombstone for deletes it accepted
+	tombstone, _ := r.loadTombstone(r.kind, req.NamespacedName)
+
+	logger.Info("Reconciling "+r.kind+" (DELETE)", "name", req.Name, "namespace", req.Namespace)
+	result, err := r.reconcileResourceDeleted(ctx, r.kind, req.NamespacedName, tombstone)
+	if err == nil {
+		r.tombstones.Delete(util.MakeResourceKey(req.Namespace, r.kind, req.Name))
+	}
+	return result, err
+}
+
+// resourceTombstone is the final state of a deleted Secret or ConfigMap
+//
+// Business Logic:
+// When the delete is reconciled, the object can no longer be read, yet its
+// annotations decide whether it is ignored, whether it reloads on delete
+// (reloader.stakater.com/reload-on-delete), and whether search-mode workloads
+// match it (reloader.stakater.com/match). The delete predicate therefore
+// records this state from the delete event. Without a tombstone (e.g., after
+// an operator restart), a delete is handled as if the object had no annotations.
+type resourceTombstone struct {
+	Kind        string
+	Annotations map[string]string
+	Labels      map[string]string
+	LastHash    string
+}
+
+// storeTombstone records the final state of a deleted Secret, ConfigMap or watched object
+// Watched objects are keyed by their group-qualified kind (util.QualifiedKind).
+func (r *ReloaderConfigReconciler) storeTombstone(kind string, obj client.Object) {
+	r.tombstones.Store(util.MakeResourceKey(obj.GetNamespace(), kind, obj.GetName()), resourceTombstone{
+		Kind:        kind,
+		Annotations: obj.GetAnnotations(),
+		Labels:      obj.GetLabels(),
+		LastHash:    obj.GetAnnotations()[util.AnnotationLastHash],
+	})
+}
+
+// loadTombstone returns the final state of a deleted Secret, ConfigMap or watched object, if it was recorded
+func (r *ReloaderConfigReconciler) loadTombstone(kind string, key client.ObjectKey) (resourceTombstone, bool) {
+	value, found := r.tombstones.Load(util.MakeResourceKey(key.Namespace, kind, key.Name))
+	if !found {
+		return resourceTombstone{Kind: kind}, false
+	}
+	return value.(resourceTombstone), true
+}
+
+// CacheOptions returns the manager cache options the controllers rely on
+// Secrets and ConfigMaps are cached without their payload, only with its digests
+// (see util.TransformResourceData), so memory does not grow with the size of their data.
+func CacheOptions() cache.Options {
+	return cache.Options{
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}:    {Transform: util.TransformResourceData},
+			&corev1.ConfigMap{}: {Transform: util.TransformResourceData},
+		},
+	}
+}
+
+// newSecret and newConfigMap create the objects reconciled by the resource controllers
+func newSecret() client.Object    { return &corev1.Secret{} }
+func newConfigMap() client.Object { return &corev1.ConfigMap{} }
@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/sharding"
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+const (
+	// resyncBufferSize bounds the resync events waiting for each controller
+	resyncBufferSize = 1024
+
+	// kindReloaderConfig keys the resync events of the ReloaderConfig controller
+	kindReloaderConfig = "ReloaderConfig"
+)
+
+// ownsNamespace reports whether this replica handles the namespace
+// Without sharding, a replica handles every namespace.
+func (r *ReloaderConfigReconciler) ownsNamespace(namespace string) bool {
+	return r.Sharding == nil || r.Sharding.Owns(namespace)
+}
+
+// shardPredicates drops events for objects in namespaces owned by other replicas
+// Informers are not sharded: the cache still holds every namespace, only the events are filtered.
+// Reloads of workloads in other namespaces are handed over to their owner (see handOverReload).
+func (r *ReloaderConfigReconciler) shardPredicates() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.ownsNamespace(obj.GetNamespace())
+	})
+}
+
+// setupSharding prepares the resync sources and rebalancing of a sharded replica
+func (r *ReloaderConfigReconciler) setupSharding() {
+	if r.Sharding == nil {
+		return
+	}
+
+	r.resyncEvents = map[string]chan event.GenericEvent{
+		kindReloaderConfig:   make(chan event.GenericEvent, resyncBufferSize),
+		util.KindSecret:      make(chan event.GenericEvent, resyncBufferSize),
+		util.KindConfigMap:   make(chan event.GenericEvent, resyncBufferSize),
+		util.KindDeployment:  make(chan event.GenericEvent, resyncBufferSize),
+		util.KindStatefulSet: make(chan event.GenericEvent, resyncBufferSize),
+		util.KindDaemonSet:   make(chan event.GenericEvent, resyncBufferSize),
+	}
+	r.objectResyncEvents = make(chan event.TypedGenericEvent[objectRequest], resyncBufferSize)
+	r.Sharding.OnRebalance = func(ctx context.Context, previous []string) {
+		go r.resyncGainedNamespaces(ctx, previous)
+	}
+}
+
+// resyncSource returns the source feeding resync events of a kind to its controller, or nil without sharding
+func (r *ReloaderConfigReconciler) resyncSource(kind string) source.Source {
+	events, ok := r.resyncEvents[kind]
+	if !ok {
+		return nil
+	}
+	return source.Channel(events, &handler.EnqueueRequestForObject{})
+}
+
+// objectResyncSource returns the source feeding resync events to the object controller, or nil without sharding
+func (r *ReloaderConfigReconciler) objectResyncSource() source.TypedSource[objectRequest] {
+	if r.objectResyncEvents == nil {
+		return nil
+	}
+	return source.TypedChannel(r.objectResyncEvents, handler.TypedFuncs[objectRequest, objectRequest]{
+		GenericFunc: func(_ context.Context, e event.TypedGenericEvent[objectRequest], q workqueue.TypedRateLimitingInterface[objectRequest]) {
+			q.Add(e.Object)
+		},
+	})
+}
+
+// resyncGainedNamespaces re-enqueues the objects of the namespaces this replica just gained
+//
+// Business Logic:
+// Events for a namespace are dropped by every replica but its owner, so changes
+// made while ownership moved (or while no replica was running) were seen by
+// nobody. The new owner therefore reconciles, in every namespace it gained:
+//   - all ReloaderConfigs, which re-validates targets and initializes hashes
+//   - the Secrets/ConfigMaps with a last-hash annotation, i.e., the ones Reloader
+//     already tracks; the UPDATE path compares hashes, so unchanged resources
+//     cause no reload
+//   - the objects selected by the ReloaderConfigs' spec.watchedResources.objects,
+//     whose hashes are compared with the ReloaderConfig status the same way
+//   - the workloads with an unhandled reload-requested annotation
+//
+// Resources without a last-hash are left alone, as at startup, so that
+// --reload-on-create does not reload for resources that merely changed owner.
+func (r *ReloaderConfigReconciler) resyncGainedNamespaces(ctx context.Context, previous []string) {
+	logger := log.FromContext(ctx)
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaces); err != nil {
+		logger.Error(err, "Failed to list namespaces for shard resync")
+		return
+	}
+
+	for _, ns := range namespaces.Items {
+		if !r.ownsNamespace(ns.Name) || sharding.Owner(previous, ns.Name) == r.Sharding.Identity {
+			continue
+		}
+		if !r.shouldProcessNamespace(ctx, ns.Name) {
+			continue
+		}
+
+		logger.Info("Resyncing namespace gained by this shard", "namespace", ns.Name)
+		if err := r.resyncNamespace(ctx, ns.Name); err != nil {
+			logger.Error(err, "Failed to resync namespace", "namespace", ns.Name)
+		}
+	}
+}
+
+// resyncNamespace sends the ReloaderConfigs, their watched objects, the tracked Secrets/ConfigMaps and
+// the workloads with a pending reload request of a namespace to their controllers
+func (r *ReloaderConfigReconciler) resyncNamespace(ctx context.Context, namespace string) error {
+	configs := &reloaderv1alpha1.ReloaderConfigList{}
+	if err := r.List(ctx, configs, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	for i := range configs.Items {
+		if !r.sendResyncEvent(ctx, kindReloaderConfig, &configs.Items[i]) {
+			return ctx.Err()
+		}
+		if err := r.resyncWatchedObjects(ctx, &configs.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		if r.isTrackedResource(&secrets.Items[i]) && !r.sendResyncEvent(ctx, util.KindSecret, &secrets.Items[i]) {
+			return ctx.Err()
+		}
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := r.List(ctx, configMaps, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	for i := range configMaps.Items {
+		if r.isTrackedResource(&configMaps.Items[i]) && !r.sendResyncEvent(ctx, util.KindConfigMap, &configMaps.Items[i]) {
+			return ctx.Err()
+		}
+	}
+
+	return r.resyncReloadRequests(ctx, namespace)
+}
+
+// resyncWatchedObjects sends the objects selected by the spec.watchedResources.objects of a ReloaderConfig to the object controller
+// Invalid entries and kinds that cannot be watched are skipped; the ReloaderConfig's own reconcile reports them.
+func (r *ReloaderConfigReconciler) resyncWatchedObjects(ctx context.Context, config *reloaderv1alpha1.ReloaderConfig) error {
+	if config.Spec.WatchedResources == nil || config.Spec.ReloaderClassName != r.ClassName {
+		return nil
+	}
+
+	for _, watched := range config.Spec.WatchedResources.Objects {
+		gvk, err := watchedObjectGVK(watched)
+		if err != nil {
+			continue
+		}
+		if err := r.ensureObjectWatch(ctx, gvk); err != nil {
+			log.FromContext(ctx).V(1).Info("Skipping resync of unwatchable object kind", "gvk", gvk.String(), "reason", err.Error())
+			continue
+		}
+
+		objects, err := r.listWatchedObjects(ctx, watched, gvk, config.Namespace)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for i := range objects {
+			req := objectRequest{GVK: gvk, ObjectKey: client.ObjectKeyFromObject(&objects[i])}
+			select {
+			case r.objectResyncEvents <- event.TypedGenericEvent[objectRequest]{Object: req}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// resyncReloadRequests sends the workloads of a namespace with an unhandled reload request or a pending pod
+// notification to their reload request controllers
+func (r *ReloaderConfigReconciler) resyncReloadRequests(ctx context.Context, namespace string) error {
+	for _, workloads := range []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{util.KindDeployment, &appsv1.DeploymentList{}},
+		{util.KindStatefulSet, &appsv1.StatefulSetList{}},
+		{util.KindDaemonSet, &appsv1.DaemonSetList{}},
+	} {
+		if err := r.List(ctx, workloads.list, client.InNamespace(namespace)); err != nil {
+			return err
+		}
+		err := meta.EachListItem(workloads.list, func(item runtime.Object) error {
+			obj := item.(client.Object)
+			if !r.hasPendingWork(obj.GetAnnotations()) {
+				return nil
+			}
+			if !r.sendResyncEvent(ctx, workloads.kind, obj) {
+				return ctx.Err()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTrackedResource reports whether a Secret/ConfigMap was already handled by Reloader and passes the label selector
+func (r *ReloaderConfigReconciler) isTrackedResource(obj client.Object) bool {
+	if obj.GetAnnotations()[util.AnnotationLastHash] == "" {
+		return false
+	}
+	return r.ResourceLabelSelector == nil || r.ResourceLabelSelector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// sendResyncEvent queues a resync event, returning false if ctx is done first
+func (r *ReloaderConfigReconciler) sendResyncEvent(ctx context.Context, kind string, obj client.Object) bool {
+	select {
+	case r.resyncEvents[kind] <- event.GenericEvent{Object: obj}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -19,6 +19,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
 	"github.com/stakater/Reloader/internal/pkg/workload"
 )
 
@@ -36,6 +39,9 @@ type statusUpdateType string
 const (
 	statusUpdateTypeReloaderConfig statusUpdateType = "reloaderconfig"
 	statusUpdateTypeTarget         statusUpdateType = "target"
+	statusUpdateTypeResourceHash   statusUpdateType = "resourcehash"
+	statusUpdateTypeReloadRequest  statusUpdateType = "reloadrequest"
+	statusUpdateTypeReloadHistory  statusUpdateType = "reloadhistory"
 )
 
 // statusUpdateWorkItem represents a status update to be processed
@@ -46,8 +52,14 @@ type statusUpdateWorkItem struct {
 	resourceKind      string
 	resourceName      string
 	newHash           string
+	pathsDigest       string // JSONPaths the hash of a watched object was computed from (util.JSONPathsDigest)
+	state             string // status.watchedResources state (statusUpdateTypeResourceHash, Present when empty)
 	target            *workload.Target
 	errorMsg          string
+	reloadRequest     string // Handled reload request token (statusUpdateTypeReloadRequest)
+	reloaded          bool   // Whether the change or reload request reloaded any target
+
+	historyEntry *reloaderv1alpha1.ReloadHistoryEntry // Entry to add to status.reloadHistory (statusUpdateTypeReloadHistory)
 }
 
 // startStatusUpdateWorker runs a worker goroutine that processes status update queue items
@@ -105,16 +117,22 @@ func (r *ReloaderConfigReconciler) processStatusUpdate(workItem statusUpdateWork
 	// Apply the update based on type
 	switch workItem.updateType {
 	case statusUpdateTypeReloaderConfig:
-		return r.updateReloaderConfigStatusDirect(ctx, config, workItem.resourceNamespace, workItem.resourceKind, workItem.resourceName, workItem.newHash)
+		return r.updateReloaderConfigStatusDirect(ctx, config, workItem.resourceNamespace, workItem.resourceKind, workItem.resourceName, workItem.newHash, workItem.pathsDigest, workItem.reloaded)
 	case statusUpdateTypeTarget:
 		return r.updateTargetStatusDirect(ctx, config, workItem.target, workItem.errorMsg)
+	case statusUpdateTypeResourceHash:
+		return r.updateResourceHashStatusDirect(ctx, config, workItem.resourceNamespace, workItem.resourceKind, workItem.resourceName, workItem.newHash, workItem.pathsDigest, workItem.state)
+	case statusUpdateTypeReloadRequest:
+		return r.updateReloadRequestStatusDirect(ctx, config, workItem.reloadRequest, workItem.reloaded)
+	case statusUpdateTypeReloadHistory:
+		return r.updateReloadHistoryStatusDirect(ctx, config, workItem.historyEntry)
 	default:
 		return fmt.Errorf("unknown status update type: %s", workItem.updateType)
 	}
 }
 
 // updateReloaderConfigStatusDirect performs direct status update for ReloaderConfig-level fields
-func (r *ReloaderConfigReconciler) updateReloaderConfigStatusDirect(ctx context.Context, config *reloaderv1alpha1.ReloaderConfig, resourceNamespace, resourceKind, resourceName, newHash string) error {
+func (r *ReloaderConfigReconciler) updateReloaderConfigStatusDirect(ctx context.Context, config *reloaderv1alpha1.ReloaderConfig, resourceNamespace, resourceKind, resourceName, newHash, pathsDigest string, reloaded bool) error {
 	if config.Status.WatchedResourceHashes == nil {
 		config.Status.WatchedResourceHashes = make(map[string]string)
 	}
@@ -124,17 +142,214 @@ func (r *ReloaderConfigReconciler) updateReloaderConfigStatusDirect(ctx context.
 	// Empty hash signals deletion - remove the entry
 	if newHash == "" {
 		delete(config.Status.WatchedResourceHashes, hashKey)
+		delete(config.Status.WatchedObjectPaths, hashKey)
+		setWatchedResourceStatus(&config.Status, resourceKind, resourceNamespace, resourceName, util.WatchedResourceMissing, "", reloaded)
 	} else {
 		// Update or add the hash
 		config.Status.WatchedResourceHashes[hashKey] = newHash
+		setWatchedObjectPaths(&config.Status, hashKey, pathsDigest)
+		setWatchedResourceStatus(&config.Status, resourceKind, resourceNamespace, resourceName, util.WatchedResourcePresent, newHash, reloaded)
+	}
+	refreshMissingResourcesCondition(&config.Status)
+
+	if reloaded {
+		config.Status.ReloadCount++
+
+		now := metav1.Now()
+		config.Status.LastReloadTime = &now
 	}
 
-	config.Status.ReloadCount++
+	return r.Status().Update(ctx, config, client.FieldOwner(util.FieldManager))
+}
 
+// updateResourceHashStatusDirect records a resource hash and state without counting a reload
+func (r *ReloaderConfigReconciler) updateResourceHashStatusDirect(ctx context.Context, config *reloaderv1alpha1.ReloaderConfig, resourceNamespace, resourceKind, resourceName, newHash, pathsDigest, state string) error {
+	if state == "" {
+		state = util.WatchedResourcePresent
+	}
+	hashKey := fmt.Sprintf("%s/%s/%s", resourceNamespace, resourceKind, resourceName)
+	hashChanged := config.Status.WatchedResourceHashes[hashKey] != newHash || config.Status.WatchedObjectPaths[hashKey] != pathsDigest
+	entryChanged := setWatchedResourceStatus(&config.Status, resourceKind, resourceNamespace, resourceName, state, newHash, false)
+	if !hashChanged && !entryChanged {
+		return nil
+	}
+	refreshMissingResourcesCondition(&config.Status)
+
+	if config.Status.WatchedResourceHashes == nil {
+		config.Status.WatchedResourceHashes = make(map[string]string)
+	}
+	config.Status.WatchedResourceHashes[hashKey] = newHash
+	setWatchedObjectPaths(&config.Status, hashKey, pathsDigest)
+
+	return r.Status().Update(ctx, config, client.FieldOwner(util.FieldManager))
+}
+
+// setWatchedObjectPaths records which JSONPaths the hash of a watched object was computed from
+// Secrets and ConfigMaps have no JSONPaths (an empty digest) and no entry.
+func setWatchedObjectPaths(status *reloaderv1alpha1.ReloaderConfigStatus, hashKey, pathsDigest string) {
+	if pathsDigest == "" {
+		delete(status.WatchedObjectPaths, hashKey)
+		return
+	}
+	if status.WatchedObjectPaths == nil {
+		status.WatchedObjectPaths = make(map[string]string)
+	}
+	status.WatchedObjectPaths[hashKey] = pathsDigest
+}
+
+// setWatchedResourceStatus records the state and hash of a watched resource, returning whether the entry changed
+//
+// Business Logic:
+// An entry is added the first time the resource is seen. After that, its
+// lastChangeTime moves whenever the hash changes, including to or from empty
+// when the resource is deleted or created again, and its lastReloadTime
+// whenever a change of the resource reloaded targets.
+func setWatchedResourceStatus(
+	status *reloaderv1alpha1.ReloaderConfigStatus,
+	kind, namespace, name, state, hash string,
+	reloaded bool,
+) bool {
 	now := metav1.Now()
-	config.Status.LastReloadTime = &now
 
-	return r.Status().Update(ctx, config)
+	var entry *reloaderv1alpha1.WatchedResourceStatus
+	for i := range status.WatchedResources {
+		if status.WatchedResources[i].Kind == kind &&
+			status.WatchedResources[i].Namespace == namespace &&
+			status.WatchedResources[i].Name == name {
+			entry = &status.WatchedResources[i]
+			break
+		}
+	}
+
+	changed := reloaded
+	if entry == nil {
+		status.WatchedResources = append(status.WatchedResources, reloaderv1alpha1.WatchedResourceStatus{
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+		})
+		entry = &status.WatchedResources[len(status.WatchedResources)-1]
+		changed = true
+	} else if entry.Hash != hash {
+		entry.LastChangeTime = &now
+		changed = true
+	}
+	if entry.State != state {
+		changed = true
+	}
+
+	entry.State = state
+	entry.Hash = hash
+	if reloaded {
+		entry.LastReloadTime = &now
+	}
+	return changed
+}
+
+// pruneWatchedResourceStatus drops the entries of resources the ReloaderConfig no longer watches
+// Secrets and ConfigMaps are kept with autoReloadAll, since every one the targets reference is watched.
+// Hashes of objects of other kinds are dropped as well, so a kind watched again starts from scratch.
+func pruneWatchedResourceStatus(config *reloaderv1alpha1.ReloaderConfig) {
+	watched := config.Spec.WatchedResources
+	if watched == nil {
+		watched = &reloaderv1alpha1.WatchedResources{}
+	}
+
+	entries := config.Status.WatchedResources[:0]
+	for _, entry := range config.Status.WatchedResources {
+		var keep bool
+		switch entry.Kind {
+		case util.KindSecret:
+			keep = config.Spec.AutoReloadAll || slices.Contains(watched.Secrets, entry.Name)
+		case util.KindConfigMap:
+			keep = config.Spec.AutoReloadAll || slices.Contains(watched.ConfigMaps, entry.Name)
+		default:
+			keep = watchesObject(config, entry.Kind, entry.Name)
+		}
+		if keep {
+			entries = append(entries, entry)
+		}
+	}
+	config.Status.WatchedResources = entries
+
+	for resourceKey := range config.Status.WatchedResourceHashes {
+		_, kind, name, err := util.ParseResourceKey(resourceKey)
+		if err != nil || kind == util.KindSecret || kind == util.KindConfigMap {
+			continue
+		}
+		if !watchesObject(config, kind, name) {
+			delete(config.Status.WatchedResourceHashes, resourceKey)
+		}
+	}
+	for resourceKey := range config.Status.WatchedObjectPaths {
+		if _, found := config.Status.WatchedResourceHashes[resourceKey]; !found {
+			delete(config.Status.WatchedObjectPaths, resourceKey)
+		}
+	}
+}
+
+// refreshMissingResourcesCondition updates a Degraded condition about missing watched resources
+// Without this, a Secret created after its ReloaderConfig would be reported missing until the
+// ReloaderConfig is reconciled again.
+func refreshMissingResourcesCondition(status *reloaderv1alpha1.ReloaderConfigStatus) {
+	degraded := util.GetCondition(status.Conditions, util.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != util.ReasonResourceNotFound {
+		return
+	}
+
+	if missing := missingWatchedResources(*status); len(missing) > 0 {
+		degraded.Message = missingResourcesMessage(missing)
+		return
+	}
+	util.SetCondition(&status.Conditions, util.ConditionDegraded, metav1.ConditionFalse, util.ReasonReconciled, "")
+}
+
+// missingResourcesMessage is the Degraded condition message listing missing watched resources
+func missingResourcesMessage(missing []string) string {
+	return "Watched resources not found: " + strings.Join(missing, ", ")
+}
+
+// missingWatchedResources lists the watched resources in the Missing state (e.g., "Secret db-credentials")
+func missingWatchedResources(status reloaderv1alpha1.ReloaderConfigStatus) []string {
+	missing := []string{}
+	for _, entry := range status.WatchedResources {
+		if entry.State == util.WatchedResourceMissing {
+			missing = append(missing, entry.Kind+" "+entry.Name)
+		}
+	}
+	return missing
+}
+
+// updateReloadRequestStatusDirect records a handled reload request, counting it as a reload if any target reloaded
+func (r *ReloaderConfigReconciler) updateReloadRequestStatusDirect(ctx context.Context, config *reloaderv1alpha1.ReloaderConfig, token string, reloaded bool) error {
+	if config.Status.LastHandledReloadRequest == token && !reloaded {
+		return nil
+	}
+
+	config.Status.LastHandledReloadRequest = token
+	if reloaded {
+		config.Status.ReloadCount++
+		now := metav1.Now()
+		config.Status.LastReloadTime = &now
+	}
+
+	return r.Status().Update(ctx, config, client.FieldOwner(util.FieldManager))
+}
+
+// updateReloadHistoryStatusDirect adds an entry to the reload history, newest first,
+// dropping the oldest entries beyond ReloadHistoryLimit
+func (r *ReloaderConfigReconciler) updateReloadHistoryStatusDirect(ctx context.Context, config *reloaderv1alpha1.ReloaderConfig, entry *reloaderv1alpha1.ReloadHistoryEntry) error {
+	if r.ReloadHistoryLimit <= 0 {
+		return nil
+	}
+
+	history := append([]reloaderv1alpha1.ReloadHistoryEntry{*entry}, config.Status.ReloadHistory...)
+	if len(history) > r.ReloadHistoryLimit {
+		history = history[:r.ReloadHistoryLimit]
+	}
+	config.Status.ReloadHistory = history
+
+	return r.Status().Update(ctx, config, client.FieldOwner(util.FieldManager))
 }
 
 // updateTargetStatusDirect performs direct status update for a specific target
@@ -160,6 +375,9 @@ func (r *ReloaderConfigReconciler) updateTargetStatusDirect(ctx context.Context,
 		targetStatus = &config.Status.TargetStatus[len(config.Status.TargetStatus)-1]
 	}
 
+	// Only notify reloads have per-pod outcomes; other strategies clear the last ones
+	targetStatus.PodNotifications = target.PodNotifications
+
 	// Update target status
 	if errorMsg != "" {
 		targetStatus.LastError = errorMsg
@@ -179,7 +397,7 @@ func (r *ReloaderConfigReconciler) updateTargetStatusDirect(ctx context.Context,
 		}
 	}
 
-	return r.Status().Update(ctx, config)
+	return r.Status().Update(ctx, config, client.FieldOwner(util.FieldManager))
 }
 
 // removeReloaderConfigStatusEntries removes hash entries from ReloaderConfig statuses
@@ -187,13 +405,15 @@ func (r *ReloaderConfigReconciler) updateTargetStatusDirect(ctx context.Context,
 // Business Logic:
 // When a Secret/ConfigMap is deleted, we should remove its hash entry from all
 // ReloaderConfig statuses that were tracking it. This keeps the status clean and
-// prevents stale entries.
+// prevents stale entries. Its status.watchedResources entry is kept as Missing.
+// The deletion only counts as a reload if it reloaded targets.
 func (r *ReloaderConfigReconciler) removeReloaderConfigStatusEntries(
 	ctx context.Context,
 	configs []*reloaderv1alpha1.ReloaderConfig,
 	resourceNamespace string,
 	resourceKind string,
 	resourceName string,
+	reloaded bool,
 ) {
 	logger := log.FromContext(ctx)
 
@@ -214,6 +434,7 @@ func (r *ReloaderConfigReconciler) removeReloaderConfigStatusEntries(
 			resourceKind:      resourceKind,
 			resourceName:      resourceName,
 			newHash:           "", // Empty hash signals deletion
+			reloaded:          reloaded,
 		})
 
 		logger.V(1).Info("Queued status update to remove deleted resource hash",
@@ -226,7 +447,7 @@ func (r *ReloaderConfigReconciler) removeReloaderConfigStatusEntries(
 //
 // Business Logic:
 // After successfully reloading workloads, we update ReloaderConfig status to track:
-// - New hash value (for the watched resource)
+// - New hash value (for the watched resource and its status.watchedResources entry)
 // - Total reload count (lifetime counter)
 // - Last reload timestamp
 //
@@ -238,6 +459,7 @@ func (r *ReloaderConfigReconciler) updateReloaderConfigStatuses(
 	resourceKind string,
 	resourceName string,
 	newHash string,
+	pathsDigest string,
 ) {
 	// Enqueue status update work items instead of updating directly
 	for _, config := range configs {
@@ -253,12 +475,114 @@ func (r *ReloaderConfigReconciler) updateReloaderConfigStatuses(
 			resourceKind:      resourceKind,
 			resourceName:      resourceName,
 			newHash:           newHash,
+			pathsDigest:       pathsDigest,
+			reloaded:          true,
 		}
 
 		r.statusQueue.Add(workItem)
 	}
 }
 
+// recordResourceChange records a processed change of a watched resource in ReloaderConfig statuses
+// Only a change that reloaded a target counts as a reload; otherwise just the new hash is recorded,
+// so the change is not processed again and its status.watchedResources entry shows it.
+// pathsDigest is only set for watched objects (see setWatchedObjectPaths).
+func (r *ReloaderConfigReconciler) recordResourceChange(
+	ctx context.Context,
+	configs []*reloaderv1alpha1.ReloaderConfig,
+	resourceNamespace string,
+	resourceKind string,
+	resourceName string,
+	newHash string,
+	pathsDigest string,
+	reloaded bool,
+) {
+	if reloaded {
+		r.updateReloaderConfigStatuses(ctx, configs, resourceNamespace, resourceKind, resourceName, newHash, pathsDigest)
+		return
+	}
+	for _, config := range configs {
+		r.queueResourceHashUpdate(config, resourceNamespace, resourceKind, resourceName, newHash, pathsDigest)
+	}
+}
+
+// queueResourceHashUpdate records the hash of a watched resource in a ReloaderConfig status
+//
+// Business Logic:
+// Objects watched through spec.watchedResources.objects are not annotated, so
+// their last hash is kept in the status. Unlike updateReloaderConfigStatuses,
+// this does not count a reload: it is used for baselines and for changes that
+// did not reload anything.
+func (r *ReloaderConfigReconciler) queueResourceHashUpdate(
+	config *reloaderv1alpha1.ReloaderConfig,
+	resourceNamespace string,
+	resourceKind string,
+	resourceName string,
+	newHash string,
+	pathsDigest string,
+) {
+	r.statusQueue.Add(statusUpdateWorkItem{
+		updateType:        statusUpdateTypeResourceHash,
+		configKey:         client.ObjectKeyFromObject(config),
+		resourceNamespace: resourceNamespace,
+		resourceKind:      resourceKind,
+		resourceName:      resourceName,
+		newHash:           newHash,
+		pathsDigest:       pathsDigest,
+	})
+}
+
+// recordWatchedResourceState updates the status.watchedResources state of a resource that was not reloaded
+//
+// Business Logic:
+// A Secret or ConfigMap that gains the ignore annotation is skipped before
+// its hash is compared, and one that loses it again usually has unchanged
+// data. Neither path records a change, so the state (see watchedResourceState)
+// is queued here for every ReloaderConfig whose entry disagrees. The recorded
+// hash is kept: an ignored change is not a new baseline.
+func (r *ReloaderConfigReconciler) recordWatchedResourceState(ctx context.Context, resourceKind string, obj client.Object) {
+	logger := log.FromContext(ctx)
+
+	configs, err := r.WorkloadFinder.FindReloaderConfigsWatchingResource(ctx, resourceKind, obj.GetName(), obj.GetNamespace())
+	if err != nil {
+		logger.Error(err, "Failed to find ReloaderConfigs to update the watched resource state",
+			"resource", resourceKind+"/"+obj.GetName())
+		return
+	}
+
+	hashKey := util.MakeResourceKey(obj.GetNamespace(), resourceKind, obj.GetName())
+	for _, config := range configs {
+		hash, tracked := config.Status.WatchedResourceHashes[hashKey]
+		if !tracked {
+			// Not initialized yet - the ReloaderConfig reconcile records the state
+			continue
+		}
+		state := r.watchedResourceState(config, resourceKind, obj)
+		if watchedResourceEntryState(&config.Status, resourceKind, obj.GetNamespace(), obj.GetName()) == state {
+			continue
+		}
+		r.statusQueue.Add(statusUpdateWorkItem{
+			updateType:        statusUpdateTypeResourceHash,
+			configKey:         client.ObjectKeyFromObject(config),
+			resourceNamespace: obj.GetNamespace(),
+			resourceKind:      resourceKind,
+			resourceName:      obj.GetName(),
+			newHash:           hash,
+			state:             state,
+		})
+	}
+}
+
+// watchedResourceEntryState returns the state of a status.watchedResources entry, or "" without one
+func watchedResourceEntryState(status *reloaderv1alpha1.ReloaderConfigStatus, kind, namespace, name string) string {
+	for _, entry := range status.WatchedResources {
+		if entry.Kind == kind && entry.Namespace == namespace && entry.Name == name {
+			return entry.State
+		}
+	}
+	return ""
+}
+
 // updateTargetStatus updates the status for a specific target workload
 func (r *ReloaderConfigReconciler) updateTargetStatus(
 	ctx context.Context,
@@ -18,7 +18,9 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -26,9 +28,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
 	"github.com/stakater/Reloader/internal/pkg/util"
+	"github.com/stakater/Reloader/internal/pkg/workload"
 )
 
 var _ = Describe("Status Management", func() {
@@ -303,7 +307,7 @@ var _ = Describe("Status Management", func() {
 
 			// Manually add a work item to test the queue
 			configs := []*reloaderv1alpha1.ReloaderConfig{config}
-			reconciler.updateReloaderConfigStatuses(ctx, configs, "default", util.KindSecret, "queue-secret", "test-hash")
+			reconciler.updateReloaderConfigStatuses(ctx, configs, "default", util.KindSecret, "queue-secret", "test-hash", "")
 
 			// Verify status was updated eventually
 			Eventually(func() bool {
@@ -380,7 +384,7 @@ var _ = Describe("Status Management", func() {
 
 			// Manually add a hash entry
 			configs := []*reloaderv1alpha1.ReloaderConfig{config}
-			reconciler.updateReloaderConfigStatuses(ctx, configs, "default", util.KindSecret, "remove-secret", "initial-hash")
+			reconciler.updateReloaderConfigStatuses(ctx, configs, "default", util.KindSecret, "remove-secret", "initial-hash", "")
 
 			// Wait for hash to be added
 			Eventually(func() bool {
@@ -398,7 +402,7 @@ var _ = Describe("Status Management", func() {
 			}, timeout, interval).Should(BeTrue())
 
 			// Now remove the entry
-			reconciler.removeReloaderConfigStatusEntries(ctx, configs, "default", util.KindSecret, "remove-secret")
+			reconciler.removeReloaderConfigStatusEntries(ctx, configs, "default", util.KindSecret, "remove-secret", true)
 
 			// Verify hash was removed
 			Eventually(func() bool {
@@ -416,4 +420,304 @@ var _ = Describe("Status Management", func() {
 			}, timeout, interval).Should(BeTrue())
 		})
 	})
+
+	Context("When tracking watched resources", func() {
+		ctx := context.Background()
+
+		It("Should record the state of every watched resource", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "watched-present-secret",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{"key": []byte("value")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			defer k8sClient.Delete(ctx, secret)
+
+			ignored := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "watched-ignored-cm",
+					Namespace: "default",
+				},
+				Data: map[string]string{"key": "value"},
+			}
+			Expect(k8sClient.Create(ctx, ignored)).To(Succeed())
+			defer k8sClient.Delete(ctx, ignored)
+
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "watched-resources-config",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Secrets:    []string{"watched-present-secret", "watched-missing-secret"},
+						ConfigMaps: []string{"watched-ignored-cm"},
+					},
+					IgnoreResources: []reloaderv1alpha1.ResourceReference{
+						{Kind: util.KindConfigMap, Name: "watched-ignored-cm"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			states := func() map[string]string {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "watched-resources-config",
+					Namespace: "default",
+				}, config)
+				if err != nil {
+					return nil
+				}
+				states := map[string]string{}
+				for _, entry := range config.Status.WatchedResources {
+					states[entry.Kind+"/"+entry.Name] = entry.State
+				}
+				return states
+			}
+			Eventually(states, timeout, interval).Should(Equal(map[string]string{
+				"Secret/watched-present-secret": util.WatchedResourcePresent,
+				"Secret/watched-missing-secret": util.WatchedResourceMissing,
+				"ConfigMap/watched-ignored-cm":  util.WatchedResourceIgnored,
+			}))
+
+			degraded := util.GetCondition(config.Status.Conditions, util.ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Reason).To(Equal(util.ReasonResourceNotFound))
+			Expect(degraded.Message).To(ContainSubstring("Secret watched-missing-secret"))
+		})
+
+		It("Should mark a watched resource Ignored when it gains the ignore annotation", func() {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "watched-later-ignored-cm",
+					Namespace: "default",
+				},
+				Data: map[string]string{"key": "value"},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			defer k8sClient.Delete(ctx, configMap)
+
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "watched-later-ignored-config",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						ConfigMaps: []string{"watched-later-ignored-cm"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			state := func() string {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "watched-later-ignored-config",
+					Namespace: "default",
+				}, config)
+				if err != nil {
+					return ""
+				}
+				return watchedResourceEntryState(&config.Status, util.KindConfigMap, "default", "watched-later-ignored-cm")
+			}
+			Eventually(state, timeout, interval).Should(Equal(util.WatchedResourcePresent))
+
+			setIgnore := func(ignore bool) {
+				Eventually(func() error {
+					if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+						return err
+					}
+					if configMap.Annotations == nil {
+						configMap.Annotations = map[string]string{}
+					}
+					if ignore {
+						configMap.Annotations[util.AnnotationIgnore] = "true"
+					} else {
+						delete(configMap.Annotations, util.AnnotationIgnore)
+					}
+					return k8sClient.Update(ctx, configMap)
+				}, timeout, interval).Should(Succeed())
+			}
+
+			setIgnore(true)
+			Eventually(state, timeout, interval).Should(Equal(util.WatchedResourceIgnored))
+
+			// Removing the annotation without a data change makes it Present again
+			setIgnore(false)
+			Eventually(state, timeout, interval).Should(Equal(util.WatchedResourcePresent))
+		})
+
+		It("Should track hash changes and reloads of an entry", func() {
+			status := &reloaderv1alpha1.ReloaderConfigStatus{}
+
+			Expect(setWatchedResourceStatus(status, util.KindSecret, "default", "db", util.WatchedResourcePresent, "hash-1", false)).To(BeTrue())
+			Expect(status.WatchedResources).To(HaveLen(1))
+			Expect(status.WatchedResources[0].LastChangeTime).To(BeNil())
+
+			// Seeing the same hash again changes nothing
+			Expect(setWatchedResourceStatus(status, util.KindSecret, "default", "db", util.WatchedResourcePresent, "hash-1", false)).To(BeFalse())
+
+			Expect(setWatchedResourceStatus(status, util.KindSecret, "default", "db", util.WatchedResourcePresent, "hash-2", true)).To(BeTrue())
+			Expect(status.WatchedResources[0].Hash).To(Equal("hash-2"))
+			Expect(status.WatchedResources[0].LastChangeTime).NotTo(BeNil())
+			Expect(status.WatchedResources[0].LastReloadTime).NotTo(BeNil())
+
+			Expect(setWatchedResourceStatus(status, util.KindSecret, "default", "db", util.WatchedResourceMissing, "", false)).To(BeTrue())
+			Expect(status.WatchedResources[0].State).To(Equal(util.WatchedResourceMissing))
+			Expect(status.WatchedResources[0].Hash).To(BeEmpty())
+
+			// Entries of resources the spec no longer watches are pruned
+			config := &reloaderv1alpha1.ReloaderConfig{Status: *status}
+			pruneWatchedResourceStatus(config)
+			Expect(config.Status.WatchedResources).To(BeEmpty())
+		})
+
+		It("Should prune objects by their group-qualified kind", func() {
+			config := &reloaderv1alpha1.ReloaderConfig{
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Objects: []reloaderv1alpha1.WatchedObject{
+							{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+						},
+					},
+				},
+				Status: reloaderv1alpha1.ReloaderConfigStatus{
+					WatchedResourceHashes: map[string]string{
+						"default/Certificate.cert-manager.io/api-tls": "hash-1",
+						"default/Certificate.example.com/api-tls":     "hash-2",
+						"default/Certificate/api-tls":                 "hash-3",
+						"default/Secret/db":                           "hash-4",
+					},
+					WatchedObjectPaths: map[string]string{
+						"default/Certificate.cert-manager.io/api-tls": "paths-1",
+						"default/Certificate.example.com/api-tls":     "paths-2",
+					},
+				},
+			}
+			setWatchedResourceStatus(&config.Status, "Certificate.cert-manager.io", "default", "api-tls", util.WatchedResourcePresent, "hash-1", false)
+			setWatchedResourceStatus(&config.Status, "Certificate.example.com", "default", "api-tls", util.WatchedResourcePresent, "hash-2", false)
+
+			pruneWatchedResourceStatus(config)
+			Expect(config.Status.WatchedResources).To(HaveLen(1))
+			Expect(config.Status.WatchedResources[0].Kind).To(Equal("Certificate.cert-manager.io"))
+			Expect(config.Status.WatchedResourceHashes).To(Equal(map[string]string{
+				"default/Certificate.cert-manager.io/api-tls": "hash-1",
+				"default/Secret/db":                           "hash-4",
+			}))
+			Expect(config.Status.WatchedObjectPaths).To(Equal(map[string]string{
+				"default/Certificate.cert-manager.io/api-tls": "paths-1",
+			}))
+		})
+	})
+
+	Context("When recording reload history", func() {
+		ctx := context.Background()
+
+		It("Should summarize the outcome of a change", func() {
+			succeeded := reloadResult{target: workload.Target{Kind: util.KindDeployment, Name: "app", Namespace: "default",
+				RolloutStrategy: util.RolloutStrategyRollout, ReloadStrategy: util.ReloadStrategyEnvVars}, outcome: util.ReloadOutcomeSucceeded}
+			failed := reloadResult{target: workload.Target{Kind: util.KindDeployment, Name: "worker", Namespace: "default",
+				RolloutStrategy: util.RolloutStrategyRestart}, outcome: util.ReloadOutcomeFailed, message: strings.Repeat("x", 500)}
+			skipped := reloadResult{target: workload.Target{Kind: util.KindStatefulSet, Name: "db", Namespace: "default"},
+				outcome: util.ReloadOutcomeSkipped, message: "workload is in pause period"}
+
+			entry := newReloadHistoryEntry(util.KindSecret, "creds", "default", "hash-1", time.Now(), []reloadResult{succeeded, failed})
+			Expect(entry.Outcome).To(Equal(util.ReloadOutcomePartiallySucceeded))
+			Expect(entry.TargetCount).To(Equal(int32(2)))
+			Expect(entry.Targets[0].Strategy).To(Equal("rollout/env-vars"))
+			Expect(entry.Targets[1].Strategy).To(Equal(util.RolloutStrategyRestart))
+			Expect(len(entry.Targets[1].Message)).To(Equal(maxHistoryMessageLength))
+
+			Expect(newReloadHistoryEntry(util.KindSecret, "creds", "default", "", time.Now(), []reloadResult{failed, skipped}).Outcome).
+				To(Equal(util.ReloadOutcomeFailed))
+			Expect(newReloadHistoryEntry(util.KindSecret, "creds", "default", "", time.Now(), []reloadResult{skipped}).Outcome).
+				To(Equal(util.ReloadOutcomeSkipped))
+
+			// A target waiting for its notify delay is reloaded, but not yet
+			scheduled := reloadResult{target: workload.Target{Kind: util.KindDeployment, Name: "prometheus", Namespace: "default",
+				RolloutStrategy: util.RolloutStrategyNotify}, outcome: util.ReloadOutcomeScheduled, message: "pods are notified in 30s"}
+			Expect(scheduled.reloaded()).To(BeTrue())
+			Expect(newReloadHistoryEntry(util.KindSecret, "creds", "default", "hash-1", time.Now(), []reloadResult{succeeded, scheduled}).Outcome).
+				To(Equal(util.ReloadOutcomeScheduled))
+			Expect(newReloadHistoryEntry(util.KindSecret, "creds", "default", "hash-1", time.Now(), []reloadResult{scheduled, failed}).Outcome).
+				To(Equal(util.ReloadOutcomePartiallySucceeded))
+
+			// Only the first targets are listed
+			many := make([]reloadResult, maxHistoryTargets+5)
+			for i := range many {
+				many[i] = succeeded
+			}
+			entry = newReloadHistoryEntry(util.KindSecret, "creds", "default", "hash-1", time.Now(), many)
+			Expect(entry.Outcome).To(Equal(util.ReloadOutcomeSucceeded))
+			Expect(entry.Targets).To(HaveLen(maxHistoryTargets))
+			Expect(entry.TargetCount).To(Equal(int32(maxHistoryTargets + 5)))
+		})
+
+		It("Should list targets in a stable order and truncate messages on characters", func() {
+			newResult := func(kind, name, message string) reloadResult {
+				return reloadResult{target: workload.Target{Kind: kind, Name: name, Namespace: "default"},
+					outcome: util.ReloadOutcomeFailed, message: message}
+			}
+			results := []reloadResult{
+				newResult(util.KindStatefulSet, "db", ""),
+				newResult(util.KindDeployment, "worker", strings.Repeat("é", 500)),
+				newResult(util.KindDeployment, "app", ""),
+			}
+
+			entry := newReloadHistoryEntry(util.KindSecret, "creds", "default", "", time.Now(), results)
+			names := []string{}
+			for _, target := range entry.Targets {
+				names = append(names, target.Kind+"/"+target.Name)
+			}
+			Expect(names).To(Equal([]string{"Deployment/app", "Deployment/worker", "StatefulSet/db"}))
+			Expect(utf8.ValidString(entry.Targets[1].Message)).To(BeTrue())
+			Expect(utf8.RuneCountInString(entry.Targets[1].Message)).To(Equal(maxHistoryMessageLength))
+
+			// The caller's results are left alone
+			Expect(results[0].target.Name).To(Equal("db"))
+		})
+
+		It("Should keep the newest entries up to the limit", func() {
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "history-config",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Secrets: []string{"history-secret"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			for _, hash := range []string{"hash-1", "hash-2", "hash-3", "hash-4"} {
+				results := []reloadResult{{
+					target:  workload.Target{Kind: util.KindDeployment, Name: "history-app", Namespace: "default", Config: config},
+					outcome: util.ReloadOutcomeSucceeded,
+				}}
+				reconciler.recordReloadHistory(util.KindSecret, "history-secret", "default", hash, time.Now(), results)
+			}
+
+			Eventually(func() []string {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "history-config",
+					Namespace: "default",
+				}, config)
+				if err != nil {
+					return nil
+				}
+				var hashes []string
+				for _, entry := range config.Status.ReloadHistory {
+					hashes = append(hashes, entry.ResourceHash)
+				}
+				return hashes
+			}, timeout, interval).Should(Equal([]string{"hash-4", "hash-3", "hash-2"}))
+		})
+	})
 })
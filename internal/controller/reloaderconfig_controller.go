@@ -19,23 +19,33 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
 	"github.com/stakater/Reloader/internal/pkg/alerts"
+	"github.com/stakater/Reloader/internal/pkg/cloudevents"
+	"github.com/stakater/Reloader/internal/pkg/sharding"
 	"github.com/stakater/Reloader/internal/pkg/util"
 	"github.com/stakater/Reloader/internal/pkg/workload"
 )
@@ -47,6 +57,8 @@ type ReloaderConfigReconciler struct {
 	WorkloadFinder  *workload.Finder
 	WorkloadUpdater *workload.Updater
 	AlertManager    *alerts.AlertManager
+	EventEmitter    *cloudevents.Emitter  // optional, emits reload lifecycle CloudEvents
+	Sharding        *sharding.Coordinator // optional, limits this replica to the namespaces it owns
 	statusQueue     workqueue.TypedRateLimitingInterface[statusUpdateWorkItem]
 	ctx             context.Context
 	cancelFunc      context.CancelFunc
@@ -66,8 +78,40 @@ type ReloaderConfigReconciler struct {
 	NamespaceSelector labels.Selector
 	IgnoredNamespaces map[string]bool
 
+	// Instance class: only ReloaderConfigs and resources of this class are processed (see util.InClass)
+	ClassName string
+
+	// Concurrency: reconciles per controller and reload workers per change (values below 1 mean 1)
+	MaxConcurrentReconciles int
+	MaxConcurrentReloads    int
+	workloadLocks           util.KeyedMutex // serializes mutations of one workload, keyed by resource key
+
+	// ReloadHistoryLimit is the number of entries kept in status.reloadHistory (0 disables the history)
+	ReloadHistoryLimit int
+
 	// Initialization tracking (safeguard to prevent processing events during startup)
 	controllersInitialized atomic.Bool
+
+	// Watched objects of arbitrary kinds (spec.watchedResources.objects)
+	objectController controller.TypedController[objectRequest]
+	objectCache      cache.Cache
+	restMapper       meta.RESTMapper
+	objectWatchesMu  sync.Mutex
+	objectWatches    map[schema.GroupVersionKind]bool
+	objectHashes     sync.Map // "<config namespace>/<config name>|<resource key>" -> objectHash
+
+	// Final state of deleted Secrets/ConfigMaps, from the delete event until the delete is reconciled
+	tombstones sync.Map // resource key -> resourceTombstone
+
+	// Progress of the last reload request token per ReloaderConfig or workload, until it is recorded on the object
+	reloadRequests sync.Map // resource key -> reloadRequestState
+
+	// Parsed alert webhook templates of ReloaderConfigs
+	webhookTemplates sync.Map // "<namespace>/<name>" -> parsedWebhookTemplate
+
+	// Objects re-enqueued when this replica gains namespaces (sharding only)
+	resyncEvents       map[string]chan event.GenericEvent          // kind -> events for its controller
+	objectResyncEvents chan event.TypedGenericEvent[objectRequest] // watched objects of arbitrary kinds
 }
 
 // RBAC permissions for ReloaderConfig CRD
@@ -76,33 +120,49 @@ type ReloaderConfigReconciler struct {
 // +kubebuilder:rbac:groups=reloader.stakater.com,resources=reloaderconfigs/finalizers,verbs=update
 
 // RBAC permissions for Secrets and ConfigMaps
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update;patch
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 
 // RBAC permissions for Workloads
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;update;patch
 
+// RBAC permissions for workload revisions (required for versioned-copy ownership)
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list
+
 // RBAC permissions for Pods (required for restart strategy)
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete;patch
 
 // RBAC permissions for Namespaces (required for namespace filtering)
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
+// RBAC permissions for Leases (required for sharding)
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update;delete
+
 // RBAC permissions for Argo Rollouts (optional)
 // +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;update;patch
 
 // RBAC permissions for OpenShift DeploymentConfigs (optional)
 // +kubebuilder:rbac:groups=apps.openshift.io,resources=deploymentconfigs,verbs=get;list;watch;update;patch
 
+// RBAC permissions for Secrets Store CSI driver SecretProviderClasses (optional, reference resolution)
+// +kubebuilder:rbac:groups=secrets-store.csi.x-k8s.io,resources=secretproviderclasses,verbs=get;list;watch
+
+// RBAC permissions for SelfSubjectAccessReviews (checking access to watched object kinds)
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews,verbs=create
+
 // RBAC permissions for Events (for recording events)
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is the main entry point for the kubernetes reconciliation loop.
 //
 // Business Logic Flow:
-// The operator watches three types of resources and handles them differently:
+// The operator watches three types of resources and handles them differently.
+// Each has its own controller, so a request is never ambiguous about the kind
+// it is for; this Reconcile only handles ReloaderConfigs (see resourceReconciler
+// for Secrets and ConfigMaps):
 //
 // 1. ReloaderConfig CRD:
 //   - User creates/updates a ReloaderConfig resource defining which Secrets/ConfigMaps to watch
@@ -127,97 +187,32 @@ type ReloaderConfigReconciler struct {
 func (r *ReloaderConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Phase 1: Determine resource type
-	// We try to fetch the resource as different types to figure out what changed.
-	// This is necessary because controller-runtime enqueues only the object key,
-	// not the object type.
-
-	// Try to fetch as ReloaderConfig first
-	reloaderConfig := &reloaderv1alpha1.ReloaderConfig{}
-	err := r.Get(ctx, req.NamespacedName, reloaderConfig)
-
-	if err == nil {
-		// This is a ReloaderConfig change - user created or updated the CRD
-		logger.Info("Reconciling ReloaderConfig", "name", reloaderConfig.Name, "namespace", reloaderConfig.Namespace)
-		return r.reconcileReloaderConfig(ctx, reloaderConfig)
-	}
-
-	if !apierrors.IsNotFound(err) {
-		logger.Error(err, "Failed to get resource")
-		return ctrl.Result{}, err
+	// Requests queued before a rebalance may belong to another replica by now
+	if !r.ownsNamespace(req.Namespace) {
+		return ctrl.Result{}, nil
 	}
 
-	// Try to fetch as Secret
-	secret := &corev1.Secret{}
-	secretErr := r.Get(ctx, req.NamespacedName, secret)
-
-	if secretErr == nil {
-		// Secret exists - determine if it's a create or update event
-		lastHash := secret.Annotations[util.AnnotationLastHash]
-
-		if lastHash == "" && r.ReloadOnCreate {
-			// No last-hash annotation means this is a newly created Secret
-			logger.Info("Reconciling Secret (CREATE)", "name", secret.Name, "namespace", secret.Namespace)
-			return r.reconcileSecretCreated(ctx, secret)
-		}
-
-		// Has last-hash annotation or ReloadOnCreate is disabled - treat as update
-		logger.Info("Reconciling Secret (UPDATE)", "name", secret.Name, "namespace", secret.Namespace)
-		return r.reconcileSecret(ctx, secret)
-	}
-
-	if !apierrors.IsNotFound(secretErr) {
-		logger.Error(secretErr, "Failed to get Secret")
-		return ctrl.Result{}, secretErr
-	}
-
-	// Try to fetch as ConfigMap
-	configMap := &corev1.ConfigMap{}
-	configMapErr := r.Get(ctx, req.NamespacedName, configMap)
-
-	if configMapErr == nil {
-		// ConfigMap exists - determine if it's a create or update event
-		lastHash := configMap.Annotations[util.AnnotationLastHash]
-
-		if lastHash == "" && r.ReloadOnCreate {
-			// No last-hash annotation means this is a newly created ConfigMap
-			logger.Info("Reconciling ConfigMap (CREATE)", "name", configMap.Name, "namespace", configMap.Namespace)
-			return r.reconcileConfigMapCreated(ctx, configMap)
+	reloaderConfig := &reloaderv1alpha1.ReloaderConfig{}
+	if err := r.Get(ctx, req.NamespacedName, reloaderConfig); err != nil {
+		// Deleted ReloaderConfigs only leave their parsed webhook template and object hashes behind
+		if apierrors.IsNotFound(err) {
+			r.webhookTemplates.Delete(req.String())
+			r.forgetObjectHashes(req.NamespacedName, nil)
 		}
-
-		// Has last-hash annotation or ReloadOnCreate is disabled - treat as update
-		logger.Info("Reconciling ConfigMap (UPDATE)", "name", configMap.Name, "namespace", configMap.Namespace)
-		return r.reconcileConfigMap(ctx, configMap)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if !apierrors.IsNotFound(configMapErr) {
-		logger.Error(configMapErr, "Failed to get ConfigMap")
-		return ctrl.Result{}, configMapErr
-	}
-
-	// Both Secret and ConfigMap not found - check if this is a delete event
-	if r.ReloadOnDelete {
-		// We need to determine if this was a Secret or ConfigMap that was deleted
-		// Try to check which watcher triggered this event by examining recent activity
-		// For now, we'll try both delete handlers and let them handle the case gracefully
-
-		// Check if there are any ReloaderConfigs watching a Secret with this name
-		secretTargets, secretConfigs, _ := r.discoverTargets(ctx, util.KindSecret, req.Name, req.Namespace)
-		if len(secretTargets) > 0 || len(secretConfigs) > 0 {
-			logger.Info("Reconciling Secret (DELETE)", "name", req.Name, "namespace", req.Namespace)
-			return r.reconcileSecretDeleted(ctx, req.NamespacedName)
-		}
-
-		// Check if there are any ReloaderConfigs watching a ConfigMap with this name
-		configMapTargets, configMapConfigs, _ := r.discoverTargets(ctx, util.KindConfigMap, req.Name, req.Namespace)
-		if len(configMapTargets) > 0 || len(configMapConfigs) > 0 {
-			logger.Info("Reconciling ConfigMap (DELETE)", "name", req.Name, "namespace", req.Namespace)
-			return r.reconcileConfigMapDeleted(ctx, req.NamespacedName)
-		}
+	// Workload events and resyncs enqueue ReloaderConfigs of every class
+	if reloaderConfig.Spec.ReloaderClassName != r.ClassName {
+		logger.V(1).Info("ReloaderConfig belongs to another class, skipping",
+			"name", reloaderConfig.Name,
+			"class", reloaderConfig.Spec.ReloaderClassName)
+		return ctrl.Result{}, nil
 	}
 
-	// Resource was deleted or doesn't exist - nothing to do
-	return ctrl.Result{}, nil
+	// This is a ReloaderConfig change - user created or updated the CRD
+	logger.Info("Reconciling ReloaderConfig", "name", reloaderConfig.Name, "namespace", reloaderConfig.Namespace)
+	return r.reconcileReloaderConfig(ctx, reloaderConfig)
 }
 
 // reconcileReloaderConfig handles ReloaderConfig CRD changes
@@ -227,10 +222,12 @@ func (r *ReloaderConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 // It performs validation and initialization:
 //
 // 1. Initialize Status: Creates the hash tracking map if it doesn't exist
-// 2. Validate Watched Resources: Checks that all Secrets and ConfigMaps exist
+// 2. Validate Watched Resources: Checks that all Secrets, ConfigMaps and watched objects exist
 // 3. Initialize Hash Tracking: Calculates initial hash for each watched resource
 // 4. Validate Target Workloads: Ensures all target Deployments/StatefulSets/DaemonSets exist
-// 5. Update Status Conditions: Sets Available/Degraded/Progressing conditions
+// 5. Validate Alert Template: Ensures a custom webhook payload template parses and renders
+// 6. Update Status Conditions: Sets Available/Degraded/Progressing conditions
+// 7. Handle Reload Requests: Reloads the targets once per reload-requested token
 //
 // Why we do this:
 // - Early validation prevents runtime errors later when Secrets/ConfigMaps change
@@ -252,28 +249,52 @@ func (r *ReloaderConfigReconciler) reconcileReloaderConfig(
 	util.SetCondition(&config.Status.Conditions, util.ConditionProgressing, metav1.ConditionTrue, util.ReasonReconciling, "Reconciling ReloaderConfig")
 
 	// Phase 2: Validate and initialize watched resources
+	validObjects := true
 	if config.Spec.WatchedResources != nil {
 		// Process all watched Secrets
 		r.initializeWatchedSecrets(ctx, config)
 
 		// Process all watched ConfigMaps
 		r.initializeWatchedConfigMaps(ctx, config)
+
+		// Process all watched objects of other kinds
+		validObjects = r.initializeWatchedObjects(ctx, config)
+	}
+
+	// Drop the entries of resources no longer watched, then report every missing one at once
+	pruneWatchedResourceStatus(config)
+	r.forgetObjectHashes(client.ObjectKeyFromObject(config), config)
+	missing := missingWatchedResources(config.Status)
+	if len(missing) > 0 {
+		util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+			util.ReasonResourceNotFound, missingResourcesMessage(missing))
 	}
 
 	// Phase 3: Validate target workloads exist
 	// This prevents configuration errors where users specify non-existent targets
 	validTargets := r.validateTargetWorkloads(ctx, config)
 
+	// Surface template errors now instead of when the first alert is sent
+	validAlerts := r.validateAlertTemplate(ctx, config)
+
+	// Targets using the notify rollout strategy need to know where to send the request
+	validNotify := r.validateNotifyConfig(ctx, config)
+	validEnvVars := r.validateEnvVarsConfig(ctx, config)
+
 	// Phase 4: Update status conditions
 	// ObservedGeneration tracks which version of the spec we've reconciled
 	config.Status.ObservedGeneration = config.Generation
 
-	if validTargets {
+	if validTargets && validAlerts && validObjects && validNotify && validEnvVars {
 		// All targets exist - mark as Available
 		util.SetCondition(&config.Status.Conditions, util.ConditionAvailable, metav1.ConditionTrue,
 			util.ReasonReconciled, "ReloaderConfig is active and watching resources")
-		util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionFalse,
-			util.ReasonReconciled, "")
+
+		// A missing watched resource leaves it Degraded: it still reloads once the resource is created
+		if len(missing) == 0 {
+			util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionFalse,
+				util.ReasonReconciled, "")
+		}
 	}
 
 	// Clear progressing condition - reconciliation complete
@@ -282,13 +303,21 @@ func (r *ReloaderConfigReconciler) reconcileReloaderConfig(
 
 	// Phase 5: Persist status updates
 	// This updates the status subresource, which is separate from the main resource
-	if err := r.Status().Update(ctx, config); err != nil {
+	if err := r.Status().Update(ctx, config, client.FieldOwner(util.FieldManager)); err != nil {
 		logger.Error(err, "Failed to update ReloaderConfig status")
 		return ctrl.Result{}, err
 	}
 
 	logger.Info("Successfully reconciled ReloaderConfig", "name", config.Name)
-	return ctrl.Result{}, nil
+
+	// Phase 6: Reload the targets if a new manual reload was requested (retrying those not reloaded)
+	result := ctrl.Result{RequeueAfter: r.handleConfigReloadRequest(ctx, config)}
+
+	// A watched kind may not be served yet (e.g., its CRD is installed later) - check again
+	if !validObjects && (result.RequeueAfter == 0 || result.RequeueAfter > time.Minute) {
+		result.RequeueAfter = time.Minute
+	}
+	return result, nil
 }
 
 // initializeWatchedSecrets validates and initializes hash tracking for watched Secrets
@@ -299,8 +328,10 @@ func (r *ReloaderConfigReconciler) reconcileReloaderConfig(
 // 2. Calculate SHA256 hash of its data
 // 3. Store the hash in status for future change detection
 //
-// If a Secret doesn't exist, we log an error and set a Degraded condition,
-// but continue processing other Secrets (fail gracefully, not catastrophically).
+// Each Secret also gets a status.watchedResources entry: Present, Ignored (see
+// watchedResourceState) or Missing. Missing Secrets are reported in a single
+// Degraded condition with the other missing resources, and processing
+// continues with the other Secrets (fail gracefully, not catastrophically).
 func (r *ReloaderConfigReconciler) initializeWatchedSecrets(
 	ctx context.Context,
 	config *reloaderv1alpha1.ReloaderConfig,
@@ -316,16 +347,28 @@ func (r *ReloaderConfigReconciler) initializeWatchedSecrets(
 
 		if err := r.Get(ctx, key, secret); err != nil {
 			logger.Error(err, "Failed to get watched Secret", "name", secretName)
-			util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
-				util.ReasonResourceNotFound, fmt.Sprintf("Secret %s not found", secretName))
+			if apierrors.IsNotFound(err) {
+				// Reported together with the other missing resources (see missingWatchedResources)
+				setWatchedResourceStatus(&config.Status, util.KindSecret, config.Namespace, secretName, util.WatchedResourceMissing, "", false)
+			} else {
+				util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+					util.ReasonResourceNotFound, fmt.Sprintf("Failed to get Secret %s: %v", secretName, err))
+			}
 			continue
 		}
 
 		// Calculate SHA256 hash of Secret data
 		// This hash will be compared on future Secret updates to detect actual changes
-		hash := util.CalculateHash(secret.Data)
+		// (the cache only holds the hash, see util.TransformResourceData)
+		hash, err := util.GetResourceDataAndHash(secret)
+		if err != nil {
+			logger.Error(err, "Failed to hash watched Secret", "name", secretName)
+			continue
+		}
 		resourceKey := util.MakeResourceKey(secret.Namespace, util.KindSecret, secret.Name)
 		config.Status.WatchedResourceHashes[resourceKey] = hash
+		setWatchedResourceStatus(&config.Status, util.KindSecret, secret.Namespace, secret.Name,
+			r.watchedResourceState(config, util.KindSecret, secret), hash, false)
 		logger.V(1).Info("Initialized Secret hash", "secret", secretName, "hash", hash)
 	}
 }
@@ -350,21 +393,45 @@ func (r *ReloaderConfigReconciler) initializeWatchedConfigMaps(
 
 		if err := r.Get(ctx, key, configMap); err != nil {
 			logger.Error(err, "Failed to get watched ConfigMap", "name", cmName)
-			util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
-				util.ReasonResourceNotFound, fmt.Sprintf("ConfigMap %s not found", cmName))
+			if apierrors.IsNotFound(err) {
+				// Reported together with the other missing resources (see missingWatchedResources)
+				setWatchedResourceStatus(&config.Status, util.KindConfigMap, config.Namespace, cmName, util.WatchedResourceMissing, "", false)
+			} else {
+				util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+					util.ReasonResourceNotFound, fmt.Sprintf("Failed to get ConfigMap %s: %v", cmName, err))
+			}
 			continue
 		}
 
 		// ConfigMaps have both Data (string) and BinaryData ([]byte) fields
-		// We merge them together for hash calculation
-		data := util.MergeDataMaps(configMap.Data, configMap.BinaryData)
-		hash := util.CalculateHash(data)
+		// They are merged together for hash calculation
+		hash, err := util.GetResourceDataAndHash(configMap)
+		if err != nil {
+			logger.Error(err, "Failed to hash watched ConfigMap", "name", cmName)
+			continue
+		}
 		resourceKey := util.MakeResourceKey(configMap.Namespace, util.KindConfigMap, configMap.Name)
 		config.Status.WatchedResourceHashes[resourceKey] = hash
+		setWatchedResourceStatus(&config.Status, util.KindConfigMap, configMap.Namespace, configMap.Name,
+			r.watchedResourceState(config, util.KindConfigMap, configMap), hash, false)
 		logger.V(1).Info("Initialized ConfigMap hash", "configMap", cmName, "hash", hash)
 	}
 }
 
+// watchedResourceState returns the status.watchedResources state of an existing watched resource
+// It is Ignored when listed in spec.ignoreResources or annotated with the ignore annotation.
+func (r *ReloaderConfigReconciler) watchedResourceState(
+	config *reloaderv1alpha1.ReloaderConfig,
+	kind string,
+	obj client.Object,
+) string {
+	if r.shouldIgnoreResource(config, kind, obj.GetName(), obj.GetNamespace()) ||
+		obj.GetAnnotations()[util.AnnotationIgnore] == "true" {
+		return util.WatchedResourceIgnored
+	}
+	return util.WatchedResourcePresent
+}
+
 // validateTargetWorkloads checks that all target workloads exist in the cluster
 //
 // Business Logic:
@@ -402,6 +469,79 @@ func (r *ReloaderConfigReconciler) validateTargetWorkloads(
 	return validTargets
 }
 
+// validateAlertTemplate checks the ReloaderConfig's custom alert webhook template
+//
+// Business Logic:
+// The template is parsed and rendered against a sample message (see
+// alerts.NewWebhookTemplate). If that fails, the Degraded condition carries the
+// error so users see it with kubectl instead of discovering it when a reload
+// alert is silently sent with the wrong payload.
+//
+// Returns true if there is no template or the template is valid.
+func (r *ReloaderConfigReconciler) validateAlertTemplate(
+	ctx context.Context,
+	config *reloaderv1alpha1.ReloaderConfig,
+) bool {
+	if _, err := r.configWebhookTemplate(config); err != nil {
+		log.FromContext(ctx).Error(err, "Invalid alert webhook template")
+		util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+			util.ReasonInvalidSpec, fmt.Sprintf("Invalid alert webhook template: %v", err))
+		return false
+	}
+	return true
+}
+
+// validateNotifyConfig checks that every target using the notify rollout strategy has notify settings
+//
+// Business Logic:
+// The strategy is resolved per target like at reload time (target, then
+// ReloaderConfig, then the operator default). A notify target without a port
+// to send the request to sets the Degraded condition; its reloads would fail.
+//
+// Returns true if all notify targets are configured.
+func (r *ReloaderConfigReconciler) validateNotifyConfig(
+	ctx context.Context,
+	config *reloaderv1alpha1.ReloaderConfig,
+) bool {
+	defaultRolloutStrategy := util.GetDefaultRolloutStrategy(config.Spec.RolloutStrategy, r.RolloutStrategy)
+	for _, target := range config.Spec.Targets {
+		if util.GetDefaultRolloutStrategy(target.RolloutStrategy, defaultRolloutStrategy) != util.RolloutStrategyNotify {
+			continue
+		}
+		if targetNotifyConfig(config, target) == nil {
+			log.FromContext(ctx).Info("Notify target without notify settings", "kind", target.Kind, "name", target.Name)
+			util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+				util.ReasonInvalidSpec, fmt.Sprintf("Target %s/%s uses the notify rollout strategy without notify settings", target.Kind, target.Name))
+			return false
+		}
+	}
+	return true
+}
+
+// validateEnvVarsConfig checks the env-vars reload strategy settings of a ReloaderConfig
+//
+// Business Logic:
+// The CRD schema validates the prefix and the policy values, but not that the
+// "named" policy lists containers. Invalid settings set the Degraded condition;
+// env-vars reloads of the config's targets would fail.
+//
+// Returns true if the settings are valid or unset.
+func (r *ReloaderConfigReconciler) validateEnvVarsConfig(
+	ctx context.Context,
+	config *reloaderv1alpha1.ReloaderConfig,
+) bool {
+	if config.Spec.EnvVars == nil {
+		return true
+	}
+	if err := workload.ValidateEnvVarsConfig(*config.Spec.EnvVars); err != nil {
+		log.FromContext(ctx).Info("Invalid env var settings", "error", err.Error())
+		util.SetCondition(&config.Status.Conditions, util.ConditionDegraded, metav1.ConditionTrue,
+			util.ReasonInvalidSpec, fmt.Sprintf("Invalid envVars: %v", err))
+		return false
+	}
+	return true
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReloaderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Initialize the status update queue
@@ -436,21 +576,57 @@ func (r *ReloaderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Index workloads by the resources they reference so discovery avoids listing every workload
+	if err := workload.SetupIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	r.WorkloadFinder.Indexed = true
+
+	// Sharded replicas re-enqueue the namespaces they gain
+	r.setupSharding()
+
+	// Secrets and ConfigMaps are handled by one controller per kind
+	if err := r.setupResourceController(mgr, "reloaderconfig-secrets", util.KindSecret, newSecret, r.secretPredicates()); err != nil {
+		return err
+	}
+	if err := r.setupResourceController(mgr, "reloaderconfig-configmaps", util.KindConfigMap, newConfigMap, r.configMapPredicates()); err != nil {
+		return err
+	}
+
+	// Manual reload requests on annotated workloads are handled by one controller per kind
+	if err := r.setupReloadRequestControllers(mgr); err != nil {
+		return err
+	}
+
+	// Objects of arbitrary kinds are handled by a separate controller with dynamic watches
+	if err := r.setupObjectController(mgr); err != nil {
+		return err
+	}
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		// Watch ReloaderConfig CRD
-		For(&reloaderv1alpha1.ReloaderConfig{}).
-		// Watch Secrets - enqueue requests when Secrets change
+		For(&reloaderv1alpha1.ReloaderConfig{}, builder.WithPredicates(r.configClassPredicates(), r.shardPredicates())).
+		// Watch target workloads - re-validate the ReloaderConfigs targeting them
+		Watches(
+			&appsv1.Deployment{},
+			handler.EnqueueRequestsFromMapFunc(r.mapTargetWorkloadToRequests(util.KindDeployment)),
+			builder.WithPredicates(r.targetWorkloadPredicates()),
+		).
 		Watches(
-			&corev1.Secret{},
-			handler.EnqueueRequestsFromMapFunc(r.mapSecretToRequests),
-			builder.WithPredicates(r.secretPredicates()),
+			&appsv1.StatefulSet{},
+			handler.EnqueueRequestsFromMapFunc(r.mapTargetWorkloadToRequests(util.KindStatefulSet)),
+			builder.WithPredicates(r.targetWorkloadPredicates()),
 		).
-		// Watch ConfigMaps - enqueue requests when ConfigMaps change
 		Watches(
-			&corev1.ConfigMap{},
-			handler.EnqueueRequestsFromMapFunc(r.mapConfigMapToRequests),
-			builder.WithPredicates(r.configMapPredicates()),
+			&appsv1.DaemonSet{},
+			handler.EnqueueRequestsFromMapFunc(r.mapTargetWorkloadToRequests(util.KindDaemonSet)),
+			builder.WithPredicates(r.targetWorkloadPredicates()),
 		).
 		Named("reloaderconfig").
-		Complete(r)
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles})
+
+	if resync := r.resyncSource(kindReloaderConfig); resync != nil {
+		bldr = bldr.WatchesRawSource(resync)
+	}
+	return bldr.Complete(r)
 }
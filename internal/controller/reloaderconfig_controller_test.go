@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -185,6 +186,105 @@ var _ = Describe("ReloaderConfig Controller", func() {
 				return false
 			}, timeout, interval).Should(BeTrue())
 		})
+
+		It("Should become Available once a missing target is created", func() {
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config-rc3",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "test-app-rc3",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			conditionStatus := func(conditionType string) metav1.ConditionStatus {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-config-rc3", Namespace: "default"}, config); err != nil {
+					return ""
+				}
+				if cond := util.GetCondition(config.Status.Conditions, conditionType); cond != nil {
+					return cond.Status
+				}
+				return ""
+			}
+
+			// The target does not exist yet
+			Eventually(func() metav1.ConditionStatus {
+				return conditionStatus(util.ConditionDegraded)
+			}, timeout, interval).Should(Equal(metav1.ConditionTrue))
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-rc3",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "test3"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "test3"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// Creating the target re-validates the ReloaderConfig without a spec change
+			Eventually(func() metav1.ConditionStatus {
+				return conditionStatus(util.ConditionAvailable)
+			}, timeout, interval).Should(Equal(metav1.ConditionTrue))
+		})
+
+		It("Should set Degraded condition when the alert webhook template is invalid", func() {
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config-rc-template",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					Alerts: &reloaderv1alpha1.AlertConfig{
+						WebhookTemplate: &reloaderv1alpha1.WebhookTemplate{
+							Payload: `{"workload": "{{ .Workload }}"}`,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			// Verify Degraded condition carries the template error
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-config-rc-template",
+					Namespace: "default",
+				}, config)
+				if err != nil {
+					return false
+				}
+
+				cond := util.GetCondition(config.Status.Conditions, util.ConditionDegraded)
+				return cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == util.ReasonInvalidSpec
+			}, timeout, interval).Should(BeTrue())
+		})
 	})
 
 	Context("When reconciling a Secret change", func() {
@@ -383,6 +483,110 @@ var _ = Describe("ReloaderConfig Controller", func() {
 		})
 	})
 
+	Context("When a ReloaderConfig and a ConfigMap share a name", func() {
+		ctx := context.Background()
+
+		It("Should still reload on ConfigMap changes", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-collision",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "collision-test"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "collision-test"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "app-config",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationLastHash: util.CalculateHashFromStringMap(map[string]string{
+							"level": "info",
+						}),
+					},
+				},
+				Data: map[string]string{
+					"level": "info",
+				},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			defer k8sClient.Delete(ctx, configMap)
+
+			// Same name as the ConfigMap it watches
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "app-config",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						ConfigMaps: []string{"app-config"},
+					},
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "test-app-collision",
+						},
+					},
+					ReloadStrategy: "env-vars",
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			// Wait for config to initialize
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: "app-config", Namespace: "default"}, config)
+				return err == nil && util.IsConditionTrue(config.Status.Conditions, util.ConditionAvailable)
+			}, timeout, interval).Should(BeTrue())
+
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "app-config", Namespace: "default"}, configMap); err != nil {
+					return err
+				}
+				configMap.Data["level"] = "debug"
+				return k8sClient.Update(ctx, configMap)
+			}, timeout, interval).Should(Succeed())
+
+			// The ConfigMap event must not be taken for a ReloaderConfig event
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-app-collision", Namespace: "default"}, deployment); err != nil {
+					return false
+				}
+
+				expectedEnvVar := util.GetEnvVarName(util.KindConfigMap, "app-config")
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					for _, env := range container.Env {
+						if env.Name == expectedEnvVar {
+							return true
+						}
+					}
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
 	Context("When using annotation-based discovery", func() {
 		ctx := context.Background()
 
@@ -471,6 +675,292 @@ var _ = Describe("ReloaderConfig Controller", func() {
 			}, timeout, interval).Should(BeTrue())
 		})
 	})
+
+	Context("When watching objects of other kinds", func() {
+		ctx := context.Background()
+
+		It("Should trigger deployment reload when a hashed field of a watched object changes", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-object1",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "object-test1"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "object-test1"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// Any kind can be watched; a Service is used since envtest has no third-party CRDs
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-object1",
+					Namespace: "default",
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, service)).To(Succeed())
+			defer k8sClient.Delete(ctx, service)
+
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config-object1",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Objects: []reloaderv1alpha1.WatchedObject{
+							{
+								APIVersion: "v1",
+								Kind:       "Service",
+								Name:       "test-object1",
+								JSONPaths:  []string{"{.spec.ports}"},
+							},
+						},
+					},
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "test-app-object1",
+						},
+					},
+					ReloadStrategy: "env-vars",
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			// Wait for the baseline hash to be recorded
+			resourceKey := util.MakeResourceKey("default", "Service", "test-object1")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-config-object1",
+					Namespace: "default",
+				}, config)
+				return err == nil && config.Status.WatchedResourceHashes[resourceKey] != ""
+			}, timeout, interval).Should(BeTrue())
+
+			// Change the hashed field
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-object1", Namespace: "default"}, service); err != nil {
+					return err
+				}
+				service.Spec.Ports[0].Port = 8080
+				return k8sClient.Update(ctx, service)
+			}, timeout, interval).Should(Succeed())
+
+			// For Service "test-object1", the expected env var is STAKATER_TEST_OBJECT1_SERVICE
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-app-object1",
+					Namespace: "default",
+				}, deployment)
+				if err != nil {
+					return false
+				}
+
+				expectedEnvVar := util.GetEnvVarName("Service", "test-object1")
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					for _, env := range container.Env {
+						if env.Name == expectedEnvVar {
+							return true
+						}
+					}
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+		})
+
+		It("Should reload targets when a watched object with the reload-on-delete annotation is deleted", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-object-delete",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: int32Ptr(1),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "object-delete"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"app": "object-delete"},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "nginx",
+									Image: "nginx:latest",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			defer k8sClient.Delete(ctx, deployment)
+
+			// --reload-on-delete is off in this suite; the annotation opts this Service in
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-object-delete",
+					Namespace: "default",
+					Annotations: map[string]string{
+						util.AnnotationReloadOnDelete: "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, service)).To(Succeed())
+
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config-object-delete",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Objects: []reloaderv1alpha1.WatchedObject{
+							{
+								APIVersion: "v1",
+								Kind:       "Service",
+								Name:       "test-object-delete",
+							},
+						},
+					},
+					Targets: []reloaderv1alpha1.TargetWorkload{
+						{
+							Kind: util.KindDeployment,
+							Name: "test-app-object-delete",
+						},
+					},
+					ReloadStrategy: "env-vars",
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			resourceKey := util.MakeResourceKey("default", "Service", "test-object-delete")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-config-object-delete",
+					Namespace: "default",
+				}, config)
+				return err == nil && config.Status.WatchedResourceHashes[resourceKey] != ""
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, service)).To(Succeed())
+
+			// The delete strategy marks the object env var as deleted
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-app-object-delete", Namespace: "default"}, deployment); err != nil {
+					return false
+				}
+
+				expectedEnvVar := util.GetEnvVarName("Service", "test-object-delete")
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					for _, env := range container.Env {
+						if env.Name == expectedEnvVar && strings.HasPrefix(env.Value, "deleted-") {
+							return true
+						}
+					}
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+		})
+
+		It("Should set Degraded condition when a watched kind is not served", func() {
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config-object-unknown",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Objects: []reloaderv1alpha1.WatchedObject{
+							{
+								APIVersion: "example.com/v1",
+								Kind:       "FeatureFlagSet",
+								Name:       "flags",
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-config-object-unknown",
+					Namespace: "default",
+				}, config)
+				if err != nil {
+					return false
+				}
+
+				cond := util.GetCondition(config.Status.Conditions, util.ConditionDegraded)
+				return cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == util.ReasonInvalidSpec
+			}, timeout, interval).Should(BeTrue())
+		})
+
+		It("Should set Degraded condition when a Secret is watched as an object", func() {
+			config := &reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-config-object-secret",
+					Namespace: "default",
+				},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{
+						Objects: []reloaderv1alpha1.WatchedObject{
+							{
+								APIVersion: "v1",
+								Kind:       util.KindSecret,
+								Name:       "credentials",
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			defer k8sClient.Delete(ctx, config)
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "test-config-object-secret",
+					Namespace: "default",
+				}, config)
+				if err != nil {
+					return false
+				}
+
+				cond := util.GetCondition(config.Status.Conditions, util.ConditionDegraded)
+				return cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == util.ReasonInvalidSpec &&
+					strings.Contains(cond.Message, "watchedResources.secrets")
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
 })
 
 // Helper function
@@ -91,6 +91,7 @@ var _ = BeforeSuite(func() {
 	By("creating manager")
 	mgr, err := manager.New(cfg, manager.Options{
 		Scheme: scheme.Scheme,
+		Cache:  CacheOptions(),
 	})
 	Expect(err).NotTo(HaveOccurred())
 
@@ -101,6 +102,10 @@ var _ = BeforeSuite(func() {
 		WorkloadFinder:  workload.NewFinder(mgr.GetClient()),
 		WorkloadUpdater: workload.NewUpdater(mgr.GetClient()),
 		AlertManager:    alerts.NewAlertManager(mgr.GetClient(), false, "webhook", "", ""),
+
+		MaxConcurrentReconciles: 2,
+		MaxConcurrentReloads:    4,
+		ReloadHistoryLimit:      3,
 	}
 	err = reconciler.SetupWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
@@ -19,6 +19,8 @@ package alerts
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
 	"sync"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +34,21 @@ type AlertManager struct {
 	AlertSink           string
 	AlertWebhookURL     string
 	AlertAdditionalInfo string
+
+	// SinkConfig holds credentials and settings for sinks that are not plain webhooks
+	SinkConfig SinkConfig
+
+	// Queue delivers alerts asynchronously when set; otherwise alerts are sent inline
+	Queue *Queue
+
+	// WebhookTemplate replaces the Slack-shaped payload of the webhook sink when set
+	WebhookTemplate *WebhookTemplate
+
+	// Digest groups reload alerts into summary messages when set
+	Digest *Digest
+
+	// DigestImmediateFailures sends failed reloads right away even when Digest is set
+	DigestImmediateFailures bool
 }
 
 // NewAlertManager creates a new alert manager with global configuration
@@ -45,29 +62,102 @@ func NewAlertManager(client client.Client, alertOnReload bool, alertSink, webhoo
 	}
 }
 
-// SendReloadAlert sends alerts for a successful reload using global configuration
+// SendReloadAlert sends alerts for a reload using global configuration
+//
+// When a Queue is configured, the alert is only enqueued for each sender and
+// this function returns without waiting for any network I/O. Delivery errors
+// are then handled (retried or dead-lettered) by the queue.
 func (m *AlertManager) SendReloadAlert(
 	ctx context.Context,
 	message *Message,
+) error {
+	return m.SendReloadAlertWithTemplate(ctx, message, nil)
+}
+
+// SendReloadAlertWithTemplate sends alerts like SendReloadAlert, but renders the
+// webhook sink payload with the given template instead of the global one.
+// A nil template falls back to the global WebhookTemplate. The override is
+// used for ReloaderConfigs that define their own payload template.
+func (m *AlertManager) SendReloadAlertWithTemplate(
+	ctx context.Context,
+	message *Message,
+	webhookTemplate *WebhookTemplate,
 ) error {
 	// Check if alerting is enabled globally
 	if !m.AlertOnReload {
 		return nil
 	}
 
-	// Check if webhook URL is configured
-	if m.AlertWebhookURL == "" {
-		return fmt.Errorf("alert-on-reload is enabled but alert-webhook-url is not configured")
+	// Check that the selected sink is fully configured
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	if m.Digest == nil {
+		// Skipped reloads are only reported as part of a digest
+		if message.Status == StatusSkipped {
+			return nil
+		}
+		return m.deliver(ctx, message, webhookTemplate)
+	}
+
+	// Failures can bypass the digest so they are not delayed by the window
+	message.webhookTemplate = webhookTemplate
+	m.Digest.Add(message)
+	if m.DigestImmediateFailures && message.Status == StatusFailed {
+		// Only the digest reports the next success, so the failure opens the digest's incident (see incidentKey)
+		immediate := *message
+		immediate.resourceIncident = true
+		return m.deliver(ctx, &immediate, webhookTemplate)
+	}
+	return nil
+}
+
+// FlushDigest sends the digest of a resource change once all its reloads were processed
+// It is a no-op unless per-change digests are enabled.
+func (m *AlertManager) FlushDigest(ctx context.Context, changeKey string) {
+	if !m.AlertOnReload || m.Digest == nil {
+		return
+	}
+	m.Digest.FlushChange(ctx, changeKey)
+}
+
+// sendDigest delivers a digest summary with the global configuration, or with the
+// ReloaderConfig template shared by all its messages (see Summarize)
+func (m *AlertManager) sendDigest(ctx context.Context, summary *Message) {
+	if err := m.deliver(ctx, summary, summary.webhookTemplate); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to send alert digest")
+	}
+}
+
+// EnableDigest groups reload alerts according to opts (no-op for DigestOff)
+func (m *AlertManager) EnableDigest(opts DigestOptions) *Digest {
+	if opts.Mode == "" || opts.Mode == DigestOff {
+		return nil
 	}
+	m.Digest = NewDigest(opts, m.sendDigest)
+	m.DigestImmediateFailures = opts.ImmediateFailures
+	return m.Digest
+}
 
+// deliver sends a message to the configured sink, through the queue if one is set
+func (m *AlertManager) deliver(
+	ctx context.Context,
+	message *Message,
+	webhookTemplate *WebhookTemplate,
+) error {
 	logger := log.FromContext(ctx)
 
-	// Add additional info to message if configured
+	// Add additional info to a copy of the message if configured
+	// The caller's message may also be held by the digest, which summarizes it later.
 	if m.AlertAdditionalInfo != "" {
-		if message.Fields == nil {
-			message.Fields = make(map[string]string)
+		withInfo := *message
+		withInfo.Fields = maps.Clone(message.Fields)
+		if withInfo.Fields == nil {
+			withInfo.Fields = make(map[string]string)
 		}
-		message.Fields["Additional Info"] = m.AlertAdditionalInfo
+		withInfo.Fields["Additional Info"] = m.AlertAdditionalInfo
+		message = &withInfo
 	}
 
 	// Collect all senders based on global alert sink configuration
@@ -75,17 +165,38 @@ func (m *AlertManager) SendReloadAlert(
 
 	// Create sender based on alert sink type
 	switch m.AlertSink {
-	case "slack":
+	case SinkSlack:
 		senders = append(senders, NewSlackSender(m.AlertWebhookURL))
-	case "teams":
+	case SinkTeams:
 		senders = append(senders, NewTeamsSender(m.AlertWebhookURL))
-	case "gchat":
+	case SinkGoogleChat:
 		senders = append(senders, NewGoogleChatSender(m.AlertWebhookURL))
-	case "webhook":
-		// Use Slack format for generic webhooks (most compatible)
-		senders = append(senders, NewSlackSender(m.AlertWebhookURL))
-	default:
-		return fmt.Errorf("unknown alert sink type: %s (supported: slack, teams, gchat, webhook)", m.AlertSink)
+	case SinkWebhook:
+		if webhookTemplate == nil {
+			webhookTemplate = m.WebhookTemplate
+		}
+		if webhookTemplate != nil {
+			senders = append(senders, NewTemplateWebhookSender(m.AlertWebhookURL, webhookTemplate))
+		} else {
+			// Use Slack format for generic webhooks (most compatible)
+			senders = append(senders, NewSlackSender(m.AlertWebhookURL))
+		}
+	case SinkPagerDuty:
+		senders = append(senders, NewPagerDutySender(m.AlertWebhookURL, m.SinkConfig.PagerDutyRoutingKey))
+	case SinkOpsgenie:
+		senders = append(senders, NewOpsgenieSender(m.AlertWebhookURL, m.SinkConfig.OpsgenieAPIKey))
+	case SinkDiscord:
+		senders = append(senders, NewDiscordSender(m.AlertWebhookURL))
+	case SinkMattermost:
+		senders = append(senders, NewMattermostSender(m.AlertWebhookURL))
+	case SinkEmail:
+		senders = append(senders, NewEmailSender(
+			m.SinkConfig.SMTPAddress,
+			m.SinkConfig.SMTPUsername,
+			m.SinkConfig.SMTPPassword,
+			m.SinkConfig.EmailFrom,
+			m.SinkConfig.EmailTo,
+		))
 	}
 
 	if len(senders) == 0 {
@@ -93,6 +204,16 @@ func (m *AlertManager) SendReloadAlert(
 		return nil
 	}
 
+	// Hand off to the async queue so the caller never blocks on alert I/O
+	if m.Queue != nil {
+		for _, sender := range senders {
+			if err := m.Queue.Enqueue(sender, message); err != nil {
+				return fmt.Errorf("%s: %w", sender.Name(), err)
+			}
+		}
+		return nil
+	}
+
 	// Send alerts concurrently
 	var wg sync.WaitGroup
 	errorChan := make(chan error, len(senders))
@@ -129,6 +250,35 @@ func (m *AlertManager) SendReloadAlert(
 	return nil
 }
 
+// Validate checks that the configured alert sink has all the settings it needs
+//
+// Webhook-style sinks require alert-webhook-url. PagerDuty and Opsgenie use it
+// only to override the public API endpoint, but require their own credentials.
+// Email requires an SMTP server, a sender and at least one recipient.
+func (m *AlertManager) Validate() error {
+	switch m.AlertSink {
+	case SinkSlack, SinkTeams, SinkGoogleChat, SinkWebhook, SinkDiscord, SinkMattermost:
+		if m.AlertWebhookURL == "" {
+			return fmt.Errorf("alert-on-reload is enabled but alert-webhook-url is not configured")
+		}
+	case SinkPagerDuty:
+		if m.SinkConfig.PagerDutyRoutingKey == "" {
+			return fmt.Errorf("alert sink %s requires alert-pagerduty-routing-key", m.AlertSink)
+		}
+	case SinkOpsgenie:
+		if m.SinkConfig.OpsgenieAPIKey == "" {
+			return fmt.Errorf("alert sink %s requires alert-opsgenie-api-key", m.AlertSink)
+		}
+	case SinkEmail:
+		if m.SinkConfig.SMTPAddress == "" || m.SinkConfig.EmailFrom == "" || len(m.SinkConfig.EmailTo) == 0 {
+			return fmt.Errorf("alert sink %s requires alert-smtp-address, alert-email-from and alert-email-to", m.AlertSink)
+		}
+	default:
+		return fmt.Errorf("unknown alert sink type: %s (supported: %s)", m.AlertSink, strings.Join(SupportedSinks, ", "))
+	}
+	return nil
+}
+
 // NewReloadSuccessMessage creates a message for successful reload
 func NewReloadSuccessMessage(
 	workloadKind, workloadName, workloadNamespace string,
@@ -139,6 +289,7 @@ func NewReloadSuccessMessage(
 		Title:             "🔄 Workload Reloaded",
 		Text:              fmt.Sprintf("Successfully reloaded %s/%s due to %s change", workloadKind, workloadName, resourceKind),
 		Color:             "good",
+		Status:            StatusSucceeded,
 		WorkloadKind:      workloadKind,
 		WorkloadName:      workloadName,
 		WorkloadNamespace: workloadNamespace,
@@ -160,6 +311,7 @@ func NewReloadErrorMessage(
 		Title:             "❌ Reload Failed",
 		Text:              fmt.Sprintf("Failed to reload %s/%s due to %s change", workloadKind, workloadName, resourceKind),
 		Color:             "danger",
+		Status:            StatusFailed,
 		WorkloadKind:      workloadKind,
 		WorkloadName:      workloadName,
 		WorkloadNamespace: workloadNamespace,
@@ -170,3 +322,26 @@ func NewReloadErrorMessage(
 		Fields:            make(map[string]string),
 	}
 }
+
+// NewReloadSkippedMessage creates a message for a reload that was skipped (e.g., pause period)
+// Skipped reloads are only reported in alert digests.
+func NewReloadSkippedMessage(
+	workloadKind, workloadName, workloadNamespace string,
+	resourceKind, resourceName string,
+	reloadStrategy string,
+	reason string,
+) *Message {
+	return &Message{
+		Title:             "⏸️ Reload Skipped",
+		Text:              fmt.Sprintf("Skipped reload of %s/%s: %s", workloadKind, workloadName, reason),
+		Color:             "warning",
+		Status:            StatusSkipped,
+		WorkloadKind:      workloadKind,
+		WorkloadName:      workloadName,
+		WorkloadNamespace: workloadNamespace,
+		ResourceKind:      resourceKind,
+		ResourceName:      resourceName,
+		ReloadStrategy:    reloadStrategy,
+		Fields:            make(map[string]string),
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Digest modes
+const (
+	// DigestOff sends one alert per reload (default)
+	DigestOff = "off"
+
+	// DigestPerChange groups all reloads triggered by the same resource change
+	DigestPerChange = "change"
+
+	// DigestWindow groups all reloads that happen within the digest window
+	DigestWindow = "window"
+)
+
+// DefaultDigestWindow is how long reloads are collected before a digest is sent
+const DefaultDigestWindow = 30 * time.Second
+
+// maxDigestListLength caps how many workloads are listed per outcome in a digest
+const maxDigestListLength = 20
+
+// DigestOptions configures alert digests
+type DigestOptions struct {
+	// Mode is one of DigestOff, DigestPerChange or DigestWindow
+	Mode string
+
+	// Window is how long reloads are collected. In DigestPerChange mode it is an
+	// upper bound: the digest is normally sent as soon as the change is processed.
+	Window time.Duration
+
+	// ImmediateFailures sends failed reloads right away (in addition to listing them in the digest)
+	ImmediateFailures bool
+}
+
+// Digest collects reload alerts and sends them as a single summary message
+//
+// Business Logic:
+// Rotating a Secret shared by many workloads would otherwise produce one alert
+// per workload. The digest groups messages by key (the resource change in
+// DigestPerChange mode, a single group in DigestWindow mode). A group is sent
+// when its window expires or, in DigestPerChange mode, when Flush is called
+// after the change has been fully processed.
+type Digest struct {
+	opts DigestOptions
+	send func(ctx context.Context, message *Message)
+
+	mu     sync.Mutex
+	groups map[string]*digestGroup
+}
+
+// digestGroup is a set of messages waiting to be summarized
+type digestGroup struct {
+	messages []*Message
+	timer    *time.Timer
+}
+
+// NewDigest creates a digest that hands summaries to send
+func NewDigest(opts DigestOptions, send func(ctx context.Context, message *Message)) *Digest {
+	if opts.Window <= 0 {
+		opts.Window = DefaultDigestWindow
+	}
+
+	return &Digest{
+		opts:   opts,
+		send:   send,
+		groups: make(map[string]*digestGroup),
+	}
+}
+
+// ValidateDigestMode checks a digest mode value
+func ValidateDigestMode(mode string) error {
+	switch mode {
+	case DigestOff, DigestPerChange, DigestWindow:
+		return nil
+	default:
+		return fmt.Errorf("unknown alert digest mode: %s (supported: %s, %s, %s)", mode, DigestOff, DigestPerChange, DigestWindow)
+	}
+}
+
+// ChangeKey identifies a single resource change
+func ChangeKey(resourceKind, resourceNamespace, resourceName, resourceHash string) string {
+	return fmt.Sprintf("%s/%s/%s@%s", resourceNamespace, resourceKind, resourceName, resourceHash)
+}
+
+// Add buffers a message until its group is sent
+func (d *Digest) Add(message *Message) {
+	key := d.groupKey(message)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	group, ok := d.groups[key]
+	if !ok {
+		group = &digestGroup{}
+		group.timer = time.AfterFunc(d.opts.Window, func() {
+			d.flush(context.Background(), key, group)
+		})
+		d.groups[key] = group
+	}
+	group.messages = append(group.messages, message)
+}
+
+// Flush sends the digest for a group immediately, if it has pending messages
+func (d *Digest) Flush(ctx context.Context, key string) {
+	d.flush(ctx, key, nil)
+}
+
+// flush sends the digest for a group, if it has pending messages
+//
+// A window timer passes its own group: when it fires while the group is being
+// flushed, a new group may already be collecting under the same key, and that
+// one must wait for its own window.
+func (d *Digest) flush(ctx context.Context, key string, expected *digestGroup) {
+	d.mu.Lock()
+	group, ok := d.groups[key]
+	if ok && expected != nil && group != expected {
+		ok = false
+	}
+	if ok {
+		delete(d.groups, key)
+		group.timer.Stop()
+	}
+	d.mu.Unlock()
+
+	if !ok || len(group.messages) == 0 {
+		return
+	}
+
+	d.send(ctx, Summarize(group.messages))
+}
+
+// FlushChange sends the digest of a resource change once all its reloads were processed
+// It is a no-op outside DigestPerChange mode, where the window decides instead.
+func (d *Digest) FlushChange(ctx context.Context, changeKey string) {
+	if d.opts.Mode != DigestPerChange {
+		return
+	}
+	d.Flush(ctx, changeKey)
+}
+
+// FlushAll sends all pending digests (e.g., on shutdown)
+func (d *Digest) FlushAll(ctx context.Context) {
+	d.mu.Lock()
+	keys := make([]string, 0, len(d.groups))
+	for key := range d.groups {
+		keys = append(keys, key)
+	}
+	d.mu.Unlock()
+
+	for _, key := range keys {
+		d.Flush(ctx, key)
+	}
+}
+
+// Start implements manager.Runnable so pending digests are sent on shutdown
+func (d *Digest) Start(ctx context.Context) error {
+	<-ctx.Done()
+	d.FlushAll(context.Background())
+	return nil
+}
+
+// groupKey returns the group a message belongs to
+func (d *Digest) groupKey(message *Message) string {
+	if d.opts.Mode == DigestPerChange {
+		return ChangeKey(message.ResourceKind, message.ResourceNamespace, message.ResourceName, message.ResourceHash)
+	}
+	return ""
+}
+
+// Summarize builds a single digest message from individual reload messages
+//
+// The text lists the counts per outcome; the fields list the workloads
+// ("Succeeded (38)", "Failed (1)", "Skipped (1)"), truncated to keep the
+// message readable in chat clients. When every message was sent with the same
+// ReloaderConfig webhook template, the digest is sent with it too; otherwise
+// it uses the operator-wide payload.
+func Summarize(messages []*Message) *Message {
+	var succeeded, failed, skipped []string
+	resources := map[string]bool{}
+	var webhookTemplate *WebhookTemplate
+	if len(messages) > 0 {
+		webhookTemplate = messages[0].webhookTemplate
+	}
+
+	for _, msg := range messages {
+		if msg.webhookTemplate != webhookTemplate {
+			webhookTemplate = nil
+		}
+		workload := fmt.Sprintf("%s/%s/%s", msg.WorkloadNamespace, msg.WorkloadKind, msg.WorkloadName)
+		resources[fmt.Sprintf("%s %s/%s", msg.ResourceKind, msg.ResourceNamespace, msg.ResourceName)] = true
+
+		switch msg.Status {
+		case StatusFailed:
+			failed = append(failed, fmt.Sprintf("%s (%s)", workload, msg.Error))
+		case StatusSkipped:
+			skipped = append(skipped, workload)
+		default:
+			succeeded = append(succeeded, workload)
+		}
+	}
+
+	resourceList := make([]string, 0, len(resources))
+	for resource := range resources {
+		resourceList = append(resourceList, resource)
+	}
+	sort.Strings(resourceList)
+
+	summary := &Message{
+		Title: "📋 Reload Digest",
+		Text: fmt.Sprintf("%s changed: %d reloaded, %d failed, %d skipped",
+			strings.Join(resourceList, ", "), len(succeeded), len(failed), len(skipped)),
+		Color:     "good",
+		Status:    StatusSucceeded,
+		Timestamp: time.Now(),
+		Fields:    make(map[string]string),
+		Digest:    true,
+
+		webhookTemplate: webhookTemplate,
+	}
+
+	// Single-resource digests keep the resource in the structured fields
+	if len(messages) > 0 && len(resourceList) == 1 {
+		summary.ResourceKind = messages[0].ResourceKind
+		summary.ResourceName = messages[0].ResourceName
+		summary.ResourceNamespace = messages[0].ResourceNamespace
+		summary.ResourceHash = messages[0].ResourceHash
+	}
+
+	if len(skipped) > 0 {
+		summary.Color = "warning"
+		summary.Fields[fmt.Sprintf("Skipped (%d)", len(skipped))] = digestList(skipped)
+	}
+	if len(failed) > 0 {
+		summary.Color = "danger"
+		summary.Status = StatusFailed
+		summary.Error = fmt.Sprintf("%d of %d reloads failed", len(failed), len(messages))
+		summary.Fields[fmt.Sprintf("Failed (%d)", len(failed))] = digestList(failed)
+	}
+	if len(succeeded) > 0 {
+		summary.Fields[fmt.Sprintf("Succeeded (%d)", len(succeeded))] = digestList(succeeded)
+	}
+
+	return summary
+}
+
+// digestList renders a sorted, truncated list of workloads
+func digestList(items []string) string {
+	sort.Strings(items)
+	if len(items) <= maxDigestListLength {
+		return strings.Join(items, "\n")
+	}
+	return strings.Join(items[:maxDigestListLength], "\n") +
+		fmt.Sprintf("\n... and %d more", len(items)-maxDigestListLength)
+}
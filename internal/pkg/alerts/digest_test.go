@@ -0,0 +1,309 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// digestRecorder collects the summaries produced by a Digest
+type digestRecorder struct {
+	mu        sync.Mutex
+	summaries []*Message
+}
+
+func (d *digestRecorder) send(_ context.Context, message *Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.summaries = append(d.summaries, message)
+}
+
+func (d *digestRecorder) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.summaries)
+}
+
+// changeMessage returns a reload message for a workload caused by the db-credentials change
+func changeMessage(workloadName, status string) *Message {
+	var msg *Message
+	switch status {
+	case StatusFailed:
+		msg = NewReloadErrorMessage("Deployment", workloadName, "shop", "Secret", "db-credentials", "env-vars", "boom")
+	case StatusSkipped:
+		msg = NewReloadSkippedMessage("Deployment", workloadName, "shop", "Secret", "db-credentials", "env-vars", "paused")
+	default:
+		msg = NewReloadSuccessMessage("Deployment", workloadName, "shop", "Secret", "db-credentials", "env-vars")
+	}
+	msg.ResourceNamespace = "shop"
+	msg.ResourceHash = "abc"
+	return msg
+}
+
+func TestDigestPerChangeFlush(t *testing.T) {
+	recorder := &digestRecorder{}
+	digest := NewDigest(DigestOptions{Mode: DigestPerChange, Window: time.Hour}, recorder.send)
+
+	digest.Add(changeMessage("api", StatusSucceeded))
+	digest.Add(changeMessage("web", StatusSucceeded))
+	digest.Add(changeMessage("worker", StatusFailed))
+	digest.Add(changeMessage("cron", StatusSkipped))
+
+	// Another change must not be part of this digest
+	other := changeMessage("billing", StatusSucceeded)
+	other.ResourceHash = "def"
+	digest.Add(other)
+
+	digest.FlushChange(context.Background(), ChangeKey("Secret", "shop", "db-credentials", "abc"))
+
+	if recorder.count() != 1 {
+		t.Fatalf("expected 1 digest, got %d", recorder.count())
+	}
+	summary := recorder.summaries[0]
+	if summary.Text != "Secret shop/db-credentials changed: 2 reloaded, 1 failed, 1 skipped" {
+		t.Errorf("unexpected text: %s", summary.Text)
+	}
+	if summary.Status != StatusFailed || summary.Color != "danger" {
+		t.Errorf("expected a failed digest, got status=%s color=%s", summary.Status, summary.Color)
+	}
+	if summary.Fields["Succeeded (2)"] != "shop/Deployment/api\nshop/Deployment/web" {
+		t.Errorf("unexpected succeeded list: %q", summary.Fields["Succeeded (2)"])
+	}
+	if summary.Fields["Failed (1)"] != "shop/Deployment/worker (boom)" {
+		t.Errorf("unexpected failed list: %q", summary.Fields["Failed (1)"])
+	}
+	if summary.Fields["Skipped (1)"] != "shop/Deployment/cron" {
+		t.Errorf("unexpected skipped list: %q", summary.Fields["Skipped (1)"])
+	}
+
+	// The other change is still pending
+	digest.FlushAll(context.Background())
+	if recorder.count() != 2 {
+		t.Errorf("expected the pending change to be flushed, got %d digests", recorder.count())
+	}
+}
+
+func TestDigestStaleTimerKeepsNewGroup(t *testing.T) {
+	recorder := &digestRecorder{}
+	digest := NewDigest(DigestOptions{Mode: DigestPerChange, Window: time.Hour}, recorder.send)
+	key := ChangeKey("Secret", "shop", "db-credentials", "abc")
+
+	digest.Add(changeMessage("api", StatusSucceeded))
+	digest.mu.Lock()
+	first := digest.groups[key]
+	digest.mu.Unlock()
+	digest.Flush(context.Background(), key)
+
+	// The timer of the flushed group fires after a new group was created under the same key
+	digest.Add(changeMessage("web", StatusSucceeded))
+	digest.flush(context.Background(), key, first)
+	if recorder.count() != 1 {
+		t.Fatalf("expected the new group to stay pending, got %d digests", recorder.count())
+	}
+
+	digest.Flush(context.Background(), key)
+	if recorder.count() != 2 {
+		t.Errorf("expected the new group to be flushed, got %d digests", recorder.count())
+	}
+}
+
+func TestDigestWindow(t *testing.T) {
+	recorder := &digestRecorder{}
+	digest := NewDigest(DigestOptions{Mode: DigestWindow, Window: 50 * time.Millisecond}, recorder.send)
+
+	digest.Add(changeMessage("api", StatusSucceeded))
+	other := changeMessage("billing", StatusSucceeded)
+	other.ResourceName = "billing-config"
+	digest.Add(other)
+
+	// FlushChange is ignored in window mode
+	digest.FlushChange(context.Background(), ChangeKey("Secret", "shop", "db-credentials", "abc"))
+	if recorder.count() != 0 {
+		t.Fatalf("expected no digest before the window expires, got %d", recorder.count())
+	}
+
+	waitFor(t, func() bool { return recorder.count() == 1 })
+	summary := recorder.summaries[0]
+	if !strings.HasPrefix(summary.Text, "Secret shop/billing-config, Secret shop/db-credentials changed: 2 reloaded") {
+		t.Errorf("unexpected text: %s", summary.Text)
+	}
+}
+
+func TestSummarizeTruncatesLongLists(t *testing.T) {
+	messages := []*Message{}
+	for i := 0; i < maxDigestListLength+5; i++ {
+		messages = append(messages, changeMessage(fmt.Sprintf("app-%02d", i), StatusSucceeded))
+	}
+
+	summary := Summarize(messages)
+	list := summary.Fields[fmt.Sprintf("Succeeded (%d)", len(messages))]
+	if !strings.HasSuffix(list, "... and 5 more") {
+		t.Errorf("expected truncated list, got %q", list)
+	}
+	if summary.ResourceName != "db-credentials" {
+		t.Errorf("expected single-resource digest to keep the resource, got %q", summary.ResourceName)
+	}
+}
+
+func TestAlertManagerDigestImmediateFailures(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusOK)
+
+	manager := NewAlertManager(nil, true, SinkDiscord, server.URL, "")
+	manager.EnableDigest(DigestOptions{Mode: DigestPerChange, Window: time.Hour, ImmediateFailures: true})
+	ctx := context.Background()
+
+	if err := manager.SendReloadAlert(ctx, changeMessage("api", StatusSucceeded)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manager.SendReloadAlert(ctx, changeMessage("worker", StatusFailed)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the failure is sent right away
+	if len(*requests) != 1 {
+		t.Fatalf("expected 1 immediate alert, got %d", len(*requests))
+	}
+
+	manager.FlushDigest(ctx, ChangeKey("Secret", "shop", "db-credentials", "abc"))
+	if len(*requests) != 2 {
+		t.Fatalf("expected the digest to be sent, got %d requests", len(*requests))
+	}
+	embed := (*requests)[1].body["embeds"].([]interface{})[0].(map[string]interface{})
+	if embed["title"] != "📋 Reload Digest" {
+		t.Errorf("unexpected digest title: %v", embed["title"])
+	}
+}
+
+func TestAlertManagerDigestKeepsMessagesUnchanged(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusOK)
+
+	manager := NewAlertManager(nil, true, SinkDiscord, server.URL, "cluster: prod")
+	manager.EnableDigest(DigestOptions{Mode: DigestPerChange, Window: time.Hour, ImmediateFailures: true})
+	ctx := context.Background()
+
+	// The immediate failure alert carries the additional info, the message held by the digest does not
+	failure := changeMessage("worker", StatusFailed)
+	if err := manager.SendReloadAlert(ctx, failure); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*requests) != 1 || !strings.Contains(fmt.Sprint((*requests)[0].body), "cluster: prod") {
+		t.Fatalf("expected the immediate alert to carry the additional info, got %v", *requests)
+	}
+	if _, ok := failure.Fields["Additional Info"]; ok {
+		t.Errorf("the message held by the digest must not be changed, got fields %v", failure.Fields)
+	}
+}
+
+func TestAlertManagerDigestResolvesIncidents(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusAccepted)
+
+	manager := NewAlertManager(nil, true, SinkPagerDuty, server.URL, "")
+	manager.SinkConfig.PagerDutyRoutingKey = "routing-key"
+	manager.EnableDigest(DigestOptions{Mode: DigestPerChange, Window: time.Hour, ImmediateFailures: true})
+	ctx := context.Background()
+
+	// A failing change: the immediate failure and its digest open one incident
+	if err := manager.SendReloadAlert(ctx, changeMessage("worker", StatusFailed)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	manager.FlushDigest(ctx, ChangeKey("Secret", "shop", "db-credentials", "abc"))
+
+	// The next change of the resource reloads the workload successfully
+	success := changeMessage("worker", StatusSucceeded)
+	success.ResourceHash = "def"
+	if err := manager.SendReloadAlert(ctx, success); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	manager.FlushDigest(ctx, ChangeKey("Secret", "shop", "db-credentials", "def"))
+
+	if len(*requests) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(*requests))
+	}
+	immediate, digestFailure, digestSuccess := (*requests)[0].body, (*requests)[1].body, (*requests)[2].body
+	if immediate["event_action"] != "trigger" || digestFailure["event_action"] != "trigger" || digestSuccess["event_action"] != "resolve" {
+		t.Fatalf("expected trigger, trigger, resolve, got %v, %v, %v",
+			immediate["event_action"], digestFailure["event_action"], digestSuccess["event_action"])
+	}
+	if immediate["dedup_key"] != digestFailure["dedup_key"] || digestSuccess["dedup_key"] != digestFailure["dedup_key"] {
+		t.Errorf("the success must resolve every incident of the failure, got keys %v, %v and %v",
+			immediate["dedup_key"], digestFailure["dedup_key"], digestSuccess["dedup_key"])
+	}
+}
+
+func TestAlertManagerDigestUsesSharedTemplate(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusOK)
+
+	configTemplate, err := NewWebhookTemplate(`{"source": "config", "text": {{ json .Text }}}`, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected template error: %v", err)
+	}
+	manager := NewAlertManager(nil, true, SinkWebhook, server.URL, "")
+	manager.EnableDigest(DigestOptions{Mode: DigestPerChange, Window: time.Hour})
+	ctx := context.Background()
+	changeKey := ChangeKey("Secret", "shop", "db-credentials", "abc")
+
+	for _, name := range []string{"api", "worker"} {
+		if err := manager.SendReloadAlertWithTemplate(ctx, changeMessage(name, StatusSucceeded), configTemplate); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	manager.FlushDigest(ctx, changeKey)
+	if len(*requests) != 1 || (*requests)[0].body["source"] != "config" {
+		t.Fatalf("expected the digest to use the shared template, got %v", *requests)
+	}
+
+	// Messages with different templates fall back to the operator-wide payload
+	if err := manager.SendReloadAlertWithTemplate(ctx, changeMessage("api", StatusSucceeded), configTemplate); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manager.SendReloadAlert(ctx, changeMessage("worker", StatusSucceeded)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	manager.FlushDigest(ctx, changeKey)
+	if len(*requests) != 2 || (*requests)[1].body["source"] == "config" {
+		t.Fatalf("expected the digest to use the default payload, got %v", *requests)
+	}
+}
+
+func TestAlertManagerDropsSkippedWithoutDigest(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusOK)
+	manager := NewAlertManager(nil, true, SinkDiscord, server.URL, "")
+
+	if err := manager.SendReloadAlert(context.Background(), changeMessage("cron", StatusSkipped)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*requests) != 0 {
+		t.Errorf("expected skipped reloads not to alert without a digest, got %d requests", len(*requests))
+	}
+}
+
+func TestValidateDigestMode(t *testing.T) {
+	for _, mode := range []string{DigestOff, DigestPerChange, DigestWindow} {
+		if err := ValidateDigestMode(mode); err != nil {
+			t.Errorf("unexpected error for %s: %v", mode, err)
+		}
+	}
+	if err := ValidateDigestMode("hourly"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+// Discord embed limits (in characters); a message exceeding any of them is rejected
+const (
+	discordMaxTitleLength       = 256
+	discordMaxDescriptionLength = 4096
+	discordMaxFieldNameLength   = 256
+	discordMaxFieldValueLength  = 1024
+	discordMaxFields            = 25
+	discordMaxEmbedLength       = 6000 // title, description, field names and values and footer together
+)
+
+// discordFooter is the footer text of every embed
+const discordFooter = "Reloader Operator"
+
+// DiscordSender sends alerts to Discord using channel webhooks
+type DiscordSender struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewDiscordSender creates a new Discord alert sender
+func NewDiscordSender(webhookURL string) *DiscordSender {
+	return &DiscordSender{
+		webhookURL: webhookURL,
+		client:     newHTTPClient(),
+	}
+}
+
+// Name returns the sender name
+func (d *DiscordSender) Name() string {
+	return "Discord"
+}
+
+// Send sends an alert to Discord
+func (d *DiscordSender) Send(ctx context.Context, message *Message) error {
+	return postJSON(ctx, d.client, d.Name(), d.webhookURL, nil, d.buildPayload(message))
+}
+
+// buildPayload creates the Discord message payload using an embed
+func (d *DiscordSender) buildPayload(message *Message) map[string]interface{} {
+	// Discord expects the embed color as a decimal integer
+	color, _ := strconv.ParseInt(colorHex(message), 16, 64)
+
+	// Long errors and digest lists are truncated to the embed limits, fields last
+	title := util.TruncateString(message.Title, discordMaxTitleLength)
+	description := util.TruncateString(message.Text, discordMaxDescriptionLength)
+	remaining := discordMaxEmbedLength - utf8.RuneCountInString(title) - utf8.RuneCountInString(description) -
+		utf8.RuneCountInString(discordFooter)
+
+	details := messageDetails(message)
+
+	fields := []map[string]interface{}{}
+	for _, key := range sortedKeys(details) {
+		name := util.TruncateString(key, discordMaxFieldNameLength)
+		valueLength := min(discordMaxFieldValueLength, remaining-utf8.RuneCountInString(name))
+		if len(fields) == discordMaxFields || valueLength <= 0 {
+			break
+		}
+		value := util.TruncateString(details[key], valueLength)
+		remaining -= utf8.RuneCountInString(name) + utf8.RuneCountInString(value)
+
+		fields = append(fields, map[string]interface{}{
+			"name":   name,
+			"value":  value,
+			"inline": key != "Error",
+		})
+	}
+
+	embed := map[string]interface{}{
+		"title":       title,
+		"description": description,
+		"color":       color,
+		"fields":      fields,
+		"timestamp":   message.Timestamp.Format(time.RFC3339),
+		"footer": map[string]interface{}{
+			"text":     discordFooter,
+			"icon_url": reloaderIconURL,
+		},
+	}
+
+	return map[string]interface{}{
+		"username":   "Reloader",
+		"avatar_url": reloaderIconURL,
+		"embeds":     []map[string]interface{}{embed},
+	}
+}
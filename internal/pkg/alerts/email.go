@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// EmailSender sends alerts as plain-text email over SMTP
+type EmailSender struct {
+	address  string // host:port of the SMTP server
+	username string
+	password string
+	from     string
+	to       []string
+	timeout  time.Duration
+}
+
+// NewEmailSender creates a new SMTP email alert sender
+// Authentication (PLAIN) is only used when a username is set.
+func NewEmailSender(address, username, password, from string, to []string) *EmailSender {
+	return &EmailSender{
+		address:  address,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+		timeout:  10 * time.Second,
+	}
+}
+
+// Name returns the sender name
+func (e *EmailSender) Name() string {
+	return "Email"
+}
+
+// Send delivers the alert to all recipients
+//
+// The SMTP conversation is driven manually (instead of smtp.SendMail) so that
+// the dial honors the context and the whole exchange is bounded by a deadline.
+// STARTTLS is used whenever the server advertises it.
+func (e *EmailSender) Send(ctx context.Context, message *Message) error {
+	host, _, err := net.SplitHostPort(e.address)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP address %q: %w", e.address, err)
+	}
+
+	dialer := &net.Dialer{Timeout: e.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(e.timeout))
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if e.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.username, e.password, host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := c.Mail(e.from); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	for _, rcpt := range e.to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s failed: %w", rcpt, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(e.buildMessage(message)); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return c.Quit()
+}
+
+// buildMessage creates the RFC 5322 message (headers and plain-text body)
+func (e *EmailSender) buildMessage(message *Message) []byte {
+	var buf bytes.Buffer
+
+	// Subject may contain emoji, so encode it as a MIME encoded-word
+	subject := mime.QEncoding.Encode("utf-8", fmt.Sprintf("[Reloader] %s: %s/%s",
+		message.Title, message.WorkloadKind, message.WorkloadName))
+
+	fmt.Fprintf(&buf, "From: %s\r\n", e.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&buf, "Date: %s\r\n", message.Timestamp.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "%s\r\n\r\n", message.Text)
+
+	details := messageDetails(message)
+	for _, key := range sortedKeys(details) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, details[key])
+	}
+	fmt.Fprintf(&buf, "Time: %s\r\n", message.Timestamp.Format(time.RFC3339))
+
+	return buf.Bytes()
+}
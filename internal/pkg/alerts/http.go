@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// reloaderIconURL is the avatar used by senders that support a custom icon
+const reloaderIconURL = "https://raw.githubusercontent.com/stakater/Reloader/master/assets/web/reloader-round-100px.png"
+
+// newHTTPClient returns the HTTP client shared by the webhook-based senders
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+// postJSON marshals the payload and POSTs it to the given URL.
+// Any 2xx response is treated as success.
+func postJSON(
+	ctx context.Context,
+	client *http.Client,
+	senderName, url string,
+	headers map[string]string,
+	payload interface{},
+) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", senderName, err)
+	}
+
+	return postBody(ctx, client, senderName, url, "application/json", headers, data)
+}
+
+// postBody POSTs an already-encoded body with the given content type.
+// Headers are applied after Content-Type, so they may override it.
+func postBody(
+	ctx context.Context,
+	client *http.Client,
+	senderName, url, contentType string,
+	headers map[string]string,
+	body []byte,
+) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s request: %w", senderName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned status %d", senderName, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// incidentKey returns a stable key identifying alerts about the same workload.
+// Incident-style sinks (PagerDuty, Opsgenie) use it to resolve a failure alert
+// once the same workload reloads successfully.
+//
+// Digests have no workload, so they get keys of their own, per resource for
+// single-resource digests: a failed digest is resolved by the next successful
+// digest of the same resource. Failures sent ahead of their digest use the
+// digest's key, since their workload's next success is only sent in a digest.
+func incidentKey(message *Message) string {
+	if message.Digest || message.resourceIncident {
+		if message.ResourceKind == "" {
+			return "reloader-operator/digest"
+		}
+		return fmt.Sprintf("reloader-operator/digest/%s/%s/%s",
+			message.ResourceNamespace, message.ResourceKind, message.ResourceName)
+	}
+	return fmt.Sprintf("reloader-operator/%s/%s/%s",
+		message.WorkloadNamespace, message.WorkloadKind, message.WorkloadName)
+}
+
+// messageDetails flattens the structured message fields into a key-value map
+func messageDetails(message *Message) map[string]string {
+	details := map[string]string{
+		"Workload":  fmt.Sprintf("%s/%s", message.WorkloadKind, message.WorkloadName),
+		"Namespace": message.WorkloadNamespace,
+		"Resource":  fmt.Sprintf("%s/%s", message.ResourceKind, message.ResourceName),
+		"Strategy":  message.ReloadStrategy,
+	}
+
+	for key, value := range message.Fields {
+		details[key] = value
+	}
+
+	if message.Error != "" {
+		details["Error"] = message.Error
+	}
+
+	return details
+}
+
+// sortedKeys returns the keys of a map in sorted order for deterministic output
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// colorHex maps the message severity to an RGB hex color (without the leading #)
+func colorHex(message *Message) string {
+	if message.Error != "" {
+		return "ff0000"
+	}
+	switch message.Color {
+	case "warning":
+		return "ffcc00"
+	case "danger":
+		return "ff0000"
+	default:
+		return "36a64f"
+	}
+}
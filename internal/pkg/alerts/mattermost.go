@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"net/http"
+)
+
+// MattermostSender sends alerts to Mattermost using incoming webhooks
+type MattermostSender struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewMattermostSender creates a new Mattermost alert sender
+func NewMattermostSender(webhookURL string) *MattermostSender {
+	return &MattermostSender{
+		webhookURL: webhookURL,
+		client:     newHTTPClient(),
+	}
+}
+
+// Name returns the sender name
+func (m *MattermostSender) Name() string {
+	return "Mattermost"
+}
+
+// Send sends an alert to Mattermost
+func (m *MattermostSender) Send(ctx context.Context, message *Message) error {
+	return postJSON(ctx, m.client, m.Name(), m.webhookURL, nil, m.buildPayload(message))
+}
+
+// buildPayload creates the Mattermost message payload using a message attachment
+func (m *MattermostSender) buildPayload(message *Message) map[string]interface{} {
+	details := messageDetails(message)
+
+	fields := []map[string]interface{}{}
+	for _, key := range sortedKeys(details) {
+		fields = append(fields, map[string]interface{}{
+			"title": key,
+			"value": details[key],
+			"short": key != "Error",
+		})
+	}
+
+	attachment := map[string]interface{}{
+		"fallback":    message.Title + ": " + message.Text,
+		"color":       "#" + colorHex(message),
+		"title":       message.Title,
+		"text":        message.Text,
+		"fields":      fields,
+		"footer":      "Reloader Operator",
+		"footer_icon": reloaderIconURL,
+	}
+
+	return map[string]interface{}{
+		"username":    "Reloader",
+		"icon_url":    reloaderIconURL,
+		"attachments": []map[string]interface{}{attachment},
+	}
+}
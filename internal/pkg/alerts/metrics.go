@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// alertQueueDepth is the number of deliveries waiting to be sent or retried per queue
+	alertQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "reloader_alert_queue_depth",
+		Help: "Number of alert deliveries waiting to be sent or retried",
+	}, []string{"queue"})
+
+	// alertsSentTotal counts successfully delivered alerts per queue and sender
+	alertsSentTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reloader_alerts_sent_total",
+		Help: "Total number of alerts delivered successfully",
+	}, []string{"queue", "sender"})
+
+	// alertsDroppedTotal counts alerts rejected because their queue was full
+	alertsDroppedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reloader_alerts_dropped_total",
+		Help: "Total number of alerts dropped because the alert queue was full",
+	}, []string{"queue", "sender"})
+
+	// alertsDeadLetteredTotal counts alerts abandoned after exhausting retries
+	alertsDeadLetteredTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reloader_alerts_dead_lettered_total",
+		Help: "Total number of alerts abandoned after exhausting retries or exceeding the maximum age",
+	}, []string{"queue", "sender"})
+)
+
+func init() {
+	// Register with the controller-runtime registry so the metrics are served on the manager's metrics endpoint
+	metrics.Registry.MustRegister(
+		alertQueueDepth,
+		alertsSentTotal,
+		alertsDroppedTotal,
+		alertsDeadLetteredTotal,
+	)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+// DefaultOpsgenieAPIURL is the Opsgenie REST API base URL
+const DefaultOpsgenieAPIURL = "https://api.opsgenie.com"
+
+// opsgenieMaxMessageLength is the maximum length (in characters) Opsgenie accepts for an alert message
+const opsgenieMaxMessageLength = 130
+
+// OpsgenieSender sends alerts to Opsgenie using the Alert API
+//
+// Like PagerDuty, a failed reload creates an alert (aliased by workload) and a
+// successful reload closes it.
+type OpsgenieSender struct {
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+// NewOpsgenieSender creates a new Opsgenie alert sender
+// If apiURL is empty, the public Opsgenie API is used (set it for the EU instance).
+func NewOpsgenieSender(apiURL, apiKey string) *OpsgenieSender {
+	if apiURL == "" {
+		apiURL = DefaultOpsgenieAPIURL
+	}
+	return &OpsgenieSender{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		apiKey: apiKey,
+		client: newHTTPClient(),
+	}
+}
+
+// Name returns the sender name
+func (o *OpsgenieSender) Name() string {
+	return "Opsgenie"
+}
+
+// Send creates or closes an Opsgenie alert
+func (o *OpsgenieSender) Send(ctx context.Context, message *Message) error {
+	headers := map[string]string{
+		"Authorization": "GenieKey " + o.apiKey,
+	}
+
+	if message.Error == "" {
+		closeURL := fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias",
+			o.apiURL, url.PathEscape(incidentKey(message)))
+		return postJSON(ctx, o.client, o.Name(), closeURL, headers, o.buildClosePayload(message))
+	}
+
+	return postJSON(ctx, o.client, o.Name(), o.apiURL+"/v2/alerts", headers, o.buildCreatePayload(message))
+}
+
+// buildCreatePayload creates the payload for opening an alert
+func (o *OpsgenieSender) buildCreatePayload(message *Message) map[string]interface{} {
+	summary := util.TruncateString(fmt.Sprintf("%s: %s", message.Title, message.Text), opsgenieMaxMessageLength)
+
+	return map[string]interface{}{
+		"message":     summary,
+		"alias":       incidentKey(message),
+		"description": message.Error,
+		"entity":      fmt.Sprintf("%s/%s", message.WorkloadKind, message.WorkloadName),
+		"source":      "reloader-operator",
+		"priority":    "P3",
+		"tags":        []string{"reloader", message.WorkloadNamespace},
+		"details":     messageDetails(message),
+	}
+}
+
+// buildClosePayload creates the payload for closing an alert
+func (o *OpsgenieSender) buildClosePayload(message *Message) map[string]interface{} {
+	return map[string]interface{}{
+		"source": "reloader-operator",
+		"note":   message.Text,
+	}
+}
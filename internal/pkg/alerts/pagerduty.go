@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultPagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const DefaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutySender sends alerts to PagerDuty using the Events API v2
+//
+// Business Logic:
+// PagerDuty is an incident tool, so only failures open an incident:
+// - Failed reload: "trigger" event keyed by the workload
+// - Successful reload: "resolve" event with the same key, closing any open incident
+//
+// Resolving is stateless; PagerDuty ignores resolve events for keys without an
+// open incident, so the sender works across operator restarts.
+type PagerDutySender struct {
+	eventsURL  string
+	routingKey string
+	client     *http.Client
+}
+
+// NewPagerDutySender creates a new PagerDuty alert sender
+// If eventsURL is empty, the public Events API v2 endpoint is used.
+func NewPagerDutySender(eventsURL, routingKey string) *PagerDutySender {
+	if eventsURL == "" {
+		eventsURL = DefaultPagerDutyEventsURL
+	}
+	return &PagerDutySender{
+		eventsURL:  eventsURL,
+		routingKey: routingKey,
+		client:     newHTTPClient(),
+	}
+}
+
+// Name returns the sender name
+func (p *PagerDutySender) Name() string {
+	return "PagerDuty"
+}
+
+// Send sends a trigger or resolve event to PagerDuty
+func (p *PagerDutySender) Send(ctx context.Context, message *Message) error {
+	return postJSON(ctx, p.client, p.Name(), p.eventsURL, nil, p.buildPayload(message))
+}
+
+// buildPayload creates the PagerDuty Events v2 payload
+func (p *PagerDutySender) buildPayload(message *Message) map[string]interface{} {
+	payload := map[string]interface{}{
+		"routing_key": p.routingKey,
+		"dedup_key":   incidentKey(message),
+	}
+
+	if message.Error == "" {
+		payload["event_action"] = "resolve"
+		return payload
+	}
+
+	payload["event_action"] = "trigger"
+	payload["payload"] = map[string]interface{}{
+		"summary":        fmt.Sprintf("%s: %s", message.Title, message.Text),
+		"source":         "reloader-operator",
+		"severity":       "error",
+		"timestamp":      message.Timestamp.Format(time.RFC3339),
+		"component":      fmt.Sprintf("%s/%s", message.WorkloadKind, message.WorkloadName),
+		"group":          message.WorkloadNamespace,
+		"class":          "reload",
+		"custom_details": messageDetails(message),
+	}
+
+	return payload
+}
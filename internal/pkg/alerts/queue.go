@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Default settings for the alert delivery queue
+const (
+	DefaultQueueName       = "alerts"
+	DefaultQueueSize       = 1000
+	DefaultQueueWorkers    = 2
+	DefaultQueueMaxRetries = 5
+	DefaultQueueMaxAge     = 10 * time.Minute
+	DefaultQueueBaseDelay  = 1 * time.Second
+	DefaultQueueMaxDelay   = 1 * time.Minute
+)
+
+// ErrQueueFull is returned when an alert cannot be enqueued because the queue is at capacity
+var ErrQueueFull = errors.New("alert queue is full")
+
+// QueueOptions configures the alert delivery queue
+type QueueOptions struct {
+	// Name tells queues apart in the workqueue and alert queue metrics (the "queue" label)
+	Name string
+
+	// Size is the maximum number of pending deliveries (including ones waiting for a retry)
+	Size int
+
+	// Workers is the number of goroutines delivering alerts concurrently
+	Workers int
+
+	// MaxRetries is the number of retries before a delivery is dead-lettered
+	MaxRetries int
+
+	// MaxAge is how long a delivery may stay in the queue before it is dead-lettered
+	MaxAge time.Duration
+
+	// BaseDelay and MaxDelay bound the exponential backoff between retries
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// delivery is a single alert message destined for a single sender
+type delivery struct {
+	sender     Sender
+	message    *Message
+	enqueuedAt time.Time
+}
+
+// Queue delivers alerts asynchronously with exponential-backoff retries
+//
+// Business Logic:
+// Sending an alert is network I/O against a third-party service. Doing that
+// inside the reconcile loop means a slow or unavailable webhook stalls every
+// reload behind it. The queue decouples the two:
+//
+//  1. The reconciler enqueues one delivery per sender and returns immediately
+//  2. Workers pop deliveries and call Sender.Send
+//  3. Failed deliveries are re-queued with exponential backoff
+//  4. Deliveries that exceed MaxRetries or MaxAge are dead-lettered (logged and counted)
+//
+// The queue is bounded: when Size deliveries are pending, new ones are dropped
+// so that a dead sink cannot grow the operator's memory without limit.
+type Queue struct {
+	opts    QueueOptions
+	queue   workqueue.TypedRateLimitingInterface[*delivery]
+	pending atomic.Int64
+}
+
+// NewQueue creates a new alert delivery queue, filling in defaults for unset options
+func NewQueue(opts QueueOptions) *Queue {
+	if opts.Name == "" {
+		opts.Name = DefaultQueueName
+	}
+	if opts.Size <= 0 {
+		opts.Size = DefaultQueueSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultQueueWorkers
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = DefaultQueueMaxRetries
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = DefaultQueueMaxAge
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = DefaultQueueBaseDelay
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = DefaultQueueMaxDelay
+	}
+
+	return &Queue{
+		opts: opts,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[*delivery](opts.BaseDelay, opts.MaxDelay),
+			workqueue.TypedRateLimitingQueueConfig[*delivery]{Name: opts.Name},
+		),
+	}
+}
+
+// Enqueue schedules a message for delivery to a sender without blocking on I/O
+// The slot is reserved with a compare-and-swap, so concurrent callers cannot exceed Size.
+func (q *Queue) Enqueue(sender Sender, message *Message) error {
+	for {
+		pending := q.pending.Load()
+		if pending >= int64(q.opts.Size) {
+			alertsDroppedTotal.WithLabelValues(q.opts.Name, sender.Name()).Inc()
+			return ErrQueueFull
+		}
+		if q.pending.CompareAndSwap(pending, pending+1) {
+			break
+		}
+	}
+	alertQueueDepth.WithLabelValues(q.opts.Name).Inc()
+
+	q.queue.Add(&delivery{
+		sender:     sender,
+		message:    message,
+		enqueuedAt: time.Now(),
+	})
+
+	return nil
+}
+
+// Len returns the number of pending deliveries, including ones waiting for a retry
+func (q *Queue) Len() int {
+	return int(q.pending.Load())
+}
+
+// Start runs the delivery workers until the context is cancelled.
+// It implements manager.Runnable so the queue can be added to the controller manager.
+func (q *Queue) Start(ctx context.Context) error {
+	for i := 0; i < q.opts.Workers; i++ {
+		go func() {
+			for q.processNext(ctx) {
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	q.queue.ShutDown()
+	return nil
+}
+
+// processNext delivers a single item from the queue
+func (q *Queue) processNext(ctx context.Context) bool {
+	d, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer q.queue.Done(d)
+
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("Sending alert", "sender", d.sender.Name())
+
+	err := d.sender.Send(ctx, d.message)
+	if err == nil {
+		logger.Info("Successfully sent alert", "sender", d.sender.Name())
+		alertsSentTotal.WithLabelValues(q.opts.Name, d.sender.Name()).Inc()
+		q.complete(d)
+		return true
+	}
+
+	// Retry until either the retry budget or the maximum age is exhausted
+	retries := q.queue.NumRequeues(d)
+	if retries < q.opts.MaxRetries && time.Since(d.enqueuedAt) < q.opts.MaxAge {
+		logger.Error(err, "Failed to send alert, retrying",
+			"sender", d.sender.Name(),
+			"attempt", retries+1)
+		q.queue.AddRateLimited(d)
+		return true
+	}
+
+	q.deadLetter(ctx, d, err, retries+1)
+	q.complete(d)
+	return true
+}
+
+// deadLetter records a delivery that will not be retried any further
+func (q *Queue) deadLetter(ctx context.Context, d *delivery, err error, attempts int) {
+	log.FromContext(ctx).Error(err, "Alert dead-lettered after exhausting retries",
+		"sender", d.sender.Name(),
+		"attempts", attempts,
+		"age", time.Since(d.enqueuedAt).Round(time.Millisecond).String(),
+		"title", d.message.Title,
+		"workload", d.message.WorkloadKind+"/"+d.message.WorkloadName,
+		"namespace", d.message.WorkloadNamespace,
+		"resource", d.message.ResourceKind+"/"+d.message.ResourceName,
+		"reloadError", d.message.Error)
+	alertsDeadLetteredTotal.WithLabelValues(q.opts.Name, d.sender.Name()).Inc()
+}
+
+// complete removes a delivery from the queue's accounting
+func (q *Queue) complete(d *delivery) {
+	q.queue.Forget(d)
+	q.pending.Add(-1)
+	alertQueueDepth.WithLabelValues(q.opts.Name).Dec()
+}
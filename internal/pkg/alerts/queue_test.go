@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeSender fails the first failures calls to Send and succeeds afterwards
+type fakeSender struct {
+	failures int32
+	calls    atomic.Int32
+	block    chan struct{}
+}
+
+func (f *fakeSender) Name() string {
+	return "Fake"
+}
+
+func (f *fakeSender) Send(ctx context.Context, message *Message) error {
+	if f.block != nil {
+		<-f.block
+	}
+	if f.calls.Add(1) <= f.failures {
+		return errors.New("webhook returned status 503")
+	}
+	return nil
+}
+
+// waitFor polls until cond returns true or the timeout expires
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("condition not met before timeout")
+}
+
+func startQueue(t *testing.T, opts QueueOptions) *Queue {
+	t.Helper()
+	q := NewQueue(opts)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = q.Start(ctx)
+	}()
+	return q
+}
+
+func TestQueueRetriesUntilSuccess(t *testing.T) {
+	q := startQueue(t, QueueOptions{
+		MaxRetries: 5,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	})
+
+	sender := &fakeSender{failures: 2}
+	if err := q.Enqueue(sender, &Message{Title: "test"}); err != nil {
+		t.Fatalf("unexpected enqueue error: %v", err)
+	}
+
+	waitFor(t, func() bool { return q.Len() == 0 })
+
+	if got := sender.calls.Load(); got != 3 {
+		t.Errorf("expected 3 send attempts, got %d", got)
+	}
+}
+
+func TestQueueDeadLettersAfterMaxRetries(t *testing.T) {
+	q := startQueue(t, QueueOptions{
+		MaxRetries: 2,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	})
+
+	sender := &fakeSender{failures: 100}
+	if err := q.Enqueue(sender, &Message{Title: "test"}); err != nil {
+		t.Fatalf("unexpected enqueue error: %v", err)
+	}
+
+	waitFor(t, func() bool { return q.Len() == 0 })
+
+	// One initial attempt plus two retries
+	if got := sender.calls.Load(); got != 3 {
+		t.Errorf("expected 3 send attempts, got %d", got)
+	}
+}
+
+func TestQueueDeadLettersAfterMaxAge(t *testing.T) {
+	q := startQueue(t, QueueOptions{
+		MaxRetries: 1000,
+		MaxAge:     20 * time.Millisecond,
+		BaseDelay:  5 * time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	})
+
+	sender := &fakeSender{failures: 1000}
+	if err := q.Enqueue(sender, &Message{Title: "test"}); err != nil {
+		t.Fatalf("unexpected enqueue error: %v", err)
+	}
+
+	waitFor(t, func() bool { return q.Len() == 0 })
+
+	if got := sender.calls.Load(); got >= 1000 {
+		t.Errorf("expected delivery to be abandoned by max age, got %d attempts", got)
+	}
+}
+
+func TestQueueDropsWhenFull(t *testing.T) {
+	block := make(chan struct{})
+	q := startQueue(t, QueueOptions{Size: 2, Workers: 1})
+
+	sender := &fakeSender{block: block}
+	for i := 0; i < 2; i++ {
+		if err := q.Enqueue(sender, &Message{Title: "test"}); err != nil {
+			t.Fatalf("unexpected enqueue error: %v", err)
+		}
+	}
+
+	if err := q.Enqueue(sender, &Message{Title: "overflow"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+
+	close(block)
+	waitFor(t, func() bool { return q.Len() == 0 })
+
+	if got := sender.calls.Load(); got != 2 {
+		t.Errorf("expected 2 deliveries, got %d", got)
+	}
+}
+
+func TestQueueMetricsAreLabeledByQueue(t *testing.T) {
+	block := make(chan struct{})
+	alertQueue := startQueue(t, QueueOptions{Name: "test-alerts", Workers: 1})
+	eventQueue := startQueue(t, QueueOptions{Name: "test-events", Size: 1, Workers: 1})
+
+	sender := &fakeSender{block: block}
+	for _, q := range []*Queue{alertQueue, alertQueue, eventQueue} {
+		if err := q.Enqueue(sender, &Message{Title: "test"}); err != nil {
+			t.Fatalf("unexpected enqueue error: %v", err)
+		}
+	}
+	if err := eventQueue.Enqueue(sender, &Message{Title: "overflow"}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(alertQueueDepth.WithLabelValues("test-alerts")); got != 2 {
+		t.Errorf("expected depth 2 for test-alerts, got %v", got)
+	}
+	if got := testutil.ToFloat64(alertQueueDepth.WithLabelValues("test-events")); got != 1 {
+		t.Errorf("expected depth 1 for test-events, got %v", got)
+	}
+	if got := testutil.ToFloat64(alertsDroppedTotal.WithLabelValues("test-alerts", "Fake")); got != 0 {
+		t.Errorf("expected no drops for test-alerts, got %v", got)
+	}
+	if got := testutil.ToFloat64(alertsDroppedTotal.WithLabelValues("test-events", "Fake")); got != 1 {
+		t.Errorf("expected 1 drop for test-events, got %v", got)
+	}
+
+	close(block)
+	waitFor(t, func() bool { return alertQueue.Len() == 0 && eventQueue.Len() == 0 })
+
+	if got := testutil.ToFloat64(alertsSentTotal.WithLabelValues("test-alerts", "Fake")); got != 2 {
+		t.Errorf("expected 2 sent for test-alerts, got %v", got)
+	}
+	if got := testutil.ToFloat64(alertsSentTotal.WithLabelValues("test-events", "Fake")); got != 1 {
+		t.Errorf("expected 1 sent for test-events, got %v", got)
+	}
+}
+
+func TestQueueConcurrentEnqueueRespectsSize(t *testing.T) {
+	// Not started: nothing is delivered, so every accepted delivery stays pending
+	q := NewQueue(QueueOptions{Size: 10})
+	sender := &fakeSender{}
+
+	var accepted atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if q.Enqueue(sender, &Message{Title: "test"}) == nil {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := accepted.Load(); got != 10 {
+		t.Errorf("expected 10 accepted deliveries, got %d", got)
+	}
+	if got := q.Len(); got != 10 {
+		t.Errorf("expected 10 pending deliveries, got %d", got)
+	}
+}
+
+func TestSendReloadAlertEnqueuesWithoutBlocking(t *testing.T) {
+	manager := NewAlertManager(nil, true, "slack", "http://127.0.0.1:0/unused", "")
+	manager.Queue = startQueue(t, QueueOptions{Workers: 1})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- manager.SendReloadAlert(context.Background(), &Message{Title: "test"})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("SendReloadAlert blocked on delivery")
+	}
+
+	// The webhook is unreachable, so the delivery stays pending while it is retried
+	if q := manager.Queue.Len(); q != 1 {
+		t.Errorf("expected 1 pending delivery, got %d", q)
+	}
+}
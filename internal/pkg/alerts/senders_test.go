@@ -0,0 +1,418 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// recordedRequest captures a request received by the HTTP stub
+type recordedRequest struct {
+	path   string
+	query  string
+	header http.Header
+	body   map[string]interface{}
+}
+
+// newHTTPStub starts an HTTP server that records requests and replies with the given status
+func newHTTPStub(t *testing.T, status int) (*httptest.Server, *[]recordedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := []recordedRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body := map[string]interface{}{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("request body is not valid JSON: %v", err)
+		}
+
+		mu.Lock()
+		requests = append(requests, recordedRequest{
+			path:   r.URL.EscapedPath(),
+			query:  r.URL.RawQuery,
+			header: r.Header.Clone(),
+			body:   body,
+		})
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func successMessage() *Message {
+	msg := NewReloadSuccessMessage("Deployment", "checkout", "shop", "Secret", "db-credentials", "env-vars")
+	msg.Timestamp = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return msg
+}
+
+func errorMessage() *Message {
+	msg := NewReloadErrorMessage("Deployment", "checkout", "shop", "Secret", "db-credentials", "env-vars", "update conflict")
+	msg.Timestamp = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return msg
+}
+
+func TestPagerDutySender(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusAccepted)
+	sender := NewPagerDutySender(server.URL, "routing-key")
+
+	if err := sender.Send(context.Background(), errorMessage()); err != nil {
+		t.Fatalf("unexpected error on trigger: %v", err)
+	}
+	if err := sender.Send(context.Background(), successMessage()); err != nil {
+		t.Fatalf("unexpected error on resolve: %v", err)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(*requests))
+	}
+
+	trigger := (*requests)[0].body
+	if trigger["event_action"] != "trigger" {
+		t.Errorf("expected trigger event, got %v", trigger["event_action"])
+	}
+	if trigger["routing_key"] != "routing-key" {
+		t.Errorf("unexpected routing key: %v", trigger["routing_key"])
+	}
+	payload, ok := trigger["payload"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("trigger event has no payload")
+	}
+	if payload["severity"] != "error" || payload["group"] != "shop" {
+		t.Errorf("unexpected trigger payload: %v", payload)
+	}
+
+	resolve := (*requests)[1].body
+	if resolve["event_action"] != "resolve" {
+		t.Errorf("expected resolve event, got %v", resolve["event_action"])
+	}
+	if resolve["dedup_key"] != trigger["dedup_key"] {
+		t.Errorf("resolve dedup key %v does not match trigger %v", resolve["dedup_key"], trigger["dedup_key"])
+	}
+}
+
+func TestOpsgenieSender(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusAccepted)
+	sender := NewOpsgenieSender(server.URL, "api-key")
+
+	if err := sender.Send(context.Background(), errorMessage()); err != nil {
+		t.Fatalf("unexpected error on create: %v", err)
+	}
+	if err := sender.Send(context.Background(), successMessage()); err != nil {
+		t.Fatalf("unexpected error on close: %v", err)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(*requests))
+	}
+
+	create := (*requests)[0]
+	if create.path != "/v2/alerts" {
+		t.Errorf("unexpected create path: %s", create.path)
+	}
+	if create.header.Get("Authorization") != "GenieKey api-key" {
+		t.Errorf("unexpected authorization header: %s", create.header.Get("Authorization"))
+	}
+	if create.body["description"] != "update conflict" {
+		t.Errorf("unexpected description: %v", create.body["description"])
+	}
+
+	closeReq := (*requests)[1]
+	if !strings.HasPrefix(closeReq.path, "/v2/alerts/") || !strings.HasSuffix(closeReq.path, "/close") {
+		t.Errorf("unexpected close path: %s", closeReq.path)
+	}
+	if closeReq.query != "identifierType=alias" {
+		t.Errorf("unexpected close query: %s", closeReq.query)
+	}
+}
+
+func TestOpsgenieSenderTruncatesOnCharacters(t *testing.T) {
+	sender := NewOpsgenieSender("", "api-key")
+	message := errorMessage()
+	message.Text = strings.Repeat("é", opsgenieMaxMessageLength)
+
+	summary, _ := sender.buildCreatePayload(message)["message"].(string)
+	if !utf8.ValidString(summary) {
+		t.Errorf("summary is not valid UTF-8: %q", summary)
+	}
+	if count := utf8.RuneCountInString(summary); count != opsgenieMaxMessageLength {
+		t.Errorf("expected %d characters, got %d", opsgenieMaxMessageLength, count)
+	}
+}
+
+func TestIncidentKeyOfDigests(t *testing.T) {
+	workloadKey := incidentKey(errorMessage())
+
+	digest := Summarize([]*Message{errorMessage(), successMessage()})
+	digestKey := incidentKey(digest)
+	if digestKey == workloadKey || !strings.HasPrefix(digestKey, "reloader-operator/digest") {
+		t.Errorf("digest has no dedicated incident key: %s", digestKey)
+	}
+	if next := incidentKey(Summarize([]*Message{successMessage()})); next != digestKey {
+		t.Errorf("digests of the same resource have different keys: %s and %s", digestKey, next)
+	}
+}
+
+func TestDiscordSender(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusNoContent)
+	sender := NewDiscordSender(server.URL)
+
+	if err := sender.Send(context.Background(), errorMessage()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	embeds, ok := (*requests)[0].body["embeds"].([]interface{})
+	if !ok || len(embeds) != 1 {
+		t.Fatalf("expected a single embed, got %v", (*requests)[0].body["embeds"])
+	}
+	embed := embeds[0].(map[string]interface{})
+	if embed["title"] != "❌ Reload Failed" {
+		t.Errorf("unexpected title: %v", embed["title"])
+	}
+	if embed["color"] != float64(0xff0000) {
+		t.Errorf("unexpected color: %v", embed["color"])
+	}
+}
+
+func TestDiscordSenderTruncatesToEmbedLimits(t *testing.T) {
+	sender := NewDiscordSender("")
+	message := errorMessage()
+	message.Text = strings.Repeat("d", 5000)
+	message.Error = strings.Repeat("é", 2000)
+	message.Fields = map[string]string{"Failed (300)": strings.Repeat("f", 3000), "Succeeded (300)": strings.Repeat("s", 3000)}
+
+	embed := sender.buildPayload(message)["embeds"].([]map[string]interface{})[0]
+	total := utf8.RuneCountInString(embed["title"].(string)) + utf8.RuneCountInString(discordFooter)
+	if description := embed["description"].(string); utf8.RuneCountInString(description) > discordMaxDescriptionLength {
+		t.Errorf("description has %d characters", utf8.RuneCountInString(description))
+	} else {
+		total += utf8.RuneCountInString(description)
+	}
+
+	for _, field := range embed["fields"].([]map[string]interface{}) {
+		value := field["value"].(string)
+		if !utf8.ValidString(value) || utf8.RuneCountInString(value) > discordMaxFieldValueLength {
+			t.Errorf("field %s has an invalid value of %d characters", field["name"], utf8.RuneCountInString(value))
+		}
+		total += utf8.RuneCountInString(field["name"].(string)) + utf8.RuneCountInString(value)
+	}
+	if total > discordMaxEmbedLength {
+		t.Errorf("embed has %d characters, want at most %d", total, discordMaxEmbedLength)
+	}
+}
+
+func TestMattermostSender(t *testing.T) {
+	server, requests := newHTTPStub(t, http.StatusOK)
+	sender := NewMattermostSender(server.URL)
+
+	if err := sender.Send(context.Background(), successMessage()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	attachments, ok := (*requests)[0].body["attachments"].([]interface{})
+	if !ok || len(attachments) != 1 {
+		t.Fatalf("expected a single attachment, got %v", (*requests)[0].body["attachments"])
+	}
+	attachment := attachments[0].(map[string]interface{})
+	if attachment["color"] != "#36a64f" {
+		t.Errorf("unexpected color: %v", attachment["color"])
+	}
+}
+
+func TestHTTPSenderReturnsErrorOnServerError(t *testing.T) {
+	server, _ := newHTTPStub(t, http.StatusServiceUnavailable)
+	sender := NewDiscordSender(server.URL)
+
+	if err := sender.Send(context.Background(), successMessage()); err == nil {
+		t.Error("expected an error for a 503 response")
+	}
+}
+
+// smtpStub is a minimal SMTP server that accepts a single message
+type smtpStub struct {
+	listener net.Listener
+	mu       sync.Mutex
+	from     string
+	rcpts    []string
+	data     string
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	stub := &smtpStub{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go stub.serve()
+	return stub
+}
+
+func (s *smtpStub) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP stub")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		upper := strings.ToUpper(cmd)
+
+		switch {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			s.mu.Lock()
+			s.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, strings.Trim(cmd[len("RCPT TO:"):], "<> "))
+			s.mu.Unlock()
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var body strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				body.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.data = body.String()
+			s.mu.Unlock()
+			reply("250 OK")
+		case upper == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestEmailSender(t *testing.T) {
+	stub := newSMTPStub(t)
+	sender := NewEmailSender(stub.listener.Addr().String(), "", "",
+		"reloader@example.com", []string{"oncall@example.com", "platform@example.com"})
+
+	if err := sender.Send(context.Background(), errorMessage()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	if stub.from != "reloader@example.com" {
+		t.Errorf("unexpected sender: %s", stub.from)
+	}
+	if len(stub.rcpts) != 2 {
+		t.Errorf("expected 2 recipients, got %v", stub.rcpts)
+	}
+	if !strings.Contains(stub.data, "Subject: =?utf-8?q?") {
+		t.Errorf("expected an encoded subject, got:\n%s", stub.data)
+	}
+	if !strings.Contains(stub.data, "Error: update conflict") {
+		t.Errorf("expected the error in the body, got:\n%s", stub.data)
+	}
+}
+
+func TestAlertManagerValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		manager *AlertManager
+		wantErr bool
+	}{
+		{
+			name:    "slack requires webhook URL",
+			manager: NewAlertManager(nil, true, SinkSlack, "", ""),
+			wantErr: true,
+		},
+		{
+			name:    "discord with webhook URL",
+			manager: NewAlertManager(nil, true, SinkDiscord, "https://discord.example.com/webhook", ""),
+			wantErr: false,
+		},
+		{
+			name:    "pagerduty requires routing key",
+			manager: NewAlertManager(nil, true, SinkPagerDuty, "", ""),
+			wantErr: true,
+		},
+		{
+			name: "pagerduty with routing key and no URL",
+			manager: &AlertManager{
+				AlertSink:  SinkPagerDuty,
+				SinkConfig: SinkConfig{PagerDutyRoutingKey: "key"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "email requires recipients",
+			manager: &AlertManager{
+				AlertSink:  SinkEmail,
+				SinkConfig: SinkConfig{SMTPAddress: "smtp:25", EmailFrom: "a@example.com"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sink",
+			manager: NewAlertManager(nil, true, "carrier-pigeon", "https://example.com", ""),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manager.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
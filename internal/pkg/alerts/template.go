@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultTemplateContentType is used when a webhook template does not set a content type
+const DefaultTemplateContentType = "application/json"
+
+// templateFuncs are the helper functions available inside payload templates
+var templateFuncs = template.FuncMap{
+	// json encodes a value as JSON, e.g. {{ json .Error }} yields a quoted, escaped string
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// WebhookTemplate is a user-defined payload for the generic webhook sink
+//
+// The payload is a Go text/template rendered with the alert Message as its
+// data, so fields are referenced as {{ .WorkloadName }}, {{ .Error }},
+// {{ index .Fields "Additional Info" }} and so on.
+type WebhookTemplate struct {
+	// ContentType is sent as the Content-Type header
+	ContentType string
+
+	// Headers are extra HTTP headers added to every request
+	Headers map[string]string
+
+	tmpl *template.Template
+}
+
+// NewWebhookTemplate parses and validates a payload template
+//
+// Business Logic:
+// Template mistakes must surface when the template is configured, not when the
+// first reload happens. Besides parsing, the template is rendered once against
+// a sample message, which catches references to unknown fields. When the
+// content type is JSON, the sample output must also be valid JSON.
+func NewWebhookTemplate(payload, contentType string, headers map[string]string) (*WebhookTemplate, error) {
+	if strings.TrimSpace(payload) == "" {
+		return nil, fmt.Errorf("webhook template payload is empty")
+	}
+
+	if contentType == "" {
+		contentType = DefaultTemplateContentType
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template content type %q: %w", contentType, err)
+	}
+
+	tmpl, err := template.New("webhook").Funcs(templateFuncs).Option("missingkey=zero").Parse(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template: %w", err)
+	}
+
+	t := &WebhookTemplate{
+		ContentType: contentType,
+		Headers:     headers,
+		tmpl:        tmpl,
+	}
+
+	sample, err := t.Render(sampleMessage())
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(mediaType, "json") && !json.Valid(sample) {
+		return nil, fmt.Errorf("webhook template does not render valid JSON for content type %s", contentType)
+	}
+
+	return t, nil
+}
+
+// Render executes the template against the message
+func (t *WebhookTemplate) Render(message *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, message); err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sampleMessage returns a fully populated message used to validate templates
+func sampleMessage() *Message {
+	msg := NewReloadErrorMessage("Deployment", "sample", "default", "Secret", "sample", "env-vars", "sample error")
+	msg.Timestamp = time.Now()
+	msg.Fields["Additional Info"] = "sample"
+	return msg
+}
+
+// TemplateWebhookSender posts alerts rendered from a user-defined template
+type TemplateWebhookSender struct {
+	webhookURL string
+	template   *WebhookTemplate
+	client     *http.Client
+}
+
+// NewTemplateWebhookSender creates a webhook sender that uses a payload template
+func NewTemplateWebhookSender(webhookURL string, tmpl *WebhookTemplate) *TemplateWebhookSender {
+	return &TemplateWebhookSender{
+		webhookURL: webhookURL,
+		template:   tmpl,
+		client:     newHTTPClient(),
+	}
+}
+
+// Name returns the sender name
+func (w *TemplateWebhookSender) Name() string {
+	return "Webhook"
+}
+
+// Send renders the template and posts it to the webhook URL
+func (w *TemplateWebhookSender) Send(ctx context.Context, message *Message) error {
+	body, err := w.template.Render(message)
+	if err != nil {
+		return err
+	}
+	return postBody(ctx, w.client, w.Name(), w.webhookURL, w.template.ContentType, w.template.Headers, body)
+}
+
+// ParseHeaders converts "Name=value" entries into a header map
+func ParseHeaders(entries []string) (map[string]string, error) {
+	headers := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name, value, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid header %q: expected Name=value", entry)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
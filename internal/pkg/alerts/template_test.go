@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewWebhookTemplateValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		contentType string
+		wantErr     bool
+	}{
+		{
+			name:    "valid JSON template",
+			payload: `{"workload": "{{ .WorkloadName }}", "error": {{ json .Error }}}`,
+		},
+		{
+			name:    "parse error",
+			payload: `{"workload": "{{ .WorkloadName }"}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			payload: `{"workload": "{{ .Workload }}"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON output",
+			payload: `{"workload": {{ .WorkloadName }}}`,
+			wantErr: true,
+		},
+		{
+			name:        "plain text is not checked as JSON",
+			payload:     `{{ .WorkloadKind }}/{{ .WorkloadName }} reloaded`,
+			contentType: "text/plain",
+		},
+		{
+			name:    "empty payload",
+			payload: "  ",
+			wantErr: true,
+		},
+		{
+			name:        "invalid content type",
+			payload:     `{}`,
+			contentType: "not a type;;",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebhookTemplate(tt.payload, tt.contentType, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWebhookTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplateWebhookSender(t *testing.T) {
+	var gotBody, gotContentType, gotToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		gotContentType = r.Header.Get("Content-Type")
+		gotToken = r.Header.Get("X-Api-Token")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tmpl, err := NewWebhookTemplate(
+		`{{ upper .WorkloadKind }} {{ .WorkloadNamespace }}/{{ .WorkloadName }}: {{ .Error }}`,
+		"text/plain; charset=utf-8",
+		map[string]string{"X-Api-Token": "secret"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected template error: %v", err)
+	}
+
+	sender := NewTemplateWebhookSender(server.URL, tmpl)
+	if err := sender.Send(context.Background(), errorMessage()); err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	if gotBody != "DEPLOYMENT shop/checkout: update conflict" {
+		t.Errorf("unexpected body: %q", gotBody)
+	}
+	if gotContentType != "text/plain; charset=utf-8" {
+		t.Errorf("unexpected content type: %q", gotContentType)
+	}
+	if gotToken != "secret" {
+		t.Errorf("unexpected header value: %q", gotToken)
+	}
+}
+
+func TestSendReloadAlertWithTemplateOverride(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	global, err := NewWebhookTemplate(`{"source": "global"}`, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected template error: %v", err)
+	}
+	override, err := NewWebhookTemplate(`{"source": "config", "name": {{ json .WorkloadName }}}`, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected template error: %v", err)
+	}
+
+	manager := NewAlertManager(nil, true, SinkWebhook, server.URL, "")
+	manager.WebhookTemplate = global
+
+	if err := manager.SendReloadAlert(context.Background(), successMessage()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotBody != `{"source": "global"}` {
+		t.Errorf("expected global template, got %q", gotBody)
+	}
+
+	if err := manager.SendReloadAlertWithTemplate(context.Background(), successMessage(), override); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotBody != `{"source": "config", "name": "checkout"}` {
+		t.Errorf("expected override template, got %q", gotBody)
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"Authorization=Bearer abc", "X-Team = platform"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if headers["Authorization"] != "Bearer abc" || headers["X-Team"] != "platform" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	if _, err := ParseHeaders([]string{"missing-separator"}); err == nil {
+		t.Error("expected an error for an entry without '='")
+	}
+}
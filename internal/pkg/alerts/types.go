@@ -21,6 +21,32 @@ import (
 	"time"
 )
 
+// Supported alert sink types
+const (
+	SinkSlack      = "slack"
+	SinkTeams      = "teams"
+	SinkGoogleChat = "gchat"
+	SinkWebhook    = "webhook"
+	SinkPagerDuty  = "pagerduty"
+	SinkOpsgenie   = "opsgenie"
+	SinkDiscord    = "discord"
+	SinkMattermost = "mattermost"
+	SinkEmail      = "email"
+)
+
+// SupportedSinks lists all alert sink types accepted by --alert-sink
+var SupportedSinks = []string{
+	SinkSlack, SinkTeams, SinkGoogleChat, SinkWebhook,
+	SinkPagerDuty, SinkOpsgenie, SinkDiscord, SinkMattermost, SinkEmail,
+}
+
+// Reload outcomes carried by a Message
+const (
+	StatusSucceeded = "Succeeded"
+	StatusFailed    = "Failed"
+	StatusSkipped   = "Skipped"
+)
+
 // Sender defines the interface for sending alerts
 type Sender interface {
 	// Send sends an alert message
@@ -41,6 +67,9 @@ type Message struct {
 	// Color represents the severity (e.g., "good", "warning", "danger")
 	Color string
 
+	// Status is the reload outcome (StatusSucceeded, StatusFailed or StatusSkipped)
+	Status string
+
 	// Fields contains additional structured information
 	Fields map[string]string
 
@@ -62,11 +91,51 @@ type Message struct {
 	// ResourceName is the name of the resource
 	ResourceName string
 
+	// ResourceNamespace is the namespace of the resource
+	ResourceNamespace string
+
+	// ResourceHash is the hash of the resource data that triggered the reload
+	ResourceHash string
+
+	// PreviousResourceHash is the hash of the resource data before the change, if known
+	PreviousResourceHash string
+
 	// ReloadStrategy is the strategy used (env-vars, annotations)
 	ReloadStrategy string
 
 	// Error contains error message if reload failed
 	Error string
+
+	// Digest is set on digest summaries (see Summarize), which have no workload
+	Digest bool
+
+	// webhookTemplate is the ReloaderConfig template the message was sent with, kept for its digest
+	webhookTemplate *WebhookTemplate
+
+	// resourceIncident keys the incident of a failure sent ahead of its digest like the digest's
+	resourceIncident bool
+}
+
+// SinkConfig holds settings for sinks that need more than a webhook URL
+type SinkConfig struct {
+	// PagerDutyRoutingKey is the Events API v2 integration (routing) key
+	PagerDutyRoutingKey string
+
+	// OpsgenieAPIKey is the API integration key used in the GenieKey header
+	OpsgenieAPIKey string
+
+	// SMTPAddress is the host:port of the SMTP server
+	SMTPAddress string
+
+	// SMTPUsername and SMTPPassword enable PLAIN authentication when set
+	SMTPUsername string
+	SMTPPassword string
+
+	// EmailFrom is the sender address
+	EmailFrom string
+
+	// EmailTo is the list of recipient addresses
+	EmailTo []string
 }
 
 // WebhookURL represents a webhook URL that can be fetched from various sources
@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudevents emits CloudEvents 1.0 over HTTP for the reload lifecycle.
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/stakater/Reloader/internal/pkg/alerts"
+)
+
+// SpecVersion is the CloudEvents specification version emitted
+const SpecVersion = "1.0"
+
+// Event types emitted by the operator
+const (
+	TypeResourceChanged = "com.stakater.reloader.resource.changed"
+	TypeReloadStarted   = "com.stakater.reloader.reload.started"
+	TypeReloadSucceeded = "com.stakater.reloader.reload.succeeded"
+	TypeReloadFailed    = "com.stakater.reloader.reload.failed"
+	TypeReloadSkipped   = "com.stakater.reloader.reload.skipped"
+)
+
+// HTTP content modes
+const (
+	// ModeStructured sends the whole event as a JSON envelope (application/cloudevents+json)
+	ModeStructured = "structured"
+
+	// ModeBinary sends the data as the body and the attributes as ce-* headers
+	ModeBinary = "binary"
+)
+
+// DefaultSource is the event source used when none is configured
+const DefaultSource = "/reloader-operator"
+
+// QueueName labels the emitter's delivery queue in the alert queue metrics
+const QueueName = "cloudevents"
+
+// Data is the event payload
+// It carries the same information as an alert message plus the resource hashes.
+type Data struct {
+	Title                string            `json:"title,omitempty"`
+	Text                 string            `json:"text,omitempty"`
+	WorkloadKind         string            `json:"workloadKind,omitempty"`
+	WorkloadName         string            `json:"workloadName,omitempty"`
+	WorkloadNamespace    string            `json:"workloadNamespace,omitempty"`
+	ResourceKind         string            `json:"resourceKind,omitempty"`
+	ResourceName         string            `json:"resourceName,omitempty"`
+	ResourceNamespace    string            `json:"resourceNamespace,omitempty"`
+	ResourceHash         string            `json:"resourceHash,omitempty"`
+	PreviousResourceHash string            `json:"previousResourceHash,omitempty"`
+	ReloadStrategy       string            `json:"reloadStrategy,omitempty"`
+	Error                string            `json:"error,omitempty"`
+	Fields               map[string]string `json:"fields,omitempty"`
+	Timestamp            time.Time         `json:"timestamp"`
+}
+
+// Event is a CloudEvents 1.0 event with a JSON data payload
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// Emitter sends CloudEvents to an HTTP sink (e.g., a Knative Broker or an Argo Events webhook)
+type Emitter struct {
+	sinkURL string
+	source  string
+	mode    string
+	client  *http.Client
+
+	// Queue delivers events asynchronously (with retries) when set; otherwise events are sent inline
+	Queue *alerts.Queue
+}
+
+// NewEmitter creates a CloudEvents emitter for the given sink
+func NewEmitter(sinkURL, source, mode string) (*Emitter, error) {
+	if _, err := url.ParseRequestURI(sinkURL); err != nil {
+		return nil, fmt.Errorf("invalid CloudEvents sink URL %q: %w", sinkURL, err)
+	}
+	if mode != ModeStructured && mode != ModeBinary {
+		return nil, fmt.Errorf("unknown CloudEvents mode: %s (supported: %s, %s)", mode, ModeStructured, ModeBinary)
+	}
+	if source == "" {
+		source = DefaultSource
+	}
+
+	return &Emitter{
+		sinkURL: sinkURL,
+		source:  source,
+		mode:    mode,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+// Emit sends an event of the given type built from the message
+//
+// Business Logic:
+// Events reuse the alert message so downstream consumers see exactly what the
+// alert sinks see. When a Queue is set the event is only enqueued, so reloads
+// never wait on the event sink; failed deliveries are retried by the queue.
+func (e *Emitter) Emit(ctx context.Context, eventType string, message *alerts.Message) error {
+	// The event (and its ID) is built once so retries are recognizable as duplicates
+	sender := &eventSender{emitter: e, event: e.NewEvent(eventType, message)}
+
+	if e.Queue != nil {
+		return e.Queue.Enqueue(sender, message)
+	}
+	return sender.Send(ctx, message)
+}
+
+// NewEvent builds a CloudEvent from an alert message
+func (e *Emitter) NewEvent(eventType string, message *alerts.Message) *Event {
+	timestamp := message.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return &Event{
+		SpecVersion:     SpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          e.source,
+		Type:            eventType,
+		Subject:         subject(message),
+		Time:            timestamp.UTC(),
+		DataContentType: "application/json",
+		Data: Data{
+			Title:                message.Title,
+			Text:                 message.Text,
+			WorkloadKind:         message.WorkloadKind,
+			WorkloadName:         message.WorkloadName,
+			WorkloadNamespace:    message.WorkloadNamespace,
+			ResourceKind:         message.ResourceKind,
+			ResourceName:         message.ResourceName,
+			ResourceNamespace:    message.ResourceNamespace,
+			ResourceHash:         message.ResourceHash,
+			PreviousResourceHash: message.PreviousResourceHash,
+			ReloadStrategy:       message.ReloadStrategy,
+			Error:                message.Error,
+			Fields:               message.Fields,
+			Timestamp:            timestamp,
+		},
+	}
+}
+
+// send posts the event using the configured content mode
+func (e *Emitter) send(ctx context.Context, event *Event) error {
+	var body []byte
+	var err error
+	headers := map[string]string{}
+
+	switch e.mode {
+	case ModeBinary:
+		body, err = json.Marshal(event.Data)
+		headers["Content-Type"] = event.DataContentType
+		headers["ce-specversion"] = event.SpecVersion
+		headers["ce-id"] = event.ID
+		headers["ce-source"] = event.Source
+		headers["ce-type"] = event.Type
+		headers["ce-time"] = event.Time.Format(time.RFC3339Nano)
+		if event.Subject != "" {
+			headers["ce-subject"] = event.Subject
+		}
+	default:
+		body, err = json.Marshal(event)
+		headers["Content-Type"] = "application/cloudevents+json"
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal CloudEvent: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.sinkURL, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send CloudEvent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("CloudEvents sink returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// subject identifies what the event is about: the workload for reload events,
+// or the changed resource for resource events
+func subject(message *alerts.Message) string {
+	if message.WorkloadName != "" {
+		return fmt.Sprintf("%s/%s/%s", message.WorkloadNamespace, message.WorkloadKind, message.WorkloadName)
+	}
+	return fmt.Sprintf("%s/%s/%s", message.ResourceNamespace, message.ResourceKind, message.ResourceName)
+}
+
+// eventSender adapts the emitter to alerts.Sender so events can use the alert delivery queue
+type eventSender struct {
+	emitter *Emitter
+	event   *Event
+}
+
+// Name returns the sender name used in queue logs and metrics
+func (s *eventSender) Name() string {
+	return "CloudEvents"
+}
+
+// Send posts the prepared event to the sink
+// The message is ignored: the event was already built from it in Emit.
+func (s *eventSender) Send(ctx context.Context, _ *alerts.Message) error {
+	return s.emitter.send(ctx, s.event)
+}
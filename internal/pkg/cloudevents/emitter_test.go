@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stakater/Reloader/internal/pkg/alerts"
+)
+
+// capturedRequest is a request received by the test sink
+type capturedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newSink(t *testing.T, statuses ...int) (*httptest.Server, func() []capturedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := []capturedRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, capturedRequest{header: r.Header.Clone(), body: body})
+		status := http.StatusAccepted
+		if len(statuses) >= len(requests) {
+			status = statuses[len(requests)-1]
+		}
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []capturedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]capturedRequest{}, requests...)
+	}
+}
+
+func testMessage() *alerts.Message {
+	msg := alerts.NewReloadSuccessMessage("Deployment", "checkout", "shop", "Secret", "db-credentials", "env-vars")
+	msg.Timestamp = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	msg.ResourceNamespace = "shop"
+	msg.ResourceHash = "new-hash"
+	msg.PreviousResourceHash = "old-hash"
+	return msg
+}
+
+func TestEmitStructuredMode(t *testing.T) {
+	server, requests := newSink(t)
+	emitter, err := NewEmitter(server.URL, "/test", ModeStructured)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := emitter.Emit(context.Background(), TypeReloadSucceeded, testMessage()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(got))
+	}
+	if ct := got[0].header.Get("Content-Type"); ct != "application/cloudevents+json" {
+		t.Errorf("unexpected content type: %s", ct)
+	}
+
+	event := Event{}
+	if err := json.Unmarshal(got[0].body, &event); err != nil {
+		t.Fatalf("body is not a valid event: %v", err)
+	}
+	if event.SpecVersion != SpecVersion || event.Type != TypeReloadSucceeded || event.Source != "/test" || event.ID == "" {
+		t.Errorf("unexpected event attributes: %+v", event)
+	}
+	if event.Subject != "shop/Deployment/checkout" {
+		t.Errorf("unexpected subject: %s", event.Subject)
+	}
+	if event.Data.ResourceHash != "new-hash" || event.Data.PreviousResourceHash != "old-hash" {
+		t.Errorf("hashes missing from data: %+v", event.Data)
+	}
+}
+
+func TestEmitBinaryMode(t *testing.T) {
+	server, requests := newSink(t)
+	emitter, err := NewEmitter(server.URL, "", ModeBinary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	message := testMessage()
+	message.WorkloadName = ""
+	if err := emitter.Emit(context.Background(), TypeResourceChanged, message); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := requests()[0]
+	if got.header.Get("ce-specversion") != SpecVersion ||
+		got.header.Get("ce-type") != TypeResourceChanged ||
+		got.header.Get("ce-source") != DefaultSource ||
+		got.header.Get("ce-id") == "" {
+		t.Errorf("missing CloudEvents headers: %v", got.header)
+	}
+	if got.header.Get("ce-subject") != "shop/Secret/db-credentials" {
+		t.Errorf("unexpected subject: %s", got.header.Get("ce-subject"))
+	}
+	if got.header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected content type: %s", got.header.Get("Content-Type"))
+	}
+
+	data := Data{}
+	if err := json.Unmarshal(got.body, &data); err != nil {
+		t.Fatalf("body is not valid data: %v", err)
+	}
+	if data.ResourceName != "db-credentials" {
+		t.Errorf("unexpected data: %+v", data)
+	}
+}
+
+func TestEmitRetriesKeepEventID(t *testing.T) {
+	server, requests := newSink(t, http.StatusServiceUnavailable, http.StatusAccepted)
+	emitter, err := NewEmitter(server.URL, "", ModeBinary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	emitter.Queue = alerts.NewQueue(alerts.QueueOptions{
+		BaseDelay: 10 * time.Millisecond,
+		MaxDelay:  10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = emitter.Queue.Start(ctx) }()
+
+	if err := emitter.Emit(ctx, TypeReloadStarted, testMessage()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(requests()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(got))
+	}
+	if got[0].header.Get("ce-id") != got[1].header.Get("ce-id") {
+		t.Errorf("retry changed event ID: %s != %s", got[0].header.Get("ce-id"), got[1].header.Get("ce-id"))
+	}
+}
+
+func TestNewEmitterValidation(t *testing.T) {
+	if _, err := NewEmitter("not a url", "", ModeStructured); err == nil {
+		t.Error("expected an error for an invalid sink URL")
+	}
+	if _, err := NewEmitter("http://broker.example.com", "", "batch"); err == nil {
+		t.Error("expected an error for an unsupported mode")
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding splits namespaces across operator replicas that coordinate through Leases.
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// LabelShardGroup marks the Leases of the replicas sharing namespaces
+	LabelShardGroup = "reloader.stakater.com/shard-group"
+
+	// DefaultGroup is the shard group used when none is configured
+	DefaultGroup = "reloader-operator"
+
+	// DefaultLeaseDuration is how long a replica stays a member without renewing its Lease
+	DefaultLeaseDuration = 15 * time.Second
+)
+
+// RebalanceFunc is called after the member set changed
+// previous is the member set before the change (empty on the first sync).
+type RebalanceFunc func(ctx context.Context, previous []string)
+
+// Coordinator tracks the live replicas of a shard group and the namespaces this replica owns
+//
+// Business Logic:
+// Each replica holds its own Lease named "<group>-<identity>" in Namespace and
+// renews it every LeaseDuration/3. The members are the replicas whose Lease
+// was renewed within its duration. Every namespace is owned by exactly one
+// member, chosen by rendezvous hashing (see Owner): all replicas compute the
+// same owner from the same member set without talking to each other, and a
+// replica joining or leaving only moves the namespaces it gains or loses.
+//
+// Until the first sync the replica owns nothing. On shutdown it deletes its
+// Lease so the others take over its namespaces without waiting for expiry.
+type Coordinator struct {
+	Client        client.Client
+	Namespace     string
+	Identity      string
+	Group         string
+	LeaseDuration time.Duration
+
+	// OnRebalance is called (from the coordinator goroutine) after the member set changed
+	OnRebalance RebalanceFunc
+
+	mu        sync.RWMutex
+	members   []string
+	lastRenew time.Time
+	now       func() time.Time
+}
+
+// NewCoordinator creates a Coordinator with the default group and lease duration
+func NewCoordinator(c client.Client, namespace, identity string) *Coordinator {
+	return &Coordinator{
+		Client:        c,
+		Namespace:     namespace,
+		Identity:      identity,
+		Group:         DefaultGroup,
+		LeaseDuration: DefaultLeaseDuration,
+		now:           time.Now,
+	}
+}
+
+// Validate checks that the coordinator can hold a Lease
+func (c *Coordinator) Validate() error {
+	if c.Namespace == "" {
+		return fmt.Errorf("sharding requires a lease namespace")
+	}
+	if c.Identity == "" {
+		return fmt.Errorf("sharding requires a replica identity")
+	}
+	// The group is a label value on every Lease of the group
+	if errs := validation.IsValidLabelValue(c.Group); c.Group == "" || len(errs) > 0 {
+		return fmt.Errorf("invalid shard group %q: %s", c.Group, strings.Join(errs, "; "))
+	}
+	if c.LeaseDuration < 3*time.Second {
+		return fmt.Errorf("shard lease duration must be at least 3s, got %s", c.LeaseDuration)
+	}
+	return nil
+}
+
+// Start renews the Lease and refreshes the member set until ctx is done
+// Every replica runs its own coordinator, so it does not need leader election.
+func (c *Coordinator) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("identity", c.Identity, "group", c.Group)
+	ticker := time.NewTicker(c.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		if err := c.sync(ctx); err != nil {
+			logger.Error(err, "Failed to sync shard membership")
+			c.expire(logger)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := c.release(); err != nil {
+				logger.Error(err, "Failed to release shard lease")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (c *Coordinator) NeedLeaderElection() bool {
+	return false
+}
+
+// Owns reports whether this replica owns the namespace
+func (c *Coordinator) Owns(namespace string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.members) > 0 && Owner(c.members, namespace) == c.Identity
+}
+
+// Members returns the current member set, sorted
+func (c *Coordinator) Members() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.members)
+}
+
+// expire drops all members once this replica's own Lease may have expired
+// The other replicas then consider it gone and own its namespaces.
+func (c *Coordinator) expire(logger logr.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.members) > 0 && c.now().Sub(c.lastRenew) >= c.LeaseDuration {
+		logger.Info("Shard lease not renewed within its duration, releasing all namespaces")
+		c.members = nil
+	}
+}
+
+// sync renews this replica's Lease and recomputes the member set
+func (c *Coordinator) sync(ctx context.Context) error {
+	renewedAt := c.now()
+	if err := c.renew(ctx); err != nil {
+		return err
+	}
+
+	leases := &coordinationv1.LeaseList{}
+	if err := c.Client.List(ctx, leases, client.InNamespace(c.Namespace), client.MatchingLabels{LabelShardGroup: c.Group}); err != nil {
+		return fmt.Errorf("failed to list shard leases: %w", err)
+	}
+
+	members := liveMembers(leases.Items, c.now())
+	if !slices.Contains(members, c.Identity) {
+		// Our own Lease was just renewed, even if the list lags behind
+		members = append(members, c.Identity)
+		slices.Sort(members)
+	}
+
+	c.mu.Lock()
+	previous := c.members
+	changed := !slices.Equal(previous, members)
+	c.members = members
+	c.lastRenew = renewedAt
+	c.mu.Unlock()
+
+	if changed {
+		log.FromContext(ctx).Info("Shard membership changed", "identity", c.Identity, "members", members)
+		if c.OnRebalance != nil {
+			c.OnRebalance(ctx, previous)
+		}
+	}
+	return nil
+}
+
+// renew creates or renews this replica's Lease
+func (c *Coordinator) renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(c.now())
+	identity := c.Identity
+	durationSeconds := int32(c.LeaseDuration / time.Second)
+	lease := &coordinationv1.Lease{}
+	err := c.Client.Get(ctx, client.ObjectKey{Namespace: c.Namespace, Name: c.leaseName()}, lease)
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.leaseName(),
+				Namespace: c.Namespace,
+				Labels:    map[string]string{LabelShardGroup: c.Group},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := c.Client.Create(ctx, lease); err != nil {
+			return fmt.Errorf("failed to create shard lease: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get shard lease: %w", err)
+	}
+
+	lease.Spec.HolderIdentity = &identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &now
+	if err := c.Client.Update(ctx, lease); err != nil {
+		return fmt.Errorf("failed to renew shard lease: %w", err)
+	}
+	return nil
+}
+
+// release deletes this replica's Lease so its namespaces move without waiting for expiry
+func (c *Coordinator) release() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: c.leaseName(), Namespace: c.Namespace}}
+	return client.IgnoreNotFound(c.Client.Delete(ctx, lease))
+}
+
+// leaseName returns the name of this replica's Lease
+func (c *Coordinator) leaseName() string {
+	return c.Group + "-" + c.Identity
+}
+
+// liveMembers returns the sorted holder identities of the Leases renewed within their duration
+func liveMembers(leases []coordinationv1.Lease, now time.Time) []string {
+	members := []string{}
+	for _, lease := range leases {
+		spec := lease.Spec
+		if spec.HolderIdentity == nil || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+		if now.Before(expiry) && !slices.Contains(members, *spec.HolderIdentity) {
+			members = append(members, *spec.HolderIdentity)
+		}
+	}
+	slices.Sort(members)
+	return members
+}
+
+// Owner returns the member owning a namespace, or "" without members
+//
+// Business Logic:
+// Rendezvous (highest random weight) hashing: each member scores the namespace
+// with hash(member, namespace) and the highest score wins. When a member
+// leaves, only its namespaces move (each to its runner-up); when one joins,
+// it only takes the namespaces it now scores highest on. Ties, which are
+// practically impossible, go to the lexically smallest member.
+func Owner(members []string, namespace string) string {
+	owner := ""
+	var best uint64
+	for _, member := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(member))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(namespace))
+		score := mix(h.Sum64())
+
+		if owner == "" || score > best || (score == best && member < owner) {
+			owner, best = member, score
+		}
+	}
+	return owner
+}
+
+// mix spreads FNV output across all 64 bits (splitmix64 finalizer)
+// FNV alone scores similar inputs too closely for an even split.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testNamespaces(count int) []string {
+	namespaces := make([]string, count)
+	for i := range namespaces {
+		namespaces[i] = fmt.Sprintf("team-%d", i)
+	}
+	return namespaces
+}
+
+func TestOwner_Deterministic(t *testing.T) {
+	members := []string{"reloader-a", "reloader-b", "reloader-c"}
+	shuffled := []string{"reloader-c", "reloader-a", "reloader-b"}
+
+	for _, ns := range testNamespaces(100) {
+		owner := Owner(members, ns)
+		if !slices.Contains(members, owner) {
+			t.Fatalf("Owner(%q) = %q, not a member", ns, owner)
+		}
+		if Owner(shuffled, ns) != owner {
+			t.Errorf("Owner(%q) depends on member order", ns)
+		}
+	}
+
+	if owner := Owner(nil, "default"); owner != "" {
+		t.Errorf("Owner(nil) = %q, want empty", owner)
+	}
+}
+
+func TestOwner_Balanced(t *testing.T) {
+	members := []string{"reloader-0", "reloader-1", "reloader-2", "reloader-3"}
+	namespaces := testNamespaces(4000)
+
+	counts := map[string]int{}
+	for _, ns := range namespaces {
+		counts[Owner(members, ns)]++
+	}
+
+	// Each member should own roughly a quarter (1000) of the namespaces
+	for _, member := range members {
+		if counts[member] < 850 || counts[member] > 1150 {
+			t.Errorf("member %s owns %d of %d namespaces, want about %d", member, counts[member], len(namespaces), len(namespaces)/len(members))
+		}
+	}
+}
+
+func TestOwner_MinimalMovement(t *testing.T) {
+	before := []string{"reloader-0", "reloader-1", "reloader-2"}
+	after := []string{"reloader-0", "reloader-1", "reloader-2", "reloader-3"}
+
+	for _, ns := range testNamespaces(1000) {
+		oldOwner, newOwner := Owner(before, ns), Owner(after, ns)
+		// Joining only takes namespaces for the new member
+		if oldOwner != newOwner && newOwner != "reloader-3" {
+			t.Errorf("namespace %s moved from %s to %s when reloader-3 joined", ns, oldOwner, newOwner)
+		}
+		// Leaving only moves the namespaces of the member that left
+		remaining := []string{"reloader-0", "reloader-2"}
+		if oldOwner != "reloader-1" && Owner(remaining, ns) != oldOwner {
+			t.Errorf("namespace %s moved from %s when reloader-1 left", ns, oldOwner)
+		}
+	}
+}
+
+func TestLiveMembers(t *testing.T) {
+	now := time.Now()
+	lease := func(holder string, renewedAgo time.Duration) coordinationv1.Lease {
+		renew := metav1.NewMicroTime(now.Add(-renewedAgo))
+		duration := int32(15)
+		return coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renew,
+		}}
+	}
+
+	leases := []coordinationv1.Lease{
+		lease("reloader-b", 5*time.Second),
+		lease("reloader-a", time.Second),
+		lease("reloader-expired", 20*time.Second),
+		{}, // never renewed
+	}
+
+	members := liveMembers(leases, now)
+	if want := []string{"reloader-a", "reloader-b"}; !slices.Equal(members, want) {
+		t.Errorf("liveMembers() = %v, want %v", members, want)
+	}
+}
+
+func newTestCoordinator(c client.Client, identity string, now time.Time) *Coordinator {
+	coordinator := NewCoordinator(c, "reloader-system", identity)
+	coordinator.now = func() time.Time { return now }
+	return coordinator
+}
+
+func TestCoordinator_SyncAndRebalance(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+	now := time.Now()
+
+	a := newTestCoordinator(c, "reloader-a", now)
+	if a.Owns("default") {
+		t.Fatal("a replica must own nothing before its first sync")
+	}
+
+	var rebalances [][]string
+	a.OnRebalance = func(_ context.Context, previous []string) {
+		rebalances = append(rebalances, previous)
+	}
+
+	if err := a.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	for _, ns := range testNamespaces(20) {
+		if !a.Owns(ns) {
+			t.Fatalf("a single replica must own every namespace, not %s", ns)
+		}
+	}
+
+	// A second replica joins and both agree on the split
+	b := newTestCoordinator(c, "reloader-b", now)
+	if err := b.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	if err := a.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	if want := []string{"reloader-a", "reloader-b"}; !slices.Equal(a.Members(), want) || !slices.Equal(b.Members(), want) {
+		t.Fatalf("members = %v / %v, want %v", a.Members(), b.Members(), want)
+	}
+	for _, ns := range testNamespaces(20) {
+		if a.Owns(ns) == b.Owns(ns) {
+			t.Errorf("namespace %s must be owned by exactly one replica", ns)
+		}
+	}
+
+	if len(rebalances) != 2 || len(rebalances[0]) != 0 || !slices.Equal(rebalances[1], []string{"reloader-a"}) {
+		t.Errorf("rebalances = %v, want [[] [reloader-a]]", rebalances)
+	}
+
+	// Syncing without membership changes does not rebalance
+	if err := a.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	if len(rebalances) != 2 {
+		t.Errorf("rebalanced without a membership change")
+	}
+
+	// b leaves: a owns everything again
+	if err := b.release(); err != nil {
+		t.Fatalf("release() error = %v", err)
+	}
+	if err := a.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	if !slices.Equal(a.Members(), []string{"reloader-a"}) {
+		t.Errorf("members after release = %v, want [reloader-a]", a.Members())
+	}
+}
+
+func TestCoordinator_ExpiredLeaseIsNotAMember(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+	now := time.Now()
+
+	// b synced once and then crashed
+	if err := newTestCoordinator(c, "reloader-b", now.Add(-time.Minute)).sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+
+	a := newTestCoordinator(c, "reloader-a", now)
+	if err := a.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	if !slices.Equal(a.Members(), []string{"reloader-a"}) {
+		t.Errorf("members = %v, want [reloader-a]", a.Members())
+	}
+}
+
+func TestCoordinator_ExpireAfterFailedRenewals(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	now := time.Now()
+
+	a := newTestCoordinator(c, "reloader-a", now)
+	if err := a.sync(context.Background()); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+
+	// Still within the lease duration: keep ownership
+	a.now = func() time.Time { return now.Add(5 * time.Second) }
+	a.expire(logr.Discard())
+	if !a.Owns("default") {
+		t.Error("ownership must survive a failed renewal within the lease duration")
+	}
+
+	// Beyond it, the other replicas have taken over
+	a.now = func() time.Time { return now.Add(DefaultLeaseDuration) }
+	a.expire(logr.Discard())
+	if a.Owns("default") {
+		t.Error("ownership must be released once the lease may have expired")
+	}
+}
+
+func TestCoordinator_Validate(t *testing.T) {
+	if err := NewCoordinator(nil, "reloader-system", "reloader-a").Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := NewCoordinator(nil, "", "reloader-a").Validate(); err == nil {
+		t.Error("Validate() must reject a missing lease namespace")
+	}
+	if err := NewCoordinator(nil, "reloader-system", "").Validate(); err == nil {
+		t.Error("Validate() must reject a missing identity")
+	}
+	long := NewCoordinator(nil, "reloader-system", "reloader-a")
+	long.Group = DefaultGroup + "-" + strings.Repeat("a", 53)
+	if err := long.Validate(); err == nil {
+		t.Error("Validate() must reject a group that is not a valid label value")
+	}
+	short := NewCoordinator(nil, "reloader-system", "reloader-a")
+	short.LeaseDuration = time.Second
+	if err := short.Validate(); err == nil {
+		t.Error("Validate() must reject a lease duration below 3s")
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultAnnotationPrefix is the domain of every annotation key (default of --annotation-prefix)
+const DefaultAnnotationPrefix = "reloader.stakater.com"
+
+// maxClassNameLength keeps the class-specific last-hash key a valid annotation name
+const maxClassNameLength = validation.LabelValueMaxLength - len("last-hash-")
+
+// annotationKeys lists every key moved by ConfigureAnnotationKeys
+var annotationKeys = []*string{
+	&AnnotationLastHash,
+	&AnnotationAuto,
+	&AnnotationSearch,
+	&AnnotationMatch,
+	&AnnotationIgnore,
+	&AnnotationRolloutStrategy,
+	&AnnotationLastReload,
+	&AnnotationLastReloadedFrom,
+	&AnnotationReloadOnCreate,
+	&AnnotationReloadOnDelete,
+	&AnnotationClass,
+	&AnnotationReloadRequested,
+	&AnnotationReloadRequestHandled,
+	&AnnotationNotifyPort,
+	&AnnotationNotifyPath,
+	&AnnotationNotifyDelay,
+	&AnnotationReloadPending,
+	&AnnotationVolumeRefresh,
+	&AnnotationSecretReload,
+	&AnnotationSecretAuto,
+	&AnnotationConfigMapReload,
+	&AnnotationConfigMapAuto,
+	&AnnotationDeploymentPausePeriod,
+	&AnnotationStatefulSetPausePeriod,
+	&AnnotationDaemonSetPausePeriod,
+	&AnnotationVersionedCopies,
+	&AnnotationCopyOf,
+	&LabelVersionedCopy,
+	&AnnotationDataHash,
+	&AnnotationKeyDigests,
+	&AnnotationCacheToken,
+}
+
+// defaultAnnotationKeys holds the keys under DefaultAnnotationPrefix, so the keys can be configured again
+var defaultAnnotationKeys = func() []string {
+	keys := make([]string, len(annotationKeys))
+	for i, key := range annotationKeys {
+		keys[i] = *key
+	}
+	return keys
+}()
+
+// ConfigureAnnotationKeys moves the annotation keys to another domain and assigns them to a class
+//
+// Business Logic:
+// Every key lives under DefaultAnnotationPrefix, including the type-specific
+// ones (secret.reloader.stakater.com/reload). Moving them to another prefix
+// (e.g., reloader.example.com) lets this operator run next to upstream
+// Stakater Reloader, which keeps reading the default keys.
+//
+// Instances that share a prefix are told apart by their class instead (see
+// InClass). A shared Secret or ConfigMap is processed by each of them, so the
+// last known hash is stored per class (last-hash-<class>): with a single key,
+// the first instance to store the new hash would hide the change from the
+// others.
+//
+// It must be called once at startup, before any key is read.
+func ConfigureAnnotationKeys(prefix, className string) error {
+	if errs := validation.IsDNS1123Subdomain(prefix); len(errs) > 0 {
+		return fmt.Errorf("invalid annotation prefix %q: %s", prefix, strings.Join(errs, "; "))
+	}
+	if err := ValidateClassName(className); err != nil {
+		return err
+	}
+
+	keys := make([]string, len(defaultAnnotationKeys))
+	for i, key := range defaultAnnotationKeys {
+		keys[i] = strings.Replace(key, DefaultAnnotationPrefix, prefix, 1)
+		if *annotationKeys[i] == AnnotationLastHash && className != "" {
+			keys[i] += "-" + className
+		}
+		// The type-specific keys add a subdomain, which can exceed the length limit
+		if errs := validation.IsQualifiedName(keys[i]); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation prefix %q: key %q: %s", prefix, keys[i], strings.Join(errs, "; "))
+		}
+	}
+
+	for i, key := range keys {
+		*annotationKeys[i] = key
+	}
+	return nil
+}
+
+// ValidateClassName checks that a class name can be used in annotation values and keys
+// The empty class is valid: it is the class of the objects that do not name one.
+func ValidateClassName(className string) error {
+	if className == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(className); len(errs) > 0 {
+		return fmt.Errorf("invalid class name %q: %s", className, strings.Join(errs, "; "))
+	}
+	if len(className) > maxClassNameLength {
+		return fmt.Errorf("invalid class name %q: must be no more than %d characters", className, maxClassNameLength)
+	}
+	return nil
+}
+
+// InClass checks if a workload belongs to the given class
+//
+// Business Logic:
+// A workload names its class with the AnnotationClass annotation, like
+// ingressClassName. A workload without it belongs to the instance without a
+// class, so an existing setup keeps working when a classed instance is added.
+func InClass(annotations map[string]string, className string) bool {
+	return annotations[AnnotationClass] == className
+}
+
+// ResourceInClass checks if a Secret or ConfigMap is processed by the given class
+//
+// Business Logic:
+// Unlike workloads, a resource without a class is shared: every instance
+// processes it for its own workloads and ReloaderConfigs, since one Secret is
+// often consumed by workloads of different classes. A resource that names a
+// class is only processed by that class.
+func ResourceInClass(annotations map[string]string, className string) bool {
+	resourceClass := annotations[AnnotationClass]
+	return resourceClass == "" || resourceClass == className
+}
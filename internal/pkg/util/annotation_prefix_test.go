@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestConfigureAnnotationKeys(t *testing.T) {
+	defer func() {
+		if err := ConfigureAnnotationKeys(DefaultAnnotationPrefix, ""); err != nil {
+			t.Fatalf("restoring the default keys failed: %v", err)
+		}
+	}()
+
+	if err := ConfigureAnnotationKeys("reloader.example.com", "blue"); err != nil {
+		t.Fatalf("ConfigureAnnotationKeys() error = %v", err)
+	}
+	expected := []struct{ key, want string }{
+		{AnnotationAuto, "reloader.example.com/auto"},
+		{AnnotationSecretReload, "secret.reloader.example.com/reload"},
+		{AnnotationDeploymentPausePeriod, "deployment.reloader.example.com/pause-period"},
+		{AnnotationClass, "reloader.example.com/class"},
+		{LabelVersionedCopy, "reloader.example.com/versioned-copy"},
+		{AnnotationLastHash, "reloader.example.com/last-hash-blue"},
+	}
+	for _, tt := range expected {
+		if tt.key != tt.want {
+			t.Errorf("key = %q, want %q", tt.key, tt.want)
+		}
+	}
+
+	// Configuring again starts from the default keys
+	if err := ConfigureAnnotationKeys("reloader.example.org", ""); err != nil {
+		t.Fatalf("ConfigureAnnotationKeys() error = %v", err)
+	}
+	if AnnotationLastHash != "reloader.example.org/last-hash" || AnnotationConfigMapAuto != "configmap.reloader.example.org/auto" {
+		t.Errorf("keys = %q, %q", AnnotationLastHash, AnnotationConfigMapAuto)
+	}
+
+	// Invalid settings leave the keys untouched
+	invalid := []struct{ prefix, className string }{
+		{"Reloader_Example", ""},
+		{strings.Repeat("a", 250) + ".com", ""},
+		{DefaultAnnotationPrefix, "Blue"},
+		{DefaultAnnotationPrefix, strings.Repeat("a", 54)},
+	}
+	for _, tt := range invalid {
+		if err := ConfigureAnnotationKeys(tt.prefix, tt.className); err == nil {
+			t.Errorf("ConfigureAnnotationKeys(%q, %q) must fail", tt.prefix, tt.className)
+		}
+	}
+	if AnnotationLastHash != "reloader.example.org/last-hash" {
+		t.Errorf("AnnotationLastHash = %q after invalid settings", AnnotationLastHash)
+	}
+}
+
+func TestAnnotationKeysCoverEveryKey(t *testing.T) {
+	// Every key declared in this package under the default prefix must move with --annotation-prefix
+	packages, err := parser.ParseDir(token.NewFileSet(), ".", func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parsing the package failed: %v", err)
+	}
+
+	for _, pkg := range packages {
+		ast.Inspect(pkg, func(node ast.Node) bool {
+			decl, ok := node.(*ast.GenDecl)
+			if !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+				return true
+			}
+			for _, spec := range decl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for i, value := range valueSpec.Values {
+					literal, ok := value.(*ast.BasicLit)
+					if !ok || literal.Kind != token.STRING {
+						continue
+					}
+					key, _ := strconv.Unquote(literal.Value)
+					if !strings.Contains(key, DefaultAnnotationPrefix+"/") {
+						continue
+					}
+					if decl.Tok == token.CONST || !slices.Contains(defaultAnnotationKeys, key) {
+						t.Errorf("%s (%q) is not moved by ConfigureAnnotationKeys", valueSpec.Names[i].Name, key)
+					}
+				}
+			}
+			return false
+		})
+	}
+}
+
+func TestInClass(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		className        string
+		expectedWorkload bool
+		expectedResource bool
+	}{
+		{name: "no class, classless instance", expectedWorkload: true, expectedResource: true},
+		{name: "no class, classed instance", className: "blue", expectedWorkload: false, expectedResource: true},
+		{name: "same class", annotations: map[string]string{AnnotationClass: "blue"}, className: "blue", expectedWorkload: true, expectedResource: true},
+		{name: "other class", annotations: map[string]string{AnnotationClass: "green"}, className: "blue", expectedWorkload: false, expectedResource: false},
+		{name: "class, classless instance", annotations: map[string]string{AnnotationClass: "blue"}, expectedWorkload: false, expectedResource: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := InClass(tt.annotations, tt.className); result != tt.expectedWorkload {
+				t.Errorf("InClass() = %v, want %v", result, tt.expectedWorkload)
+			}
+			if result := ResourceInClass(tt.annotations, tt.className); result != tt.expectedResource {
+				t.Errorf("ResourceInClass() = %v, want %v", result, tt.expectedResource)
+			}
+		})
+	}
+}
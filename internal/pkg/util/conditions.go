@@ -41,10 +41,30 @@ const (
 	ReasonResourceNotFound = "ResourceNotFound"
 	ReasonTargetNotFound   = "TargetNotFound"
 	ReasonInvalidSpec      = "InvalidSpec"
+	ReasonForbidden        = "Forbidden"
 	ReasonReloadFailed     = "ReloadFailed"
 	ReasonReloadSucceeded  = "ReloadSucceeded"
 )
 
+// Watched resource states (status.watchedResources[].state)
+const (
+	WatchedResourcePresent = "Present"
+	WatchedResourceMissing = "Missing"
+	WatchedResourceIgnored = "Ignored" // Listed in spec.ignoreResources or has the ignore annotation
+)
+
+// Reload outcomes (status.reloadHistory[].outcome and status.reloadHistory[].targets[].outcome)
+const (
+	ReloadOutcomeSucceeded          = "Succeeded"
+	ReloadOutcomeFailed             = "Failed"
+	ReloadOutcomeSkipped            = "Skipped"
+	ReloadOutcomePartiallySucceeded = "PartiallySucceeded" // Only for a whole change
+
+	// ReloadOutcomeScheduled is a reload left to be done later: a notify reload waiting for its notify delay,
+	// or a reload handed over to the replica owning the workload's namespace (see AnnotationReloadPending)
+	ReloadOutcomeScheduled = "Scheduled"
+)
+
 // SetCondition updates or adds a condition to the conditions list
 func SetCondition(conditions *[]metav1.Condition, conditionType string, status metav1.ConditionStatus, reason, message string) {
 	now := metav1.NewTime(time.Now())
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Cache annotation keys (variables, so ConfigureAnnotationKeys can move them to another prefix)
+var (
+	// AnnotationDataHash carries the data hash of a cached Secret/ConfigMap whose payload was dropped.
+	// It only exists on objects in the operator's cache (see TransformResourceData), never in the cluster.
+	AnnotationDataHash = "reloader.stakater.com/cached-data-hash"
+
+	// AnnotationKeyDigests carries the per-key SHA256 digests (JSON object) of such an object
+	AnnotationKeyDigests = "reloader.stakater.com/cached-key-digests"
+
+	// AnnotationCacheToken marks an object as transformed by this process (see cachedDigest)
+	AnnotationCacheToken = "reloader.stakater.com/cached-token"
+)
+
+// cacheToken is the value of AnnotationCacheToken, random per process so it cannot be set in the cluster
+var cacheToken = rand.Text()
+
+// annotationLastAppliedConfiguration holds a full copy of objects applied with kubectl
+const annotationLastAppliedConfiguration = "kubectl.kubernetes.io/last-applied-configuration"
+
+// TransformResourceData is a cache transform that replaces the payload of Secrets and
+// ConfigMaps with its digests
+//
+// Business Logic:
+// Reloader only needs the data of a Secret/ConfigMap to detect changes, yet the
+// cache would otherwise hold every Secret and ConfigMap in the cluster in full,
+// including Helm release Secrets and large CA bundles. The transform runs once
+// per object as it enters the cache: it computes the data hash (identical to
+// GetResourceDataAndHash on the full object) and the per-key digests, stores
+// them in annotations, and drops the data, the managed fields and the kubectl
+// last-applied copy.
+//
+// Cached objects must therefore never be written back with Update; patches
+// computed against the cached object (client.MergeFrom) leave the payload alone.
+func TransformResourceData(obj interface{}) (interface{}, error) {
+	var data map[string][]byte
+	var object metav1.Object
+
+	switch resource := obj.(type) {
+	case *corev1.Secret:
+		data = resource.Data
+		resource.Data = nil
+		resource.StringData = nil
+		object = resource
+	case *corev1.ConfigMap:
+		data = MergeDataMaps(resource.Data, resource.BinaryData)
+		resource.Data = nil
+		resource.BinaryData = nil
+		object = resource
+	default:
+		// Delete tombstones and other types pass through unchanged
+		return obj, nil
+	}
+
+	digests, err := json.Marshal(CalculateKeyDigests(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key digests: %w", err)
+	}
+
+	annotations := make(map[string]string, len(object.GetAnnotations())+2)
+	for key, value := range object.GetAnnotations() {
+		if key != annotationLastAppliedConfiguration {
+			annotations[key] = value
+		}
+	}
+	annotations[AnnotationDataHash] = CalculateHash(data)
+	annotations[AnnotationKeyDigests] = string(digests)
+	annotations[AnnotationCacheToken] = cacheToken
+	object.SetAnnotations(annotations)
+	object.SetManagedFields(nil)
+
+	return obj, nil
+}
+
+// CalculateKeyDigests computes the SHA256 digest of each value in a data map
+func CalculateKeyDigests(data map[string][]byte) map[string]string {
+	digests := make(map[string]string, len(data))
+	for key, value := range data {
+		sum := sha256.Sum256(value)
+		digests[key] = hex.EncodeToString(sum[:])
+	}
+	return digests
+}
+
+// GetResourceKeyDigests returns the per-key digests of a Secret or ConfigMap
+// It works on both full objects and objects whose payload was dropped by TransformResourceData.
+func GetResourceKeyDigests(obj interface{}) (map[string]string, error) {
+	switch resource := obj.(type) {
+	case *corev1.Secret:
+		if encoded, ok := cachedDigest(resource.Annotations, AnnotationKeyDigests); ok {
+			return decodeKeyDigests(encoded)
+		}
+		return CalculateKeyDigests(resource.Data), nil
+	case *corev1.ConfigMap:
+		if encoded, ok := cachedDigest(resource.Annotations, AnnotationKeyDigests); ok {
+			return decodeKeyDigests(encoded)
+		}
+		return CalculateKeyDigests(MergeDataMaps(resource.Data, resource.BinaryData)), nil
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %T", obj)
+	}
+}
+
+// cachedDigest returns a digest annotation stored by TransformResourceData
+// The digest annotations are only trusted next to this process's cache token:
+// on an object read around the cache (e.g., with an API reader) they are set
+// by whoever wrote the object, and the digests are computed from its data.
+func cachedDigest(annotations map[string]string, key string) (string, bool) {
+	if annotations[AnnotationCacheToken] != cacheToken {
+		return "", false
+	}
+	value, ok := annotations[key]
+	return value, ok
+}
+
+// decodeKeyDigests decodes the AnnotationKeyDigests annotation
+func decodeKeyDigests(encoded string) (map[string]string, error) {
+	digests := map[string]string{}
+	if err := json.Unmarshal([]byte(encoded), &digests); err != nil {
+		return nil, fmt.Errorf("invalid key digests annotation: %w", err)
+	}
+	return digests, nil
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestTransformResourceData_Secret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationMatch:                    "true",
+				annotationLastAppliedConfiguration: `{"data":{"password":"c2VjcmV0"}}`,
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("secret"),
+		},
+	}
+	expectedHash, _ := GetResourceDataAndHash(secret)
+	expectedDigests, _ := GetResourceKeyDigests(secret)
+
+	transformed, err := TransformResourceData(secret.DeepCopy())
+	if err != nil {
+		t.Fatalf("TransformResourceData() error = %v", err)
+	}
+	stripped := transformed.(*corev1.Secret)
+
+	if stripped.Data != nil || stripped.ManagedFields != nil {
+		t.Errorf("payload not dropped: data=%v managedFields=%v", stripped.Data, stripped.ManagedFields)
+	}
+	if _, ok := stripped.Annotations[annotationLastAppliedConfiguration]; ok {
+		t.Error("last-applied-configuration annotation not dropped")
+	}
+	if stripped.Annotations[AnnotationMatch] != "true" {
+		t.Error("other annotations must be kept")
+	}
+
+	if hash, _ := GetResourceDataAndHash(stripped); hash != expectedHash {
+		t.Errorf("GetResourceDataAndHash(stripped) = %q, want %q", hash, expectedHash)
+	}
+	digests, err := GetResourceKeyDigests(stripped)
+	if err != nil {
+		t.Fatalf("GetResourceKeyDigests() error = %v", err)
+	}
+	if !maps.Equal(digests, expectedDigests) {
+		t.Errorf("GetResourceKeyDigests(stripped) = %v, want %v", digests, expectedDigests)
+	}
+}
+
+func TestTransformResourceData_ConfigMap(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Data:       map[string]string{"level": "info"},
+		BinaryData: map[string][]byte{"ca.crt": {0x30, 0x82}},
+	}
+	expectedHash, _ := GetResourceDataAndHash(configMap)
+
+	transformed, err := TransformResourceData(configMap.DeepCopy())
+	if err != nil {
+		t.Fatalf("TransformResourceData() error = %v", err)
+	}
+	stripped := transformed.(*corev1.ConfigMap)
+
+	if stripped.Data != nil || stripped.BinaryData != nil {
+		t.Errorf("payload not dropped: data=%v binaryData=%v", stripped.Data, stripped.BinaryData)
+	}
+	if hash, _ := GetResourceDataAndHash(stripped); hash != expectedHash {
+		t.Errorf("GetResourceDataAndHash(stripped) = %q, want %q", hash, expectedHash)
+	}
+}
+
+func TestTransformResourceData_EmptyData(t *testing.T) {
+	transformed, err := TransformResourceData(&corev1.Secret{})
+	if err != nil {
+		t.Fatalf("TransformResourceData() error = %v", err)
+	}
+
+	// An empty hash must still be recognized as computed on ingest
+	stripped := transformed.(*corev1.Secret)
+	if hash, ok := stripped.Annotations[AnnotationDataHash]; !ok || hash != "" {
+		t.Errorf("data hash annotation = %q (present %v), want empty and present", hash, ok)
+	}
+}
+
+func TestGetResourceDataAndHash_IgnoresForgedDigests(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationDataHash:   "forged",
+				AnnotationKeyDigests: `{"password":"forged"}`,
+				AnnotationCacheToken: "guessed",
+			},
+		},
+		Data: map[string][]byte{"password": []byte("secret")},
+	}
+
+	// Read around the cache: the annotations were set in the cluster
+	if hash, _ := GetResourceDataAndHash(secret); hash != CalculateHash(secret.Data) {
+		t.Errorf("GetResourceDataAndHash() = %q, want the hash of the data", hash)
+	}
+	digests, err := GetResourceKeyDigests(secret)
+	if err != nil {
+		t.Fatalf("GetResourceKeyDigests() error = %v", err)
+	}
+	if !maps.Equal(digests, CalculateKeyDigests(secret.Data)) {
+		t.Errorf("GetResourceKeyDigests() = %v, want the digests of the data", digests)
+	}
+
+	// Through the cache: the transform replaces them with the digests of the data
+	transformed, err := TransformResourceData(secret.DeepCopy())
+	if err != nil {
+		t.Fatalf("TransformResourceData() error = %v", err)
+	}
+	if hash, _ := GetResourceDataAndHash(transformed); hash != CalculateHash(secret.Data) {
+		t.Errorf("GetResourceDataAndHash(transformed) = %q, want the hash of the data", hash)
+	}
+}
+
+func TestTransformResourceData_PassesThroughOtherTypes(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+
+	transformed, err := TransformResourceData(pod)
+	if err != nil {
+		t.Fatalf("TransformResourceData() error = %v", err)
+	}
+	if transformed != pod || pod.Annotations != nil {
+		t.Error("objects other than Secrets and ConfigMaps must pass through unchanged")
+	}
+}
+
+// benchmarkCachedSecrets reports the heap retained by a cache of Helm-release-sized Secrets
+func benchmarkCachedSecrets(b *testing.B, transform bool) {
+	const count = 500
+	payload := bytes.Repeat([]byte("x"), 64*1024)
+
+	for b.Loop() {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		cached := make([]interface{}, 0, count)
+		for i := range count {
+			var obj interface{} = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("sh.helm.release.v1.app.v%d", i), Namespace: "default"},
+				Data:       map[string][]byte{"release": bytes.Clone(payload)},
+			}
+			if transform {
+				obj, _ = TransformResourceData(obj)
+			}
+			cached = append(cached, obj)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/count, "retained-B/secret")
+		runtime.KeepAlive(cached)
+	}
+}
+
+func BenchmarkCachedSecrets_Full(b *testing.B) {
+	benchmarkCachedSecrets(b, false)
+}
+
+func BenchmarkCachedSecrets_Transformed(b *testing.B) {
+	benchmarkCachedSecrets(b, true)
+}
+
+// Load test: an informer caching thousands of Secrets, with and without the transform
+const (
+	rssLoadSecrets     = 3000
+	rssLoadPayloadSize = 16 * 1024
+	rssHelperEnv       = "RELOADER_RSS_LOAD_HELPER"
+)
+
+// TestTransformResourceData_LowersCacheRSS compares the resident memory of two processes that
+// each sync a Secret informer (the machinery behind the manager cache) over the same Secrets
+func TestTransformResourceData_LowersCacheRSS(t *testing.T) {
+	if mode := os.Getenv(rssHelperEnv); mode != "" {
+		runRSSLoadHelper(t, mode == "transformed")
+		return
+	}
+	if testing.Short() {
+		t.Skip("load test skipped in short mode")
+	}
+	if _, err := readRSS(); err != nil {
+		t.Skipf("process RSS is not available: %v", err)
+	}
+
+	full := measureCacheRSS(t, "full")
+	transformed := measureCacheRSS(t, "transformed")
+	payload := int64(rssLoadSecrets * rssLoadPayloadSize)
+	t.Logf("RSS with %d Secrets of %d KiB: full %d MiB, transformed %d MiB",
+		rssLoadSecrets, rssLoadPayloadSize/1024, full>>20, transformed>>20)
+
+	// The transformed cache must not hold the payload: at least half of it has to be saved
+	if full-transformed < payload/2 {
+		t.Errorf("RSS dropped by %d MiB, want at least %d MiB", (full-transformed)>>20, (payload/2)>>20)
+	}
+}
+
+// measureCacheRSS runs the load test helper in a fresh process and returns its RSS in bytes
+func measureCacheRSS(t *testing.T, mode string) int64 {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestTransformResourceData_LowersCacheRSS$", "-test.v")
+	cmd.Env = append(os.Environ(), rssHelperEnv+"="+mode)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s helper failed: %v\n%s", mode, err, output)
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "rss="); found {
+			rss, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				t.Fatalf("invalid %s helper output %q: %v", mode, line, err)
+			}
+			return rss
+		}
+	}
+	t.Fatalf("%s helper reported no RSS:\n%s", mode, output)
+	return 0
+}
+
+// runRSSLoadHelper syncs a Secret informer and prints the process RSS once the cache is filled
+func runRSSLoadHelper(t *testing.T, transform bool) {
+	lw := &cache.ListWatch{
+		ListFunc: func(metav1.ListOptions) (k8sruntime.Object, error) {
+			list := &corev1.SecretList{Items: make([]corev1.Secret, rssLoadSecrets)}
+			for i := range list.Items {
+				list.Items[i] = corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            fmt.Sprintf("sh.helm.release.v1.app%d.v1", i),
+						Namespace:       "default",
+						ResourceVersion: "1",
+					},
+					Data: map[string][]byte{"release": bytes.Repeat([]byte{byte(i)}, rssLoadPayloadSize)},
+				}
+			}
+			return list, nil
+		},
+		WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+	informer := cache.NewSharedIndexInformer(lw, &corev1.Secret{}, 0, cache.Indexers{})
+	if transform {
+		if err := informer.SetTransform(TransformResourceData); err != nil {
+			t.Fatalf("SetTransform() error = %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go informer.RunWithContext(ctx)
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		t.Fatal("informer did not sync")
+	}
+	if got := len(informer.GetStore().ListKeys()); got != rssLoadSecrets {
+		t.Fatalf("informer cached %d Secrets, want %d", got, rssLoadSecrets)
+	}
+
+	// Return the memory of the list and of dropped payloads to the OS
+	debug.FreeOSMemory()
+	rss, err := readRSS()
+	if err != nil {
+		t.Fatalf("readRSS() error = %v", err)
+	}
+	fmt.Printf("rss=%d\n", rss)
+	runtime.KeepAlive(informer)
+}
+
+// readRSS returns the resident set size of the current process from /proc
+func readRSS() (int64, error) {
+	file, err := os.Open("/proc/self/status")
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, found := strings.CutPrefix(scanner.Text(), "VmRSS:"); found {
+			kib, err := strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "kB")), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return kib * 1024, nil
+		}
+	}
+	return 0, fmt.Errorf("VmRSS not found in /proc/self/status")
+}
@@ -88,12 +88,20 @@ func MergeDataMaps(stringData map[string]string, binaryData map[string][]byte) m
 }
 
 // GetResourceDataAndHash extracts data from a Secret or ConfigMap and calculates its hash
-// This consolidates the duplicate data extraction logic from reconcile functions
+// This consolidates the duplicate data extraction logic from reconcile functions.
+// For cached objects whose payload was dropped (see TransformResourceData), the hash
+// computed on ingest is returned; a hash annotation on any other object is ignored.
 func GetResourceDataAndHash(obj interface{}) (string, error) {
 	switch resource := obj.(type) {
 	case *corev1.Secret:
+		if hash, ok := cachedDigest(resource.Annotations, AnnotationDataHash); ok {
+			return hash, nil
+		}
 		return CalculateHash(resource.Data), nil
 	case *corev1.ConfigMap:
+		if hash, ok := cachedDigest(resource.Annotations, AnnotationDataHash); ok {
+			return hash, nil
+		}
 		data := MergeDataMaps(resource.Data, resource.BinaryData)
 		return CalculateHash(data), nil
 	default:
@@ -23,8 +23,9 @@ import (
 	"time"
 )
 
-const (
-	// Annotations used by Reloader
+// Annotations used by Reloader
+// The keys are variables: ConfigureAnnotationKeys moves them to another domain at startup.
+var (
 	AnnotationLastHash         = "reloader.stakater.com/last-hash"
 	AnnotationAuto             = "reloader.stakater.com/auto"
 	AnnotationSearch           = "reloader.stakater.com/search"
@@ -33,6 +34,33 @@ const (
 	AnnotationRolloutStrategy  = "reloader.stakater.com/rollout-strategy"
 	AnnotationLastReload       = "reloader.stakater.com/last-reload"
 	AnnotationLastReloadedFrom = "reloader.stakater.com/last-reloaded-from"
+	AnnotationReloadOnCreate   = "reloader.stakater.com/reload-on-create"
+	AnnotationReloadOnDelete   = "reloader.stakater.com/reload-on-delete"
+
+	// AnnotationClass assigns a workload, Secret or ConfigMap to an operator instance (see InClass)
+	AnnotationClass = "reloader.stakater.com/class"
+
+	// AnnotationReloadRequested asks for a manual reload of a ReloaderConfig's targets or of a workload
+	// The value is a token: each distinct token reloads once.
+	AnnotationReloadRequested = "reloader.stakater.com/reload-requested"
+
+	// AnnotationReloadRequestHandled is the last token of AnnotationReloadRequested handled on a workload
+	// (ReloaderConfigs record it in status.lastHandledReloadRequest)
+	AnnotationReloadRequestHandled = "reloader.stakater.com/reload-request-handled"
+
+	// Notify rollout strategy settings of annotation-based workloads (see NotifyConfig)
+	AnnotationNotifyPort  = "reloader.stakater.com/notify-port"
+	AnnotationNotifyPath  = "reloader.stakater.com/notify-path"
+	AnnotationNotifyDelay = "reloader.stakater.com/notify-delay"
+
+	// AnnotationReloadPending holds the reloads of a workload left to be done later, keyed by the changed resource:
+	// pod notifications waiting for their notify delay, and reloads handed over to the replica owning the
+	// workload's namespace (see --enable-sharding). Stored on the workload, so they survive operator restarts
+	// and shard rebalances.
+	AnnotationReloadPending = "reloader.stakater.com/reload-pending"
+
+	// AnnotationVolumeRefresh is the pod annotation bumped by the volume-refresh rollout strategy
+	AnnotationVolumeRefresh = "reloader.stakater.com/volume-refresh"
 
 	// Type-specific annotations
 	AnnotationSecretReload    = "secret.reloader.stakater.com/reload"
@@ -44,11 +72,28 @@ const (
 	AnnotationDeploymentPausePeriod  = "deployment.reloader.stakater.com/pause-period"
 	AnnotationStatefulSetPausePeriod = "statefulset.reloader.stakater.com/pause-period"
 	AnnotationDaemonSetPausePeriod   = "daemonset.reloader.stakater.com/pause-period"
+)
+
+const (
+	// AnnotationRestartedAt is the pod template annotation set by `kubectl rollout restart`
+	AnnotationRestartedAt = "kubectl.kubernetes.io/restartedAt"
 
-	// Environment variable prefix for reload triggers
+	// Environment variable prefix for reload triggers (default of --env-var-prefix)
 	EnvVarPrefix = "STAKATER_"
 )
 
+// Env var container policies (which containers receive the variable of the env-vars reload strategy)
+const (
+	EnvVarContainersFirst       = "first"       // The first container of the pod template
+	EnvVarContainersAll         = "all"         // Every container
+	EnvVarContainersReferencing = "referencing" // The containers that reference the changed resource
+	EnvVarContainersNamed       = "named"       // The containers listed by name
+)
+
+// FieldManager is the field manager of every write made by the operator
+// GitOps tools can key diff customizations on it (e.g., Argo CD managedFieldsManagers).
+const FieldManager = "reloader-operator"
+
 // Resource kinds supported by Reloader
 const (
 	KindSecret           = "Secret"
@@ -65,12 +110,25 @@ const (
 const (
 	RolloutStrategyRestart = "restart" // Delete pods directly (no template modification)
 	RolloutStrategyRollout = "rollout" // Modify template and trigger rolling update
+	RolloutStrategyNotify  = "notify"  // Ask Ready pods to reload in place over HTTP (no template modification)
+
+	// RolloutStrategyVolumeRefresh annotates the running pods so the kubelet resyncs mounted volumes now
+	// (no template modification; falls back to "rollout" when the resource is not only mounted as a volume)
+	RolloutStrategyVolumeRefresh = "volume-refresh"
+)
+
+// Notify fallbacks (what happens when a pod cannot be notified)
+const (
+	NotifyFallbackRollout = "rollout" // Reload with the rollout strategy instead
+	NotifyFallbackNone    = "none"    // Only report the failure
 )
 
 // Reload strategies (how to modify template when rollout strategy is "rollout")
 const (
-	ReloadStrategyEnvVars     = "env-vars"    // Update environment variable based on resource (e.g., STAKATER_DB_CREDENTIALS_SECRET)
-	ReloadStrategyAnnotations = "annotations" // Update pod template annotations
+	ReloadStrategyEnvVars       = "env-vars"       // Update environment variable based on resource (e.g., STAKATER_DB_CREDENTIALS_SECRET)
+	ReloadStrategyAnnotations   = "annotations"    // Update pod template annotations
+	ReloadStrategyRestartedAt   = "restarted-at"   // Set kubectl.kubernetes.io/restartedAt like `kubectl rollout restart`
+	ReloadStrategyVersionedCopy = "versioned-copy" // Reference an immutable, content-addressed copy of the resource
 )
 
 // GetDefaultNamespace returns the namespace from target or falls back to default
@@ -182,6 +240,19 @@ func ContainsString(slice []string, str string) bool {
 	return false
 }
 
+// TruncateString shortens s to at most maxLength characters, ending it with "..." when cut
+// It cuts on rune boundaries, so the result stays valid UTF-8.
+func TruncateString(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	if maxLength <= 3 {
+		return string(runes[:maxLength])
+	}
+	return string(runes[:maxLength-3]) + "..."
+}
+
 // IsSupportedWorkloadKind checks if the given kind is supported
 func IsSupportedWorkloadKind(kind string) bool {
 	supportedKinds := []string{
@@ -206,7 +277,7 @@ func ShouldReloadOnCreate(reloadOnCreate bool, annotations map[string]string) bo
 		return true
 	}
 	// Check annotation for backward compatibility
-	if annotations != nil && annotations["reloader.stakater.com/reload-on-create"] == "true" {
+	if annotations != nil && annotations[AnnotationReloadOnCreate] == "true" {
 		return true
 	}
 	return false
@@ -218,7 +289,7 @@ func ShouldReloadOnDelete(reloadOnDelete bool, annotations map[string]string) bo
 		return true
 	}
 	// Check annotation for backward compatibility
-	if annotations != nil && annotations["reloader.stakater.com/reload-on-delete"] == "true" {
+	if annotations != nil && annotations[AnnotationReloadOnDelete] == "true" {
 		return true
 	}
 	return false
@@ -251,7 +322,16 @@ func ConvertToEnvVarName(name string) string {
 //   - Secret "db-credentials" -> "STAKATER_DB_CREDENTIALS_SECRET"
 //   - ConfigMap "app-config" -> "STAKATER_APP_CONFIG_CONFIGMAP"
 func GetEnvVarName(resourceKind, resourceName string) string {
+	return GetPrefixedEnvVarName(EnvVarPrefix, resourceKind, resourceName)
+}
+
+// GetPrefixedEnvVarName generates the environment variable name for a resource with a custom prefix
+// Format: <PREFIX><RESOURCE_NAME>_<TYPE>; an empty prefix means EnvVarPrefix.
+func GetPrefixedEnvVarName(prefix, resourceKind, resourceName string) string {
+	if prefix == "" {
+		prefix = EnvVarPrefix
+	}
 	typeName := strings.ToUpper(resourceKind)
 	envVarName := ConvertToEnvVarName(resourceName)
-	return EnvVarPrefix + envVarName + "_" + typeName
+	return prefix + envVarName + "_" + typeName
 }
@@ -405,6 +405,49 @@ func TestContainsString(t *testing.T) {
 	}
 }
 
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		expected  string
+	}{
+		{
+			name:      "short enough",
+			input:     "reloaded",
+			maxLength: 8,
+			expected:  "reloaded",
+		},
+		{
+			name:      "truncated",
+			input:     "reload failed",
+			maxLength: 9,
+			expected:  "reload...",
+		},
+		{
+			name:      "multi-byte characters",
+			input:     "❌ Reload Failed",
+			maxLength: 5,
+			expected:  "❌ ...",
+		},
+		{
+			name:      "no room for ellipsis",
+			input:     "❌❌❌❌",
+			maxLength: 2,
+			expected:  "❌❌",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TruncateString(tt.input, tt.maxLength)
+			if result != tt.expected {
+				t.Errorf("TruncateString() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestIsSupportedWorkloadKind(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -618,3 +661,12 @@ func TestGetEnvVarName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPrefixedEnvVarName(t *testing.T) {
+	if result := GetPrefixedEnvVarName("RELOAD_", "ConfigMap", "app-config"); result != "RELOAD_APP_CONFIG_CONFIGMAP" {
+		t.Errorf("GetPrefixedEnvVarName() = %s, want RELOAD_APP_CONFIG_CONFIGMAP", result)
+	}
+	if result := GetPrefixedEnvVarName("", "Secret", "db"); result != "STAKATER_DB_SECRET" {
+		t.Errorf("GetPrefixedEnvVarName() with an empty prefix = %s, want STAKATER_DB_SECRET", result)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex_SerializesSameKey(t *testing.T) {
+	var locks KeyedMutex
+	var active, overlaps atomic.Int32
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.Lock("default/Deployment/app")
+			defer unlock()
+
+			if active.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if overlaps.Load() != 0 {
+		t.Errorf("the same key was held by several goroutines %d times", overlaps.Load())
+	}
+	if locks.Len() != 0 {
+		t.Errorf("Len() = %d after all unlocks, want 0", locks.Len())
+	}
+}
+
+func TestKeyedMutex_DifferentKeysInParallel(t *testing.T) {
+	var locks KeyedMutex
+
+	unlockA := locks.Lock("default/Deployment/a")
+	done := make(chan struct{})
+	go func() {
+		unlockB := locks.Lock("default/Deployment/b")
+		unlockB()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("a different key was blocked by a held key")
+	}
+
+	if locks.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", locks.Len())
+	}
+	unlockA()
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// DefaultObjectJSONPath is hashed when a watched object does not list any JSONPaths
+const DefaultObjectJSONPath = ".spec"
+
+// QualifiedKind names the kind of a watched object in resource keys and status entries
+// Kinds of API groups other than the core group are qualified with the group
+// (e.g., "Certificate.cert-manager.io"), so same-named kinds of different CRDs
+// never share a hash or status entry.
+func QualifiedKind(gvk schema.GroupVersionKind) string {
+	if gvk.Group == "" {
+		return gvk.Kind
+	}
+	return gvk.Kind + "." + gvk.Group
+}
+
+// NormalizeJSONPath accepts both kubectl-style "{.status.revision}" and bare ".status.revision"
+func NormalizeJSONPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return "{" + path + "}"
+}
+
+// ValidateJSONPaths checks that all JSONPath expressions parse
+func ValidateJSONPaths(paths []string) error {
+	for _, path := range paths {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("empty JSONPath")
+		}
+		if err := jsonpath.New(path).Parse(NormalizeJSONPath(path)); err != nil {
+			return fmt.Errorf("invalid JSONPath %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// JSONPathsDigest identifies the fields CalculateObjectHash hashes for a list of JSONPaths
+// Equivalent lists (another order, with or without braces, the default path) share a digest.
+func JSONPathsDigest(paths []string) string {
+	if len(paths) == 0 {
+		paths = []string{DefaultObjectJSONPath}
+	}
+	normalized := make(map[string]string, len(paths))
+	for _, path := range paths {
+		normalized[NormalizeJSONPath(path)] = ""
+	}
+	return CalculateHashFromStringMap(normalized)
+}
+
+// CalculateObjectHash computes the SHA256 hash of the given fields of an object
+//
+// Business Logic:
+// Arbitrary objects carry a lot of churn (resourceVersion, managedFields,
+// status heartbeats), so only the fields selected by the JSONPaths are hashed.
+// Each result is JSON-encoded, so a missing field and an empty string hash
+// differently. Missing fields are not an error: a Certificate without a
+// status.revision yet simply hashes as "not set".
+func CalculateObjectHash(obj *unstructured.Unstructured, paths []string) (string, error) {
+	if len(paths) == 0 {
+		paths = []string{DefaultObjectJSONPath}
+	}
+
+	data := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		path = NormalizeJSONPath(path)
+		parser := jsonpath.New(path).AllowMissingKeys(true)
+		if err := parser.Parse(path); err != nil {
+			return "", fmt.Errorf("invalid JSONPath %q: %w", path, err)
+		}
+
+		results, err := parser.FindResults(obj.Object)
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate JSONPath %q: %w", path, err)
+		}
+
+		values := []interface{}{}
+		for _, result := range results {
+			for _, value := range result {
+				values = append(values, value.Interface())
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(values); err != nil {
+			return "", fmt.Errorf("failed to encode JSONPath %q result: %w", path, err)
+		}
+		data[path] = buf.Bytes()
+	}
+
+	return CalculateHash(data), nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newCertificate(revision int64, renewalTime string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":            "api-tls",
+			"namespace":       "default",
+			"resourceVersion": renewalTime,
+		},
+		"spec": map[string]interface{}{
+			"secretName": "api-tls",
+			"dnsNames":   []interface{}{"api.example.com"},
+		},
+		"status": map[string]interface{}{
+			"renewalTime": renewalTime,
+		},
+	}}
+	if revision > 0 {
+		_ = unstructured.SetNestedField(obj.Object, revision, "status", "revision")
+	}
+	return obj
+}
+
+func TestCalculateObjectHash(t *testing.T) {
+	paths := []string{"{.status.revision}"}
+
+	hash1, err := CalculateObjectHash(newCertificate(1, "2025-01-01T00:00:00Z"), paths)
+	if err != nil {
+		t.Fatalf("CalculateObjectHash() error = %v", err)
+	}
+	if hash1 == "" {
+		t.Fatal("CalculateObjectHash() returned an empty hash")
+	}
+
+	// Fields outside the JSONPaths do not affect the hash
+	hash2, _ := CalculateObjectHash(newCertificate(1, "2025-02-01T00:00:00Z"), paths)
+	if hash1 != hash2 {
+		t.Error("hash changed although the hashed field did not")
+	}
+
+	// A new revision changes the hash
+	hash3, _ := CalculateObjectHash(newCertificate(2, "2025-02-01T00:00:00Z"), paths)
+	if hash1 == hash3 {
+		t.Error("hash did not change although the hashed field did")
+	}
+
+	// A missing field is not an error, and differs from a set one
+	hashMissing, err := CalculateObjectHash(newCertificate(0, "2025-01-01T00:00:00Z"), paths)
+	if err != nil {
+		t.Fatalf("CalculateObjectHash() error for missing field = %v", err)
+	}
+	if hashMissing == "" || hashMissing == hash1 {
+		t.Error("missing field should hash to a distinct, non-empty value")
+	}
+
+	// Bare paths are equivalent to braced paths
+	hashBare, _ := CalculateObjectHash(newCertificate(1, "2025-01-01T00:00:00Z"), []string{".status.revision"})
+	if hashBare != hash1 {
+		t.Error("bare JSONPath should hash like its braced form")
+	}
+}
+
+func TestCalculateObjectHash_DefaultsToSpec(t *testing.T) {
+	obj := newCertificate(1, "2025-01-01T00:00:00Z")
+	hash1, err := CalculateObjectHash(obj, nil)
+	if err != nil {
+		t.Fatalf("CalculateObjectHash() error = %v", err)
+	}
+
+	_ = unstructured.SetNestedField(obj.Object, int64(5), "status", "revision")
+	hash2, _ := CalculateObjectHash(obj, nil)
+	if hash1 != hash2 {
+		t.Error("status change should not affect the default .spec hash")
+	}
+
+	_ = unstructured.SetNestedField(obj.Object, "other-tls", "spec", "secretName")
+	hash3, _ := CalculateObjectHash(obj, nil)
+	if hash1 == hash3 {
+		t.Error("spec change should affect the default .spec hash")
+	}
+}
+
+func TestValidateJSONPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr bool
+	}{
+		{name: "braced path", paths: []string{"{.status.revision}"}},
+		{name: "bare path", paths: []string{".spec.data"}},
+		{name: "filter expression", paths: []string{`{.status.conditions[?(@.type=="Ready")].status}`}},
+		{name: "no paths", paths: nil},
+		{name: "empty path", paths: []string{" "}, wantErr: true},
+		{name: "unterminated path", paths: []string{"{.status.revision"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSONPaths(tt.paths)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateJSONPaths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQualifiedKind(t *testing.T) {
+	tests := []struct {
+		gvk      schema.GroupVersionKind
+		expected string
+	}{
+		{schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}, "Certificate.cert-manager.io"},
+		{schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Certificate"}, "Certificate.example.com"},
+		{schema.GroupVersionKind{Version: "v1", Kind: "Service"}, "Service"},
+	}
+
+	for _, tt := range tests {
+		if got := QualifiedKind(tt.gvk); got != tt.expected {
+			t.Errorf("QualifiedKind(%v) = %q, want %q", tt.gvk, got, tt.expected)
+		}
+	}
+}
+
+func TestJSONPathsDigest(t *testing.T) {
+	base := JSONPathsDigest([]string{".status.revision", "{.spec.dnsNames}"})
+	if got := JSONPathsDigest([]string{"{.spec.dnsNames}", "{.status.revision}"}); got != base {
+		t.Errorf("equivalent JSONPaths must share a digest, got %q and %q", got, base)
+	}
+	if got := JSONPathsDigest([]string{".status.revision"}); got == base {
+		t.Error("different JSONPaths must not share a digest")
+	}
+	if JSONPathsDigest(nil) != JSONPathsDigest([]string{DefaultObjectJSONPath}) {
+		t.Error("no JSONPaths must digest like the default path")
+	}
+}
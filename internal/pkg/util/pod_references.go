@@ -17,6 +17,8 @@ limitations under the License.
 package util
 
 import (
+	"slices"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -154,3 +156,131 @@ func checkVolumesReferenceResource(volumes []corev1.Volume, resourceKind, resour
 
 	return false
 }
+
+// CheckContainerReferencesResource checks if one container of a PodSpec references a specific
+// Secret or ConfigMap, through env, envFrom, or a mounted volume of the PodSpec.
+func CheckContainerReferencesResource(podSpec *corev1.PodSpec, container corev1.Container, resourceKind, resourceName string) bool {
+	if checkContainerReferencesResource(container, resourceKind, resourceName) {
+		return true
+	}
+
+	for _, mount := range container.VolumeMounts {
+		for _, volume := range podSpec.Volumes {
+			if volume.Name == mount.Name && checkVolumesReferenceResource([]corev1.Volume{volume}, resourceKind, resourceName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckPodSpecReferencesResourceOnlyAsVolume checks if a PodSpec consumes a Secret or ConfigMap
+// only through volumes the kubelet keeps in sync with the resource.
+//
+// Business Logic:
+// The kubelet refreshes Secret, ConfigMap and projected volumes in running
+// pods, but never environment variables, and never files mounted with subPath.
+// A resource qualifies when at least one volume references it, no container
+// (regular or init) reads it through env or envFrom, and no container mounts
+// one of its volumes with subPath or subPathExpr. Resources reached through a
+// SecretProviderClass do not qualify: the CSI driver, not the kubelet, updates them.
+func CheckPodSpecReferencesResourceOnlyAsVolume(podSpec *corev1.PodSpec, resourceKind, resourceName string) bool {
+	if podSpec == nil {
+		return false
+	}
+
+	// Concat copies: the PodSpec may belong to a shared cache object
+	allContainers := slices.Concat(podSpec.Containers, podSpec.InitContainers)
+	for _, container := range allContainers {
+		if checkContainerReferencesResource(container, resourceKind, resourceName) {
+			return false
+		}
+	}
+
+	volumeNames := []string{}
+	for _, volume := range podSpec.Volumes {
+		if checkVolumesReferenceResource([]corev1.Volume{volume}, resourceKind, resourceName) {
+			volumeNames = append(volumeNames, volume.Name)
+		}
+	}
+	if len(volumeNames) == 0 {
+		return false
+	}
+
+	for _, container := range allContainers {
+		for _, mount := range container.VolumeMounts {
+			if ContainsString(volumeNames, mount.Name) && (mount.SubPath != "" || mount.SubPathExpr != "") {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// ReferenceKey identifies a referenced resource as "<kind>/<name>" (e.g., "Secret/db-credentials")
+func ReferenceKey(resourceKind, resourceName string) string {
+	return resourceKind + "/" + resourceName
+}
+
+// GetPodSpecReferences returns the keys (see ReferenceKey) of all Secrets and ConfigMaps
+// a PodSpec references directly, through the same sources CheckPodSpecReferencesResource checks.
+func GetPodSpecReferences(podSpec *corev1.PodSpec) []string {
+	if podSpec == nil {
+		return nil
+	}
+
+	refs := []string{}
+	add := func(resourceKind, resourceName string) {
+		if resourceName == "" {
+			return
+		}
+		if key := ReferenceKey(resourceKind, resourceName); !ContainsString(refs, key) {
+			refs = append(refs, key)
+		}
+	}
+
+	// Concat copies: the PodSpec may belong to a shared cache object
+	for _, container := range slices.Concat(podSpec.Containers, podSpec.InitContainers) {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				add(KindSecret, env.ValueFrom.SecretKeyRef.Name)
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				add(KindConfigMap, env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				add(KindSecret, envFrom.SecretRef.Name)
+			}
+			if envFrom.ConfigMapRef != nil {
+				add(KindConfigMap, envFrom.ConfigMapRef.Name)
+			}
+		}
+	}
+
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			add(KindSecret, volume.Secret.SecretName)
+		}
+		if volume.ConfigMap != nil {
+			add(KindConfigMap, volume.ConfigMap.Name)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(KindSecret, source.Secret.Name)
+				}
+				if source.ConfigMap != nil {
+					add(KindConfigMap, source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+
+	return refs
+}
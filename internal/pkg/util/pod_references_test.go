@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCheckPodSpecReferencesResourceOnlyAsVolume(t *testing.T) {
+	configVolume := corev1.Volume{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+		},
+	}
+	projectedVolume := corev1.Volume{
+		Name: "bundle",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+			}}},
+		},
+	}
+	envFrom := corev1.EnvFromSource{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+	}
+
+	tests := []struct {
+		name     string
+		podSpec  *corev1.PodSpec
+		expected bool
+	}{
+		{
+			name: "volume mount",
+			podSpec: &corev1.PodSpec{
+				Volumes:    []corev1.Volume{configVolume},
+				Containers: []corev1.Container{{Name: "app", VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/app"}}}},
+			},
+			expected: true,
+		},
+		{
+			name: "projected volume",
+			podSpec: &corev1.PodSpec{
+				Volumes:    []corev1.Volume{projectedVolume},
+				Containers: []corev1.Container{{Name: "app", VolumeMounts: []corev1.VolumeMount{{Name: "bundle", MountPath: "/etc/app"}}}},
+			},
+			expected: true,
+		},
+		{
+			name: "subPath mount",
+			podSpec: &corev1.PodSpec{
+				Volumes: []corev1.Volume{configVolume},
+				Containers: []corev1.Container{{Name: "app", VolumeMounts: []corev1.VolumeMount{
+					{Name: "config", MountPath: "/etc/app/app.yaml", SubPath: "app.yaml"},
+				}}},
+			},
+			expected: false,
+		},
+		{
+			name: "also read as env in an init container",
+			podSpec: &corev1.PodSpec{
+				Volumes:        []corev1.Volume{configVolume},
+				Containers:     []corev1.Container{{Name: "app", VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/app"}}}},
+				InitContainers: []corev1.Container{{Name: "init", EnvFrom: []corev1.EnvFromSource{envFrom}}},
+			},
+			expected: false,
+		},
+		{
+			name:     "only env",
+			podSpec:  &corev1.PodSpec{Containers: []corev1.Container{{Name: "app", EnvFrom: []corev1.EnvFromSource{envFrom}}}},
+			expected: false,
+		},
+		{
+			name:     "not referenced",
+			podSpec:  &corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			expected: false,
+		},
+		{
+			name:     "nil pod spec",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CheckPodSpecReferencesResourceOnlyAsVolume(tt.podSpec, KindConfigMap, "app-config")
+			if result != tt.expected {
+				t.Errorf("CheckPodSpecReferencesResourceOnlyAsVolume() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
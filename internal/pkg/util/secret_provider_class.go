@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretsStoreCSIDriver is the name of the Secrets Store CSI driver
+	SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+
+	// SecretProviderClassAttribute is the CSI volume attribute naming the SecretProviderClass
+	SecretProviderClassAttribute = "secretProviderClass"
+)
+
+// SecretProviderClassGVK identifies the Secrets Store CSI driver SecretProviderClass resource.
+// The CRD is optional, so it is read as unstructured instead of registering its types.
+var SecretProviderClassGVK = schema.GroupVersionKind{
+	Group:   "secrets-store.csi.x-k8s.io",
+	Version: "v1",
+	Kind:    "SecretProviderClass",
+}
+
+// GetSecretProviderClassNames returns the SecretProviderClasses mounted by a pod spec
+// through Secrets Store CSI driver volumes.
+func GetSecretProviderClassNames(podSpec *corev1.PodSpec) []string {
+	if podSpec == nil {
+		return nil
+	}
+
+	names := []string{}
+	for _, volume := range podSpec.Volumes {
+		if volume.CSI == nil || volume.CSI.Driver != SecretsStoreCSIDriver {
+			continue
+		}
+		if name := volume.CSI.VolumeAttributes[SecretProviderClassAttribute]; name != "" && !ContainsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GetSecretProviderClassSecrets returns the Kubernetes Secrets a SecretProviderClass syncs
+// (spec.secretObjects[].secretName).
+//
+// A missing SecretProviderClass, or a cluster without the SecretProviderClass CRD,
+// is not an error: the class simply syncs no Secrets. c is called for every
+// workload check, so it should be a cache (an informer-backed reader).
+func GetSecretProviderClassSecrets(ctx context.Context, c client.Reader, namespace, name string) ([]string, error) {
+	spc := &unstructured.Unstructured{}
+	spc.SetGroupVersionKind(SecretProviderClassGVK)
+
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, spc); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SecretProviderClass %s/%s: %w", namespace, name, err)
+	}
+
+	secretObjects, _, err := unstructured.NestedSlice(spc.Object, "spec", "secretObjects")
+	if err != nil {
+		return nil, fmt.Errorf("invalid secretObjects in SecretProviderClass %s/%s: %w", namespace, name, err)
+	}
+
+	secrets := []string{}
+	for _, item := range secretObjects {
+		secretObject, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if secretName, ok := secretObject["secretName"].(string); ok && secretName != "" {
+			secrets = append(secrets, secretName)
+		}
+	}
+	return secrets, nil
+}
+
+// PodSpecReferencesResource checks if a PodSpec references a Secret or ConfigMap,
+// including Secrets synced by Secrets Store CSI driver SecretProviderClasses.
+//
+// Business Logic:
+// CheckPodSpecReferencesResource only sees direct references. Pods using the
+// Secrets Store CSI driver mount a SecretProviderClass instead; when that class
+// has secretObjects, the driver syncs the mounted content into Kubernetes
+// Secrets. Such a Secret is treated as referenced by the pod, so auto and
+// targeted reload work for these workloads too.
+func PodSpecReferencesResource(
+	ctx context.Context,
+	c client.Reader,
+	podSpec *corev1.PodSpec,
+	namespace, resourceKind, resourceName string,
+) (bool, error) {
+	if CheckPodSpecReferencesResource(podSpec, resourceKind, resourceName) {
+		return true, nil
+	}
+
+	// SecretProviderClasses only sync Secrets
+	if resourceKind != KindSecret {
+		return false, nil
+	}
+
+	for _, spcName := range GetSecretProviderClassNames(podSpec) {
+		secrets, err := GetSecretProviderClassSecrets(ctx, c, namespace, spcName)
+		if err != nil {
+			return false, err
+		}
+		if ContainsString(secrets, resourceName) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newSecretProviderClass(namespace, name string, secretNames ...string) *unstructured.Unstructured {
+	secretObjects := []interface{}{}
+	for _, secretName := range secretNames {
+		secretObjects = append(secretObjects, map[string]interface{}{
+			"secretName": secretName,
+			"type":       "Opaque",
+		})
+	}
+
+	spc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"provider":      "vault",
+			"secretObjects": secretObjects,
+		},
+	}}
+	spc.SetGroupVersionKind(SecretProviderClassGVK)
+	spc.SetNamespace(namespace)
+	spc.SetName(name)
+	return spc
+}
+
+func csiPodSpec(spcNames ...string) *corev1.PodSpec {
+	podSpec := &corev1.PodSpec{}
+	for _, spcName := range spcNames {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: spcName,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:           SecretsStoreCSIDriver,
+					VolumeAttributes: map[string]string{SecretProviderClassAttribute: spcName},
+				},
+			},
+		})
+	}
+	return podSpec
+}
+
+func TestGetSecretProviderClassNames(t *testing.T) {
+	podSpec := csiPodSpec("vault-db", "vault-api", "vault-db")
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "other-csi",
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           "other.csi.k8s.io",
+				VolumeAttributes: map[string]string{SecretProviderClassAttribute: "ignored"},
+			},
+		},
+	})
+
+	names := GetSecretProviderClassNames(podSpec)
+	if len(names) != 2 || names[0] != "vault-db" || names[1] != "vault-api" {
+		t.Errorf("GetSecretProviderClassNames() = %v, want [vault-db vault-api]", names)
+	}
+
+	if names := GetSecretProviderClassNames(nil); len(names) != 0 {
+		t.Errorf("GetSecretProviderClassNames(nil) = %v, want empty", names)
+	}
+}
+
+func TestPodSpecReferencesResource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newSecretProviderClass("default", "vault-db", "db-credentials", "db-tls")).
+		Build()
+
+	tests := []struct {
+		name         string
+		podSpec      *corev1.PodSpec
+		resourceKind string
+		resourceName string
+		expected     bool
+	}{
+		{
+			name:         "secret synced by mounted SecretProviderClass",
+			podSpec:      csiPodSpec("vault-db"),
+			resourceKind: KindSecret,
+			resourceName: "db-tls",
+			expected:     true,
+		},
+		{
+			name:         "secret not synced by mounted SecretProviderClass",
+			podSpec:      csiPodSpec("vault-db"),
+			resourceKind: KindSecret,
+			resourceName: "api-token",
+			expected:     false,
+		},
+		{
+			name:         "configmap is never resolved through SecretProviderClass",
+			podSpec:      csiPodSpec("vault-db"),
+			resourceKind: KindConfigMap,
+			resourceName: "db-credentials",
+			expected:     false,
+		},
+		{
+			name:         "missing SecretProviderClass",
+			podSpec:      csiPodSpec("missing"),
+			resourceKind: KindSecret,
+			resourceName: "db-credentials",
+			expected:     false,
+		},
+		{
+			name: "direct reference",
+			podSpec: &corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "creds",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: "api-token"},
+					},
+				}},
+			},
+			resourceKind: KindSecret,
+			resourceName: "api-token",
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PodSpecReferencesResource(context.Background(), c, tt.podSpec, "default", tt.resourceKind, tt.resourceName)
+			if err != nil {
+				t.Fatalf("PodSpecReferencesResource() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("PodSpecReferencesResource() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPodSpecReferencesResource_OtherNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newSecretProviderClass("other", "vault-db", "db-credentials")).
+		Build()
+
+	result, err := PodSpecReferencesResource(context.Background(), c, csiPodSpec("vault-db"), "default", KindSecret, "db-credentials")
+	if err != nil {
+		t.Fatalf("PodSpecReferencesResource() error = %v", err)
+	}
+	if result {
+		t.Error("SecretProviderClass from another namespace must not be resolved")
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the versioned-copy reload strategy (moved by ConfigureAnnotationKeys like the other annotations)
+var (
+	// AnnotationVersionedCopies is the pod template annotation mapping each original
+	// Secret/ConfigMap (ReferenceKey) to the versioned copy the template references
+	AnnotationVersionedCopies = "reloader.stakater.com/versioned-copies"
+
+	// AnnotationCopyOf names the original Secret/ConfigMap of a versioned copy
+	AnnotationCopyOf = "reloader.stakater.com/copy-of"
+
+	// LabelVersionedCopy marks the versioned copies created by the operator
+	LabelVersionedCopy = "reloader.stakater.com/versioned-copy"
+)
+
+// versionedCopyHashLength is the number of hash characters in a copy name
+const versionedCopyHashLength = 10
+
+// VersionedCopyName returns the name of the copy of a resource holding the data with the given hash
+// The original name is shortened when needed so the result stays a valid object name.
+func VersionedCopyName(resourceName, resourceHash string) string {
+	suffix := resourceHash
+	if len(suffix) > versionedCopyHashLength {
+		suffix = suffix[:versionedCopyHashLength]
+	}
+
+	maxNameLength := validation.DNS1123SubdomainMaxLength - len(suffix) - 1
+	if len(resourceName) > maxNameLength {
+		resourceName = resourceName[:maxNameLength]
+	}
+	return resourceName + "-" + suffix
+}
+
+// GetVersionedCopies returns the copy mapping of a pod template (see AnnotationVersionedCopies)
+// A missing or malformed annotation yields an empty mapping.
+func GetVersionedCopies(annotations map[string]string) map[string]string {
+	copies := map[string]string{}
+	if value := annotations[AnnotationVersionedCopies]; value != "" {
+		_ = json.Unmarshal([]byte(value), &copies)
+	}
+	return copies
+}
+
+// SetVersionedCopy records in the pod template which copy now stands in for a resource
+func SetVersionedCopy(template *corev1.PodTemplateSpec, resourceKind, resourceName, copyName string) error {
+	copies := GetVersionedCopies(template.Annotations)
+	copies[ReferenceKey(resourceKind, resourceName)] = copyName
+
+	value, err := json.Marshal(copies)
+	if err != nil {
+		return err
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[AnnotationVersionedCopies] = string(value)
+	return nil
+}
+
+// RewritePodSpecReferences points every reference to one of the names at the new name
+// It covers the same sources as CheckPodSpecReferencesResource and reports whether anything changed.
+func RewritePodSpecReferences(podSpec *corev1.PodSpec, resourceKind string, names []string, newName string) bool {
+	changed := false
+	rewrite := func(name *string) {
+		if *name != newName && ContainsString(names, *name) {
+			*name = newName
+			changed = true
+		}
+	}
+
+	for _, containers := range [][]corev1.Container{podSpec.Containers, podSpec.InitContainers} {
+		for i := range containers {
+			container := &containers[i]
+			for j := range container.Env {
+				valueFrom := container.Env[j].ValueFrom
+				if valueFrom == nil {
+					continue
+				}
+				if resourceKind == KindSecret && valueFrom.SecretKeyRef != nil {
+					rewrite(&valueFrom.SecretKeyRef.Name)
+				}
+				if resourceKind == KindConfigMap && valueFrom.ConfigMapKeyRef != nil {
+					rewrite(&valueFrom.ConfigMapKeyRef.Name)
+				}
+			}
+			for j := range container.EnvFrom {
+				envFrom := &container.EnvFrom[j]
+				if resourceKind == KindSecret && envFrom.SecretRef != nil {
+					rewrite(&envFrom.SecretRef.Name)
+				}
+				if resourceKind == KindConfigMap && envFrom.ConfigMapRef != nil {
+					rewrite(&envFrom.ConfigMapRef.Name)
+				}
+			}
+		}
+	}
+
+	for i := range podSpec.Volumes {
+		volume := &podSpec.Volumes[i]
+		if resourceKind == KindSecret && volume.Secret != nil {
+			rewrite(&volume.Secret.SecretName)
+		}
+		if resourceKind == KindConfigMap && volume.ConfigMap != nil {
+			rewrite(&volume.ConfigMap.Name)
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for j := range volume.Projected.Sources {
+			source := &volume.Projected.Sources[j]
+			if resourceKind == KindSecret && source.Secret != nil {
+				rewrite(&source.Secret.Name)
+			}
+			if resourceKind == KindConfigMap && source.ConfigMap != nil {
+				rewrite(&source.ConfigMap.Name)
+			}
+		}
+	}
+
+	return changed
+}
+
+// PodTemplateReferencesResource checks if a pod template references a Secret or ConfigMap,
+// directly, through a SecretProviderClass, or through the versioned copy standing in for it.
+//
+// Business Logic:
+// The versioned-copy reload strategy rewrites references to point at copies,
+// so the pod spec no longer names the original. The template's copy mapping
+// keeps the workload attached to the original: a change to the original must
+// still be found to concern this workload.
+func PodTemplateReferencesResource(
+	ctx context.Context,
+	c client.Reader,
+	template *corev1.PodTemplateSpec,
+	namespace, resourceKind, resourceName string,
+) (bool, error) {
+	if template == nil {
+		return false, nil
+	}
+
+	references, err := PodSpecReferencesResource(ctx, c, &template.Spec, namespace, resourceKind, resourceName)
+	if err != nil || references {
+		return references, err
+	}
+
+	copyName := GetVersionedCopies(template.Annotations)[ReferenceKey(resourceKind, resourceName)]
+	return copyName != "" && CheckPodSpecReferencesResource(&template.Spec, resourceKind, copyName), nil
+}
+
+// GetPodTemplateReferences returns the keys of all Secrets and ConfigMaps a pod template references
+// directly (see GetPodSpecReferences), plus the originals of the versioned copies it references.
+func GetPodTemplateReferences(template *corev1.PodTemplateSpec) []string {
+	if template == nil {
+		return nil
+	}
+
+	refs := GetPodSpecReferences(&template.Spec)
+	for original, copyName := range GetVersionedCopies(template.Annotations) {
+		kind, _, ok := strings.Cut(original, "/")
+		if !ok || ContainsString(refs, original) || !ContainsString(refs, ReferenceKey(kind, copyName)) {
+			continue
+		}
+		refs = append(refs, original)
+	}
+	return refs
+}
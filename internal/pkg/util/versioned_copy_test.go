@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func configMapTemplate(names ...string) *corev1.PodTemplateSpec {
+	template := &corev1.PodTemplateSpec{}
+	container := corev1.Container{Name: "app"}
+	for _, name := range names {
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+			},
+		})
+		container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+		})
+	}
+	template.Spec.Containers = []corev1.Container{container}
+	return template
+}
+
+func TestVersionedCopyName(t *testing.T) {
+	if name := VersionedCopyName("app-config", "0123456789abcdef"); name != "app-config-0123456789" {
+		t.Errorf("VersionedCopyName() = %q, want app-config-0123456789", name)
+	}
+
+	long := VersionedCopyName(strings.Repeat("a", validation.DNS1123SubdomainMaxLength), "0123456789abcdef")
+	if len(long) != validation.DNS1123SubdomainMaxLength || !strings.HasSuffix(long, "-0123456789") {
+		t.Errorf("VersionedCopyName() of a long name = %q (%d characters)", long, len(long))
+	}
+}
+
+func TestSetVersionedCopy(t *testing.T) {
+	template := &corev1.PodTemplateSpec{}
+	if err := SetVersionedCopy(template, KindConfigMap, "app-config", "app-config-1111111111"); err != nil {
+		t.Fatalf("SetVersionedCopy() error = %v", err)
+	}
+	if err := SetVersionedCopy(template, KindSecret, "db", "db-2222222222"); err != nil {
+		t.Fatalf("SetVersionedCopy() error = %v", err)
+	}
+
+	copies := GetVersionedCopies(template.Annotations)
+	if copies["ConfigMap/app-config"] != "app-config-1111111111" || copies["Secret/db"] != "db-2222222222" {
+		t.Errorf("GetVersionedCopies() = %v", copies)
+	}
+
+	if copies := GetVersionedCopies(map[string]string{AnnotationVersionedCopies: "not json"}); len(copies) != 0 {
+		t.Errorf("GetVersionedCopies() of a malformed annotation = %v, want empty", copies)
+	}
+}
+
+func TestRewritePodSpecReferences(t *testing.T) {
+	template := configMapTemplate("app-config", "app-config-1111111111", "other")
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: "projected",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+			}}},
+		},
+	})
+
+	names := []string{"app-config", "app-config-1111111111"}
+	if !RewritePodSpecReferences(&template.Spec, KindConfigMap, names, "app-config-2222222222") {
+		t.Fatal("RewritePodSpecReferences() reported no change")
+	}
+
+	refs := GetPodSpecReferences(&template.Spec)
+	if want := []string{"ConfigMap/app-config-2222222222", "ConfigMap/other"}; !slices.Equal(refs, want) {
+		t.Errorf("references after rewrite = %v, want %v", refs, want)
+	}
+
+	// Secrets are left alone, and rewriting again changes nothing
+	if RewritePodSpecReferences(&template.Spec, KindSecret, []string{"other"}, "other-3333333333") {
+		t.Error("RewritePodSpecReferences() rewrote a reference of another kind")
+	}
+	if RewritePodSpecReferences(&template.Spec, KindConfigMap, names, "app-config-2222222222") {
+		t.Error("RewritePodSpecReferences() reported a change for references already rewritten")
+	}
+}
+
+func TestPodTemplateReferencesResource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	template := configMapTemplate("app-config-1111111111")
+	if err := SetVersionedCopy(template, KindConfigMap, "app-config", "app-config-1111111111"); err != nil {
+		t.Fatalf("SetVersionedCopy() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		resourceKind string
+		resourceName string
+		expected     bool
+	}{
+		{"original of the referenced copy", KindConfigMap, "app-config", true},
+		{"the copy itself", KindConfigMap, "app-config-1111111111", true},
+		{"same name, other kind", KindSecret, "app-config", false},
+		{"unrelated resource", KindConfigMap, "other", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PodTemplateReferencesResource(ctx, c, template, "default", tt.resourceKind, tt.resourceName)
+			if err != nil {
+				t.Fatalf("PodTemplateReferencesResource() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("PodTemplateReferencesResource() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	// A stale mapping (e.g., the reference was edited back by hand) does not count
+	stale := configMapTemplate("other")
+	stale.Annotations = template.Annotations
+	if result, _ := PodTemplateReferencesResource(ctx, c, stale, "default", KindConfigMap, "app-config"); result {
+		t.Error("PodTemplateReferencesResource() followed a mapping to a copy the template does not reference")
+	}
+
+	refs := GetPodTemplateReferences(template)
+	if want := []string{"ConfigMap/app-config-1111111111", "ConfigMap/app-config"}; !slices.Equal(refs, want) {
+		t.Errorf("GetPodTemplateReferences() = %v, want %v", refs, want)
+	}
+}
@@ -27,7 +27,7 @@ import (
 
 // GetWorkload fetches a workload by kind, name, and namespace
 // This consolidates the duplicate switch logic found in multiple files
-func GetWorkload(ctx context.Context, c client.Client, kind, name, namespace string) (client.Object, error) {
+func GetWorkload(ctx context.Context, c client.Reader, kind, name, namespace string) (client.Object, error) {
 	key := client.ObjectKey{
 		Name:      name,
 		Namespace: namespace,
@@ -18,9 +18,15 @@ package workload
 
 import (
 	"context"
+	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -33,16 +39,54 @@ type Target struct {
 	Kind             string
 	Name             string
 	Namespace        string
-	RolloutStrategy  string // How to deploy: "rollout" (modify template) or "restart" (delete pods)
+	RolloutStrategy  string // How to deploy: "rollout" (modify template), "restart" (delete pods) or "notify" (HTTP request to pods)
 	ReloadStrategy   string // How to modify template: "env-vars" or "annotations" (only used when RolloutStrategy is "rollout")
 	PausePeriod      string
 	RequireReference bool                             // Whether this target requires pod spec reference for targeted reload
 	Config           *reloaderv1alpha1.ReloaderConfig // Reference to the ReloaderConfig that triggered this
+	Notify           *reloaderv1alpha1.NotifyConfig   // HTTP reload request (only used when RolloutStrategy is "notify")
+	EnvVars          *reloaderv1alpha1.EnvVarsConfig  // Env var name and containers (only used when ReloadStrategy is "env-vars"); nil means the operator-wide settings
+	Rule             string                           // Why the workload is a target of the change (see ReloadRule*)
+
+	// PodNotifications is the per-pod outcome of a "notify" reload, recorded in the target status
+	PodNotifications []reloaderv1alpha1.PodNotificationStatus
+}
+
+// Reload rules (why a workload is a target of a resource change)
+const (
+	ReloadRuleAuto           = "auto"            // reloader.stakater.com/auto and the pod spec references the resource
+	ReloadRuleTypeAuto       = "type-auto"       // secret/configmap.reloader.stakater.com/auto and the pod spec references the resource
+	ReloadRuleNamedReload    = "named-reload"    // secret/configmap.reloader.stakater.com/reload lists the resource
+	ReloadRuleSearchMatch    = "search-match"    // reloader.stakater.com/search on the workload, match on the resource
+	ReloadRuleReloaderConfig = "reloader-config" // Target of a ReloaderConfig watching the resource
+
+	// ReloadRuleReloadRequested is a manual reload requested with reloader.stakater.com/reload-requested
+	ReloadRuleReloadRequested = "reload-requested"
+)
+
+// ObjectWatch is a ReloaderConfig watching an object of an arbitrary kind
+// through one of its spec.watchedResources.objects entries
+type ObjectWatch struct {
+	Config *reloaderv1alpha1.ReloaderConfig
+	Object reloaderv1alpha1.WatchedObject
 }
 
 // Finder discovers workloads that need to be reloaded
 type Finder struct {
 	client.Client
+
+	// Indexed enables lookups through the IndexResourceReferences field index
+	// It must only be set once SetupIndexes registered the index on the client's cache.
+	Indexed bool
+
+	// ClassName is the class of the operator instance (see --reloader-class-name)
+	// ReloaderConfigs and workloads of other classes are left to their own instance.
+	ClassName string
+
+	// SecretProviderClassReader reads the SecretProviderClasses mounted by workloads
+	// It should be the manager's cache: the client reads unstructured objects from
+	// the API server, one request per class and workload. Nil means the client.
+	SecretProviderClassReader client.Reader
 }
 
 // NewFinder creates a new workload finder
@@ -55,6 +99,10 @@ func (f *Finder) FindReloaderConfigsWatchingResource(
 	ctx context.Context,
 	resourceKind, resourceName, resourceNamespace string,
 ) ([]*reloaderv1alpha1.ReloaderConfig, error) {
+	if f.Indexed {
+		return f.findReloaderConfigsWatchingResourceIndexed(ctx, resourceKind, resourceName, resourceNamespace)
+	}
+
 	logger := log.FromContext(ctx)
 
 	// List all ReloaderConfigs in the same namespace
@@ -68,10 +116,13 @@ func (f *Finder) FindReloaderConfigsWatchingResource(
 	for i := range configList.Items {
 		config := &configList.Items[i]
 
-		// Skip if ignored
+		// Skip if ignored or handled by another instance
 		if config.Annotations != nil && config.Annotations[util.AnnotationIgnore] == "true" {
 			continue
 		}
+		if !f.configInClass(config) {
+			continue
+		}
 
 		// Check if this config explicitly watches the resource
 		if f.configWatchesResource(config, resourceKind, resourceName) {
@@ -97,6 +148,119 @@ func (f *Finder) FindReloaderConfigsWatchingResource(
 	return result, nil
 }
 
+// findReloaderConfigsWatchingResourceIndexed is FindReloaderConfigsWatchingResource using the field indexes
+//
+// Business Logic:
+// Explicit watchers are looked up in IndexWatchedResources. For autoReloadAll,
+// the lookup goes the other way round: the workloads referencing the resource
+// come from IndexResourceReferences, and the ReloaderConfigs targeting them from
+// IndexTargetWorkloads. Neither lookup touches unrelated configs or workloads.
+func (f *Finder) findReloaderConfigsWatchingResourceIndexed(
+	ctx context.Context,
+	resourceKind, resourceName, resourceNamespace string,
+) ([]*reloaderv1alpha1.ReloaderConfig, error) {
+	logger := log.FromContext(ctx)
+
+	configList := &reloaderv1alpha1.ReloaderConfigList{}
+	if err := f.List(ctx, configList,
+		client.InNamespace(resourceNamespace),
+		client.MatchingFields{IndexWatchedResources: util.ReferenceKey(resourceKind, resourceName)},
+	); err != nil {
+		return nil, err
+	}
+
+	result := []*reloaderv1alpha1.ReloaderConfig{}
+	found := map[string]bool{}
+	for i := range configList.Items {
+		config := &configList.Items[i]
+		if config.Annotations[util.AnnotationIgnore] == "true" || !f.configInClass(config) {
+			continue
+		}
+
+		found[config.Name] = true
+		result = append(result, config)
+		logger.V(1).Info("Found ReloaderConfig watching resource",
+			"config", config.Name,
+			"resource", resourceKind+"/"+resourceName)
+	}
+
+	for _, workloadKind := range annotatedWorkloadKinds {
+		candidates, err := f.listCandidateWorkloads(ctx, workloadKind.newList, resourceNamespace, resourceKind, resourceName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range candidates {
+			configs, err := f.FindReloaderConfigsTargetingWorkload(ctx, workloadKind.kind, obj.GetNamespace(), obj.GetName())
+			if err != nil {
+				return nil, err
+			}
+
+			// The pod spec is only checked once an autoReloadAll config targets the workload
+			referenced := false
+			checked := false
+			for _, config := range configs {
+				if config.Namespace != resourceNamespace || found[config.Name] || !config.Spec.AutoReloadAll ||
+					config.Annotations[util.AnnotationIgnore] == "true" || !f.configInClass(config) {
+					continue
+				}
+
+				if !checked {
+					checked = true
+					if template, err := util.GetPodTemplate(obj); err == nil {
+						referenced = f.templateReferencesResource(ctx, template, resourceNamespace, resourceKind, resourceName)
+					}
+				}
+				if !referenced {
+					break
+				}
+
+				found[config.Name] = true
+				result = append(result, config)
+				logger.V(1).Info("Found ReloaderConfig with autoReloadAll referencing resource",
+					"config", config.Name,
+					"resource", resourceKind+"/"+resourceName)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// FindReloaderConfigsTargetingWorkload finds all ReloaderConfigs, in any namespace, that target a workload
+//
+// This is the reverse of spec.targets, used by discovery, status and tooling.
+// Ignored ReloaderConfigs are included; callers decide whether they apply.
+func (f *Finder) FindReloaderConfigsTargetingWorkload(
+	ctx context.Context,
+	kind, namespace, name string,
+) ([]*reloaderv1alpha1.ReloaderConfig, error) {
+	workloadKey := util.MakeResourceKey(namespace, kind, name)
+
+	configList := &reloaderv1alpha1.ReloaderConfigList{}
+	if f.Indexed {
+		if err := f.List(ctx, configList, client.MatchingFields{IndexTargetWorkloads: workloadKey}); err != nil {
+			return nil, err
+		}
+	} else if err := f.List(ctx, configList); err != nil {
+		return nil, err
+	}
+
+	result := []*reloaderv1alpha1.ReloaderConfig{}
+	for i := range configList.Items {
+		config := &configList.Items[i]
+		if util.ContainsString(TargetWorkloadsIndexFunc(config), workloadKey) {
+			result = append(result, config)
+		}
+	}
+	return result, nil
+}
+
+// configInClass checks if a ReloaderConfig belongs to the class of this instance
+func (f *Finder) configInClass(config *reloaderv1alpha1.ReloaderConfig) bool {
+	return config.Spec.ReloaderClassName == f.ClassName
+}
+
 // configWatchesResource checks if a ReloaderConfig explicitly watches a resource
 func (f *Finder) configWatchesResource(config *reloaderv1alpha1.ReloaderConfig, kind, name string) bool {
 	if config.Spec.WatchedResources == nil {
@@ -113,6 +277,80 @@ func (f *Finder) configWatchesResource(config *reloaderv1alpha1.ReloaderConfig,
 	return util.ContainsString(watchList, name)
 }
 
+// FindReloaderConfigsWatchingObject finds all ReloaderConfigs that watch an object of an arbitrary kind
+//
+// Business Logic:
+// An object is watched when a spec.watchedResources.objects entry has the same
+// GroupVersionKind and either names the object or has a selector matching its
+// labels. Each ReloaderConfig is returned once, with its first matching entry.
+func (f *Finder) FindReloaderConfigsWatchingObject(
+	ctx context.Context,
+	gvk schema.GroupVersionKind,
+	objectName, objectNamespace string,
+	objectLabels map[string]string,
+) ([]ObjectWatch, error) {
+	logger := log.FromContext(ctx)
+
+	configList := &reloaderv1alpha1.ReloaderConfigList{}
+	if err := f.List(ctx, configList, client.InNamespace(objectNamespace)); err != nil {
+		return nil, err
+	}
+
+	result := []ObjectWatch{}
+	for i := range configList.Items {
+		config := &configList.Items[i]
+
+		// Skip if ignored or handled by another instance
+		if config.Annotations != nil && config.Annotations[util.AnnotationIgnore] == "true" {
+			continue
+		}
+		if !f.configInClass(config) {
+			continue
+		}
+		if config.Spec.WatchedResources == nil {
+			continue
+		}
+
+		for _, watched := range config.Spec.WatchedResources.Objects {
+			if WatchedObjectMatches(watched, gvk, objectName, objectLabels) {
+				result = append(result, ObjectWatch{Config: config, Object: watched})
+				logger.V(1).Info("Found ReloaderConfig watching object",
+					"config", config.Name,
+					"object", gvk.Kind+"/"+objectName)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// WatchedObjectMatches checks if a watched object entry selects the given object
+func WatchedObjectMatches(
+	watched reloaderv1alpha1.WatchedObject,
+	gvk schema.GroupVersionKind,
+	objectName string,
+	objectLabels map[string]string,
+) bool {
+	if watched.APIVersion != gvk.GroupVersion().String() || watched.Kind != gvk.Kind {
+		return false
+	}
+
+	if watched.Name != "" {
+		return watched.Name == objectName
+	}
+
+	if watched.Selector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(watched.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(objectLabels))
+}
+
 // anyTargetReferencesResource checks if any target workload references the resource
 func (f *Finder) anyTargetReferencesResource(
 	ctx context.Context,
@@ -149,32 +387,64 @@ func (f *Finder) workloadReferencesResource(
 		if err := f.Get(ctx, key, deployment); err != nil {
 			return false
 		}
-		return podTemplateReferencesResource(&deployment.Spec.Template, resourceKind, resourceName)
+		return f.templateReferencesResource(ctx, &deployment.Spec.Template, workloadNamespace, resourceKind, resourceName)
 
 	case util.KindStatefulSet:
 		statefulSet := &appsv1.StatefulSet{}
 		if err := f.Get(ctx, key, statefulSet); err != nil {
 			return false
 		}
-		return podTemplateReferencesResource(&statefulSet.Spec.Template, resourceKind, resourceName)
+		return f.templateReferencesResource(ctx, &statefulSet.Spec.Template, workloadNamespace, resourceKind, resourceName)
 
 	case util.KindDaemonSet:
 		daemonSet := &appsv1.DaemonSet{}
 		if err := f.Get(ctx, key, daemonSet); err != nil {
 			return false
 		}
-		return podTemplateReferencesResource(&daemonSet.Spec.Template, resourceKind, resourceName)
+		return f.templateReferencesResource(ctx, &daemonSet.Spec.Template, workloadNamespace, resourceKind, resourceName)
 	}
 
 	return false
 }
 
-// podTemplateReferencesResource checks if a pod template references a resource
-func podTemplateReferencesResource(template *corev1.PodTemplateSpec, resourceKind, resourceName string) bool {
-	if template == nil {
-		return false
+// annotatedWorkloadKinds are the workload kinds discovered through annotations
+// The pause period keys are pointers: ConfigureAnnotationKeys may move them after this is initialized.
+var annotatedWorkloadKinds = []struct {
+	kind                  string
+	newList               func() client.ObjectList
+	pausePeriodAnnotation *string
+}{
+	{util.KindDeployment, func() client.ObjectList { return &appsv1.DeploymentList{} }, &util.AnnotationDeploymentPausePeriod},
+	{util.KindStatefulSet, func() client.ObjectList { return &appsv1.StatefulSetList{} }, &util.AnnotationStatefulSetPausePeriod},
+	{util.KindDaemonSet, func() client.ObjectList { return &appsv1.DaemonSetList{} }, &util.AnnotationDaemonSetPausePeriod},
+}
+
+// AnnotatedTarget returns the target of a workload configured through annotations
+// The rollout strategy, pause period and notify settings come from the workload's own
+// annotations; the Rule is left for the caller to set.
+func AnnotatedTarget(kind string, obj client.Object) Target {
+	annotations := obj.GetAnnotations()
+	target := Target{
+		Kind:      kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		RolloutStrategy: util.GetDefaultRolloutStrategy(
+			annotations[util.AnnotationRolloutStrategy],
+			util.RolloutStrategyRollout,
+		),
+		ReloadStrategy: util.GetDefaultReloadStrategy(
+			"", // No annotation for reload strategy in annotation-based mode
+			util.ReloadStrategyEnvVars,
+		),
+		Config: nil, // No ReloaderConfig for annotation-based
+		Notify: NotifyConfigFromAnnotations(annotations),
 	}
-	return util.CheckPodSpecReferencesResource(&template.Spec, resourceKind, resourceName)
+	for _, workloadKind := range annotatedWorkloadKinds {
+		if workloadKind.kind == kind {
+			target.PausePeriod = annotations[*workloadKind.pausePeriodAnnotation]
+		}
+	}
+	return target
 }
 
 // FindWorkloadsWithAnnotations finds workloads that have annotation-based reload config
@@ -186,154 +456,150 @@ func (f *Finder) FindWorkloadsWithAnnotations(
 	logger := log.FromContext(ctx)
 	targets := []Target{}
 
-	// Check Deployments
-	deployments := &appsv1.DeploymentList{}
-	if err := f.List(ctx, deployments, client.InNamespace(resourceNamespace)); err != nil {
-		return nil, err
-	}
+	// Check Deployments, StatefulSets and DaemonSets
+	for _, workloadKind := range annotatedWorkloadKinds {
+		workloads, err := f.listCandidateWorkloads(ctx, workloadKind.newList, resourceNamespace, resourceKind, resourceName)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, deploy := range deployments.Items {
-		if shouldReloadFromAnnotations(&deploy, resourceKind, resourceName, resourceAnnotations) {
-			rolloutStrategy := util.GetDefaultRolloutStrategy(
-				deploy.Annotations[util.AnnotationRolloutStrategy],
-				util.RolloutStrategyRollout,
-			)
-			reloadStrategy := util.GetDefaultReloadStrategy(
-				"", // No annotation for reload strategy in annotation-based mode
-				util.ReloadStrategyEnvVars,
-			)
-			pausePeriod := deploy.Annotations[util.AnnotationDeploymentPausePeriod]
-
-			targets = append(targets, Target{
-				Kind:            util.KindDeployment,
-				Name:            deploy.Name,
-				Namespace:       deploy.Namespace,
-				RolloutStrategy: rolloutStrategy,
-				ReloadStrategy:  reloadStrategy,
-				PausePeriod:     pausePeriod,
-				Config:          nil, // No ReloaderConfig for annotation-based
-			})
-
-			logger.V(1).Info("Found Deployment with annotations",
-				"deployment", deploy.Name,
+		for _, obj := range workloads {
+			rule := f.reloadRuleFromAnnotations(ctx, obj, resourceKind, resourceName, resourceAnnotations)
+			if rule == "" {
+				continue
+			}
+
+			target := AnnotatedTarget(workloadKind.kind, obj)
+			target.Rule = rule
+			targets = append(targets, target)
+
+			logger.V(1).Info("Found "+workloadKind.kind+" with annotations",
+				"workload", obj.GetName(),
 				"resource", resourceKind+"/"+resourceName)
 		}
 	}
 
-	// Check StatefulSets
-	statefulSets := &appsv1.StatefulSetList{}
-	if err := f.List(ctx, statefulSets, client.InNamespace(resourceNamespace)); err != nil {
-		return nil, err
-	}
+	return targets, nil
+}
 
-	for _, sts := range statefulSets.Items {
-		if shouldReloadFromAnnotations(&sts, resourceKind, resourceName, resourceAnnotations) {
-			rolloutStrategy := util.GetDefaultRolloutStrategy(
-				sts.Annotations[util.AnnotationRolloutStrategy],
-				util.RolloutStrategyRollout,
-			)
-			reloadStrategy := util.GetDefaultReloadStrategy(
-				"", // No annotation for reload strategy in annotation-based mode
-				util.ReloadStrategyEnvVars,
-			)
-			pausePeriod := sts.Annotations[util.AnnotationStatefulSetPausePeriod]
-
-			targets = append(targets, Target{
-				Kind:            util.KindStatefulSet,
-				Name:            sts.Name,
-				Namespace:       sts.Namespace,
-				RolloutStrategy: rolloutStrategy,
-				ReloadStrategy:  reloadStrategy,
-				PausePeriod:     pausePeriod,
-				Config:          nil,
-			})
-
-			logger.V(1).Info("Found StatefulSet with annotations",
-				"statefulset", sts.Name,
-				"resource", resourceKind+"/"+resourceName)
+// listCandidateWorkloads lists the workloads of one kind that may reload for a resource
+//
+// Business Logic:
+// With Indexed set, only the workloads indexed under the resource (see
+// ResourceReferencesIndexFunc) are returned, instead of every workload in the
+// namespace. Callers still apply the full reload rules to each candidate.
+func (f *Finder) listCandidateWorkloads(
+	ctx context.Context,
+	newList func() client.ObjectList,
+	namespace, resourceKind, resourceName string,
+) ([]client.Object, error) {
+	if !f.Indexed {
+		list := newList()
+		if err := f.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, err
 		}
+		return listItems(list)
 	}
 
-	// Check DaemonSets
-	daemonSets := &appsv1.DaemonSetList{}
-	if err := f.List(ctx, daemonSets, client.InNamespace(resourceNamespace)); err != nil {
-		return nil, err
-	}
+	workloads := []client.Object{}
+	seen := map[string]bool{}
+	for _, key := range candidateIndexKeys(resourceKind, resourceName) {
+		list := newList()
+		if err := f.List(ctx, list, client.InNamespace(namespace), client.MatchingFields{IndexResourceReferences: key}); err != nil {
+			return nil, err
+		}
 
-	for _, ds := range daemonSets.Items {
-		if shouldReloadFromAnnotations(&ds, resourceKind, resourceName, resourceAnnotations) {
-			rolloutStrategy := util.GetDefaultRolloutStrategy(
-				ds.Annotations[util.AnnotationRolloutStrategy],
-				util.RolloutStrategyRollout,
-			)
-			reloadStrategy := util.GetDefaultReloadStrategy(
-				"", // No annotation for reload strategy in annotation-based mode
-				util.ReloadStrategyEnvVars,
-			)
-			pausePeriod := ds.Annotations[util.AnnotationDaemonSetPausePeriod]
-
-			targets = append(targets, Target{
-				Kind:            util.KindDaemonSet,
-				Name:            ds.Name,
-				Namespace:       ds.Namespace,
-				RolloutStrategy: rolloutStrategy,
-				ReloadStrategy:  reloadStrategy,
-				PausePeriod:     pausePeriod,
-				Config:          nil,
-			})
-
-			logger.V(1).Info("Found DaemonSet with annotations",
-				"daemonset", ds.Name,
-				"resource", resourceKind+"/"+resourceName)
+		items, err := listItems(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range items {
+			if !seen[obj.GetName()] {
+				seen[obj.GetName()] = true
+				workloads = append(workloads, obj)
+			}
 		}
 	}
+	return workloads, nil
+}
 
-	return targets, nil
+// listItems returns the items of a typed object list
+func listItems(list client.ObjectList) ([]client.Object, error) {
+	items := []client.Object{}
+	err := meta.EachListItem(list, func(item runtime.Object) error {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return fmt.Errorf("unexpected list item type: %T", item)
+		}
+		items = append(items, obj)
+		return nil
+	})
+	return items, err
 }
 
 // shouldReloadFromAnnotations checks if a workload should reload based on annotations
-func shouldReloadFromAnnotations(obj client.Object, resourceKind, resourceName string, resourceAnnotations map[string]string) bool {
+func (f *Finder) shouldReloadFromAnnotations(
+	ctx context.Context,
+	obj client.Object,
+	resourceKind, resourceName string,
+	resourceAnnotations map[string]string,
+) bool {
+	return f.reloadRuleFromAnnotations(ctx, obj, resourceKind, resourceName, resourceAnnotations) != ""
+}
+
+// reloadRuleFromAnnotations returns the first rule (see ReloadRule*) under which a workload reloads, or ""
+func (f *Finder) reloadRuleFromAnnotations(
+	ctx context.Context,
+	obj client.Object,
+	resourceKind, resourceName string,
+	resourceAnnotations map[string]string,
+) string {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
-		return false
+		return ""
 	}
 
 	// Check if ignored
 	if annotations[util.AnnotationIgnore] == "true" {
-		return false
+		return ""
+	}
+
+	// Workloads of another class are reloaded by their own instance
+	if !util.InClass(annotations, f.ClassName) {
+		return ""
 	}
 
-	// Get pod template spec based on workload type
-	var podSpec *corev1.PodSpec
+	// Get pod template based on workload type
+	var template *corev1.PodTemplateSpec
 	switch v := obj.(type) {
 	case *appsv1.Deployment:
-		podSpec = &v.Spec.Template.Spec
+		template = &v.Spec.Template
 	case *appsv1.StatefulSet:
-		podSpec = &v.Spec.Template.Spec
+		template = &v.Spec.Template
 	case *appsv1.DaemonSet:
-		podSpec = &v.Spec.Template.Spec
+		template = &v.Spec.Template
 	}
 
 	// Rule 1: Check auto-reload (takes precedence over search)
 	autoValue := annotations[util.AnnotationAuto]
 	if autoValue == "true" {
-		if podSpec != nil && workloadReferencesResource(podSpec, resourceKind, resourceName) {
-			return true
+		if template != nil && f.templateReferencesResource(ctx, template, obj.GetNamespace(), resourceKind, resourceName) {
+			return ReloadRuleAuto
 		}
 	} else if autoValue == "false" {
 		// Explicitly disabled - no reload regardless of other annotations
-		return false
+		return ""
 	}
 
 	// Rule 2: Check type-specific auto
 	if resourceKind == util.KindSecret && annotations[util.AnnotationSecretAuto] == "true" {
-		if podSpec != nil && workloadReferencesResource(podSpec, resourceKind, resourceName) {
-			return true
+		if template != nil && f.templateReferencesResource(ctx, template, obj.GetNamespace(), resourceKind, resourceName) {
+			return ReloadRuleTypeAuto
 		}
 	}
 	if resourceKind == util.KindConfigMap && annotations[util.AnnotationConfigMapAuto] == "true" {
-		if podSpec != nil && workloadReferencesResource(podSpec, resourceKind, resourceName) {
-			return true
+		if template != nil && f.templateReferencesResource(ctx, template, obj.GetNamespace(), resourceKind, resourceName) {
+			return ReloadRuleTypeAuto
 		}
 	}
 
@@ -347,7 +613,10 @@ func shouldReloadFromAnnotations(obj client.Object, resourceKind, resourceName s
 
 	if reloadList != "" {
 		names := util.ParseCommaSeparatedList(reloadList)
-		return util.ContainsString(names, resourceName)
+		if util.ContainsString(names, resourceName) {
+			return ReloadRuleNamedReload
+		}
+		return ""
 	}
 
 	// Rule 4: Check targeted reload (search + match)
@@ -358,16 +627,36 @@ func shouldReloadFromAnnotations(obj client.Object, resourceKind, resourceName s
 		// Check if resource has match annotation
 		if resourceAnnotations != nil && resourceAnnotations[util.AnnotationMatch] == "true" {
 			// Check if resource is referenced in pod spec
-			if podSpec != nil && workloadReferencesResource(podSpec, resourceKind, resourceName) {
-				return true
+			if template != nil && f.templateReferencesResource(ctx, template, obj.GetNamespace(), resourceKind, resourceName) {
+				return ReloadRuleSearchMatch
 			}
 		}
 	}
 
-	return false
+	return ""
+}
+
+// SecretProviderClasses returns the reader to resolve the Secrets synced by SecretProviderClasses with
+func (f *Finder) SecretProviderClasses() client.Reader {
+	if f.SecretProviderClassReader != nil {
+		return f.SecretProviderClassReader
+	}
+	return f.Client
 }
 
-// workloadReferencesResource checks if a pod spec references a specific resource
-func workloadReferencesResource(podSpec *corev1.PodSpec, resourceKind, resourceName string) bool {
-	return util.CheckPodSpecReferencesResource(podSpec, resourceKind, resourceName)
+// templateReferencesResource checks if a pod template references a specific resource,
+// including Secrets synced from mounted SecretProviderClasses and versioned copies.
+// Lookup errors are logged and treated as "not referenced".
+func (f *Finder) templateReferencesResource(
+	ctx context.Context,
+	template *corev1.PodTemplateSpec,
+	namespace, resourceKind, resourceName string,
+) bool {
+	references, err := util.PodTemplateReferencesResource(ctx, f.SecretProviderClasses(), template, namespace, resourceKind, resourceName)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to resolve resource references",
+			"resource", resourceKind+"/"+resourceName, "namespace", namespace)
+		return false
+	}
+	return references
 }
@@ -23,7 +23,9 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
@@ -46,6 +48,7 @@ func TestFindReloaderConfigsWatchingResource(t *testing.T) {
 		resourceKind     string
 		resourceName     string
 		resourceNS       string
+		className        string
 		expectedCount    int
 		expectAutoReload bool
 	}{
@@ -138,6 +141,29 @@ func TestFindReloaderConfigsWatchingResource(t *testing.T) {
 			resourceNS:    "default",
 			expectedCount: 0,
 		},
+		{
+			name: "only finds configs of its own class",
+			configs: []*reloaderv1alpha1.ReloaderConfig{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "classless", Namespace: "default"},
+					Spec: reloaderv1alpha1.ReloaderConfigSpec{
+						WatchedResources: &reloaderv1alpha1.WatchedResources{Secrets: []string{"my-secret"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: "default"},
+					Spec: reloaderv1alpha1.ReloaderConfigSpec{
+						WatchedResources:  &reloaderv1alpha1.WatchedResources{Secrets: []string{"my-secret"}},
+						ReloaderClassName: "blue",
+					},
+				},
+			},
+			resourceKind:  util.KindSecret,
+			resourceName:  "my-secret",
+			resourceNS:    "default",
+			className:     "blue",
+			expectedCount: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -149,6 +175,7 @@ func TestFindReloaderConfigsWatchingResource(t *testing.T) {
 
 			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
 			finder := NewFinder(fakeClient)
+			finder.ClassName = tt.className
 
 			configs, err := finder.FindReloaderConfigsWatchingResource(
 				context.Background(),
@@ -169,6 +196,104 @@ func TestFindReloaderConfigsWatchingResource(t *testing.T) {
 	}
 }
 
+func TestFindReloaderConfigsWatchingObject(t *testing.T) {
+	certificateGVK := schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+	configs := []runtime.Object{
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "by-name", Namespace: "default"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				WatchedResources: &reloaderv1alpha1.WatchedResources{
+					Objects: []reloaderv1alpha1.WatchedObject{
+						{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "api-tls", JSONPaths: []string{"{.status.revision}"}},
+					},
+				},
+			},
+		},
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "by-selector", Namespace: "default"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				WatchedResources: &reloaderv1alpha1.WatchedResources{
+					Objects: []reloaderv1alpha1.WatchedObject{
+						{
+							APIVersion: "cert-manager.io/v1",
+							Kind:       "Certificate",
+							Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+						},
+					},
+				},
+			},
+		},
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-version", Namespace: "default"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				WatchedResources: &reloaderv1alpha1.WatchedResources{
+					Objects: []reloaderv1alpha1.WatchedObject{
+						{APIVersion: "cert-manager.io/v1alpha2", Kind: "Certificate", Name: "api-tls"},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		objectName    string
+		objectLabels  map[string]string
+		expectedNames []string
+	}{
+		{
+			name:          "matches by name",
+			objectName:    "api-tls",
+			expectedNames: []string{"by-name"},
+		},
+		{
+			name:          "matches by name and selector",
+			objectName:    "api-tls",
+			objectLabels:  map[string]string{"team": "payments"},
+			expectedNames: []string{"by-name", "by-selector"},
+		},
+		{
+			name:          "matches by selector only",
+			objectName:    "web-tls",
+			objectLabels:  map[string]string{"team": "payments"},
+			expectedNames: []string{"by-selector"},
+		},
+		{
+			name:          "no match",
+			objectName:    "web-tls",
+			objectLabels:  map[string]string{"team": "search"},
+			expectedNames: []string{},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(configs...).Build()
+	finder := NewFinder(fakeClient)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watches, err := finder.FindReloaderConfigsWatchingObject(
+				context.Background(), certificateGVK, tt.objectName, "default", tt.objectLabels)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names := []string{}
+			for _, watch := range watches {
+				names = append(names, watch.Config.Name)
+			}
+			if len(names) != len(tt.expectedNames) {
+				t.Fatalf("expected configs %v, got %v", tt.expectedNames, names)
+			}
+			for _, expected := range tt.expectedNames {
+				if !util.ContainsString(names, expected) {
+					t.Errorf("expected configs %v, got %v", tt.expectedNames, names)
+				}
+			}
+		})
+	}
+}
+
 func TestFindWorkloadsWithAnnotations(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -531,6 +656,7 @@ func TestShouldReloadFromAnnotations_Precedence(t *testing.T) {
 		workloadAnnotations map[string]string
 		resourceAnnotations map[string]string
 		expectedReload      bool
+		expectedRule        string
 		description         string
 	}{
 		{
@@ -541,6 +667,7 @@ func TestShouldReloadFromAnnotations_Precedence(t *testing.T) {
 			},
 			resourceAnnotations: map[string]string{},
 			expectedReload:      true,
+			expectedRule:        ReloadRuleAuto,
 			description:         "Auto annotation should take precedence, ignore search",
 		},
 		{
@@ -565,6 +692,7 @@ func TestShouldReloadFromAnnotations_Precedence(t *testing.T) {
 			},
 			resourceAnnotations: map[string]string{},
 			expectedReload:      true,
+			expectedRule:        ReloadRuleTypeAuto,
 			description:         "Type-specific auto should trigger reload",
 		},
 		{
@@ -574,8 +702,19 @@ func TestShouldReloadFromAnnotations_Precedence(t *testing.T) {
 			},
 			resourceAnnotations: map[string]string{},
 			expectedReload:      true,
+			expectedRule:        ReloadRuleNamedReload,
 			description:         "Named reload annotation should work",
 		},
+		{
+			name: "other class is skipped",
+			workloadAnnotations: map[string]string{
+				util.AnnotationAuto:  "true",
+				util.AnnotationClass: "blue",
+			},
+			resourceAnnotations: map[string]string{},
+			expectedReload:      false,
+			description:         "Workloads of another class should be left to their instance",
+		},
 	}
 
 	for _, tt := range tests {
@@ -619,7 +758,9 @@ func TestShouldReloadFromAnnotations_Precedence(t *testing.T) {
 				},
 			}
 
-			result := shouldReloadFromAnnotations(
+			finder := NewFinder(fake.NewClientBuilder().WithScheme(scheme).Build())
+			result := finder.shouldReloadFromAnnotations(
+				context.Background(),
 				deployment,
 				util.KindSecret,
 				"test-secret",
@@ -629,6 +770,106 @@ func TestShouldReloadFromAnnotations_Precedence(t *testing.T) {
 			if result != tt.expectedReload {
 				t.Errorf("%s: expected %v, got %v", tt.description, tt.expectedReload, result)
 			}
+
+			rule := finder.reloadRuleFromAnnotations(context.Background(), deployment, util.KindSecret, "test-secret", tt.resourceAnnotations)
+			if rule != tt.expectedRule {
+				t.Errorf("%s: expected rule %q, got %q", tt.description, tt.expectedRule, rule)
+			}
 		})
 	}
 }
+
+func TestAnnotatedTarget(t *testing.T) {
+	defer func() {
+		if err := util.ConfigureAnnotationKeys(util.DefaultAnnotationPrefix, ""); err != nil {
+			t.Fatalf("restoring the default keys failed: %v", err)
+		}
+	}()
+
+	// The pause period key must follow a configured prefix
+	if err := util.ConfigureAnnotationKeys("reloader.example.com", ""); err != nil {
+		t.Fatalf("ConfigureAnnotationKeys() error = %v", err)
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"reloader.example.com/rollout-strategy":         util.RolloutStrategyRestart,
+				"statefulset.reloader.example.com/pause-period": "5m",
+			},
+		},
+	}
+
+	target := AnnotatedTarget(util.KindStatefulSet, statefulSet)
+	if target.Kind != util.KindStatefulSet || target.Name != "db" || target.Namespace != "default" {
+		t.Errorf("target = %s %s/%s, want StatefulSet default/db", target.Kind, target.Namespace, target.Name)
+	}
+	if target.RolloutStrategy != util.RolloutStrategyRestart {
+		t.Errorf("RolloutStrategy = %q, want %q", target.RolloutStrategy, util.RolloutStrategyRestart)
+	}
+	if target.ReloadStrategy != util.ReloadStrategyEnvVars {
+		t.Errorf("ReloadStrategy = %q, want %q", target.ReloadStrategy, util.ReloadStrategyEnvVars)
+	}
+	if target.PausePeriod != "5m" {
+		t.Errorf("PausePeriod = %q, want %q", target.PausePeriod, "5m")
+	}
+	if target.Config != nil || target.Rule != "" {
+		t.Errorf("Config = %v, Rule = %q, want neither", target.Config, target.Rule)
+	}
+}
+
+func TestFinderSecretProviderClassReader(t *testing.T) {
+	spc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"provider":      "vault",
+			"secretObjects": []interface{}{map[string]interface{}{"secretName": "db-credentials"}},
+		},
+	}}
+	spc.SetGroupVersionKind(util.SecretProviderClassGVK)
+	spc.SetNamespace("default")
+	spc.SetName("vault-db")
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Namespace:   "default",
+			Annotations: map[string]string{util.AnnotationAuto: "true"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name: "secrets",
+						VolumeSource: corev1.VolumeSource{
+							CSI: &corev1.CSIVolumeSource{
+								Driver:           util.SecretsStoreCSIDriver,
+								VolumeAttributes: map[string]string{util.SecretProviderClassAttribute: "vault-db"},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	// The SecretProviderClass is only visible through the reader (the cache in the operator)
+	finder := NewFinder(fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build())
+	targets, err := finder.FindWorkloadsWithAnnotations(context.Background(), util.KindSecret, "db-credentials", "default", nil)
+	if err != nil {
+		t.Fatalf("FindWorkloadsWithAnnotations() error = %v", err)
+	}
+	if len(targets) != 0 {
+		t.Errorf("expected no targets without the SecretProviderClass, got %d", len(targets))
+	}
+
+	finder.SecretProviderClassReader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(spc).Build()
+	targets, err = finder.FindWorkloadsWithAnnotations(context.Background(), util.KindSecret, "db-credentials", "default", nil)
+	if err != nil {
+		t.Fatalf("FindWorkloadsWithAnnotations() error = %v", err)
+	}
+	if len(targets) != 1 || targets[0].Name != "api" {
+		t.Errorf("expected the workload mounting the SecretProviderClass, got %v", targets)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+const (
+	// IndexResourceReferences is the field index of Deployments, StatefulSets and DaemonSets
+	// by the Secrets and ConfigMaps they may reload for. Values are util.ReferenceKey keys.
+	IndexResourceReferences = "reloader.stakater.com/resource-references"
+
+	// IndexWatchedResources is the field index of ReloaderConfigs by the Secrets and
+	// ConfigMaps listed in spec.watchedResources. Values are util.ReferenceKey keys.
+	IndexWatchedResources = "reloader.stakater.com/watched-resources"
+
+	// IndexTargetWorkloads is the field index of ReloaderConfigs by their target workloads.
+	// Values are util.MakeResourceKey keys, so cross-namespace targets are indexed too.
+	IndexTargetWorkloads = "reloader.stakater.com/target-workloads"
+)
+
+// secretProviderClassIndexKey is indexed for workloads mounting a SecretProviderClass
+// The Secrets such a class syncs are only known by reading it, so these workloads
+// are candidates for every Secret.
+var secretProviderClassIndexKey = util.ReferenceKey("SecretProviderClass", "*")
+
+// indexedWorkloads are the workload types carrying the IndexResourceReferences index
+var indexedWorkloads = []client.Object{
+	&appsv1.Deployment{},
+	&appsv1.StatefulSet{},
+	&appsv1.DaemonSet{},
+}
+
+// SetupIndexes registers the field indexes used by the Finder
+//
+// Business Logic:
+// Without the indexes, every Secret/ConfigMap change lists every workload and
+// every ReloaderConfig in the namespace and inspects each of them. With them,
+// discovery is a lookup of the few objects that can possibly match the
+// resource; those are still checked in full (e.g., by shouldReloadFromAnnotations).
+// The cache re-evaluates the index functions on every update, so the indexes
+// follow spec and annotation changes without further bookkeeping.
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, obj := range indexedWorkloads {
+		if err := indexer.IndexField(ctx, obj, IndexResourceReferences, ResourceReferencesIndexFunc); err != nil {
+			return fmt.Errorf("failed to index %T by resource references: %w", obj, err)
+		}
+	}
+	if err := indexer.IndexField(ctx, &reloaderv1alpha1.ReloaderConfig{}, IndexWatchedResources, WatchedResourcesIndexFunc); err != nil {
+		return fmt.Errorf("failed to index ReloaderConfigs by watched resources: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &reloaderv1alpha1.ReloaderConfig{}, IndexTargetWorkloads, TargetWorkloadsIndexFunc); err != nil {
+		return fmt.Errorf("failed to index ReloaderConfigs by target workloads: %w", err)
+	}
+	return nil
+}
+
+// ResourceReferencesIndexFunc returns the IndexResourceReferences keys of a workload
+//
+// A workload is indexed under every resource that can make it reload:
+// - Secrets and ConfigMaps referenced by its pod spec, directly or through a versioned copy (auto, search + match, autoReloadAll)
+// - Secrets and ConfigMaps named in its reload annotations (named reload)
+// - secretProviderClassIndexKey if it mounts a SecretProviderClass
+func ResourceReferencesIndexFunc(obj client.Object) []string {
+	template, err := util.GetPodTemplate(obj)
+	if err != nil {
+		return nil
+	}
+	podSpec := &template.Spec
+
+	keys := util.GetPodTemplateReferences(template)
+	add := func(key string) {
+		if !util.ContainsString(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	annotations := obj.GetAnnotations()
+	for _, name := range util.ParseCommaSeparatedList(annotations[util.AnnotationSecretReload]) {
+		add(util.ReferenceKey(util.KindSecret, name))
+	}
+	for _, name := range util.ParseCommaSeparatedList(annotations[util.AnnotationConfigMapReload]) {
+		add(util.ReferenceKey(util.KindConfigMap, name))
+	}
+
+	if len(util.GetSecretProviderClassNames(podSpec)) > 0 {
+		add(secretProviderClassIndexKey)
+	}
+
+	return keys
+}
+
+// candidateIndexKeys returns the index keys of the workloads that may reload for a resource
+func candidateIndexKeys(resourceKind, resourceName string) []string {
+	keys := []string{util.ReferenceKey(resourceKind, resourceName)}
+	if resourceKind == util.KindSecret {
+		keys = append(keys, secretProviderClassIndexKey)
+	}
+	return keys
+}
+
+// WatchedResourcesIndexFunc returns the IndexWatchedResources keys of a ReloaderConfig
+func WatchedResourcesIndexFunc(obj client.Object) []string {
+	config, ok := obj.(*reloaderv1alpha1.ReloaderConfig)
+	if !ok || config.Spec.WatchedResources == nil {
+		return nil
+	}
+
+	keys := []string{}
+	for _, name := range config.Spec.WatchedResources.Secrets {
+		keys = append(keys, util.ReferenceKey(util.KindSecret, name))
+	}
+	for _, name := range config.Spec.WatchedResources.ConfigMaps {
+		keys = append(keys, util.ReferenceKey(util.KindConfigMap, name))
+	}
+	return keys
+}
+
+// TargetWorkloadsIndexFunc returns the IndexTargetWorkloads keys of a ReloaderConfig
+func TargetWorkloadsIndexFunc(obj client.Object) []string {
+	config, ok := obj.(*reloaderv1alpha1.ReloaderConfig)
+	if !ok {
+		return nil
+	}
+
+	keys := []string{}
+	for _, target := range config.Spec.Targets {
+		namespace := util.GetDefaultNamespace(target.Namespace, config.Namespace)
+		keys = append(keys, util.MakeResourceKey(namespace, target.Kind, target.Name))
+	}
+	return keys
+}
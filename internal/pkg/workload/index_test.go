@@ -0,0 +1,508 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+func newIndexedDeployment(name string, annotations map[string]string, podSpec corev1.PodSpec) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+func secretEnvPodSpec(secretName string) corev1.PodSpec {
+	return corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:  "app",
+			Image: "app",
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}},
+			}},
+		}},
+	}
+}
+
+func newIndexedClientBuilder() *fake.ClientBuilder {
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, obj := range indexedWorkloads {
+		builder = builder.WithIndex(obj, IndexResourceReferences, ResourceReferencesIndexFunc)
+	}
+	return builder.
+		WithIndex(&reloaderv1alpha1.ReloaderConfig{}, IndexWatchedResources, WatchedResourcesIndexFunc).
+		WithIndex(&reloaderv1alpha1.ReloaderConfig{}, IndexTargetWorkloads, TargetWorkloadsIndexFunc)
+}
+
+func TestResourceReferencesIndexFunc(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Name: "init",
+			Env: []corev1.EnvVar{{
+				Name: "TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "init-token"},
+						Key:                  "token",
+					},
+				},
+			}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "app",
+			EnvFrom: []corev1.EnvFromSource{{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+			}},
+		}},
+		Volumes: []corev1.Volume{
+			{
+				Name: "projected",
+				VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}},
+					}},
+				}},
+			},
+			{
+				Name: "vault",
+				VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{
+					Driver:           util.SecretsStoreCSIDriver,
+					VolumeAttributes: map[string]string{util.SecretProviderClassAttribute: "vault-db"},
+				}},
+			},
+		},
+	}
+	deployment := newIndexedDeployment("app", map[string]string{
+		util.AnnotationSecretReload:    "named-secret, tls",
+		util.AnnotationConfigMapReload: "named-config",
+	}, podSpec)
+
+	keys := ResourceReferencesIndexFunc(deployment)
+	expected := []string{
+		util.ReferenceKey(util.KindSecret, "init-token"),
+		util.ReferenceKey(util.KindConfigMap, "app-config"),
+		util.ReferenceKey(util.KindSecret, "tls"),
+		util.ReferenceKey(util.KindSecret, "named-secret"),
+		util.ReferenceKey(util.KindConfigMap, "named-config"),
+		secretProviderClassIndexKey,
+	}
+
+	slices.Sort(keys)
+	slices.Sort(expected)
+	if !slices.Equal(keys, expected) {
+		t.Errorf("ResourceReferencesIndexFunc() = %v, want %v", keys, expected)
+	}
+
+	if keys := ResourceReferencesIndexFunc(&corev1.Secret{}); keys != nil {
+		t.Errorf("ResourceReferencesIndexFunc(Secret) = %v, want nil", keys)
+	}
+}
+
+func TestFindWorkloadsWithAnnotations_Indexed(t *testing.T) {
+	objects := []client.Object{
+		newIndexedDeployment("auto", map[string]string{util.AnnotationAuto: "true"}, secretEnvPodSpec("db")),
+		newIndexedDeployment("auto-other", map[string]string{util.AnnotationAuto: "true"}, secretEnvPodSpec("other")),
+		newIndexedDeployment("named", map[string]string{util.AnnotationSecretReload: "db"}, corev1.PodSpec{}),
+		newIndexedDeployment("ignored", map[string]string{
+			util.AnnotationAuto:   "true",
+			util.AnnotationIgnore: "true",
+		}, secretEnvPodSpec("db")),
+		newIndexedDeployment("unannotated", nil, secretEnvPodSpec("db")),
+	}
+
+	// References rewritten by the versioned-copy strategy still lead back to the original
+	versioned := newIndexedDeployment("versioned", map[string]string{util.AnnotationAuto: "true"}, secretEnvPodSpec("db-0123456789"))
+	if err := util.SetVersionedCopy(&versioned.Spec.Template, util.KindSecret, "db", "db-0123456789"); err != nil {
+		t.Fatalf("SetVersionedCopy() error = %v", err)
+	}
+	objects = append(objects, versioned)
+
+	for _, indexed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("indexed=%v", indexed), func(t *testing.T) {
+			finder := NewFinder(newIndexedClientBuilder().WithObjects(objects...).Build())
+			finder.Indexed = indexed
+
+			targets, err := finder.FindWorkloadsWithAnnotations(context.Background(), util.KindSecret, "db", "default", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names := []string{}
+			for _, target := range targets {
+				names = append(names, target.Name)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, []string{"auto", "named", "versioned"}) {
+				t.Errorf("FindWorkloadsWithAnnotations() = %v, want [auto named versioned]", names)
+			}
+		})
+	}
+}
+
+func TestFindReloaderConfigsWatchingResource_Indexed(t *testing.T) {
+	config := &reloaderv1alpha1.ReloaderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+		Spec: reloaderv1alpha1.ReloaderConfigSpec{
+			AutoReloadAll: true,
+			Targets: []reloaderv1alpha1.TargetWorkload{
+				{Kind: util.KindDeployment, Name: "app"},
+			},
+		},
+	}
+	objects := []client.Object{
+		config,
+		newIndexedDeployment("app", nil, secretEnvPodSpec("db")),
+		newIndexedDeployment("unrelated", nil, secretEnvPodSpec("other")),
+	}
+
+	finder := NewFinder(newIndexedClientBuilder().WithObjects(objects...).Build())
+	finder.Indexed = true
+
+	tests := []struct {
+		resourceName  string
+		expectedCount int
+	}{
+		{resourceName: "db", expectedCount: 1},
+		{resourceName: "other", expectedCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceName, func(t *testing.T) {
+			matches, err := finder.FindReloaderConfigsWatchingResource(context.Background(), util.KindSecret, tt.resourceName, "default")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(matches) != tt.expectedCount {
+				t.Errorf("expected %d configs, got %d", tt.expectedCount, len(matches))
+			}
+		})
+	}
+}
+
+func TestFindReloaderConfigsWatchingResource_IndexedMatchesFullScan(t *testing.T) {
+	objects := []client.Object{
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "explicit", Namespace: "default"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				WatchedResources: &reloaderv1alpha1.WatchedResources{Secrets: []string{"db"}},
+				Targets:          []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: "unrelated"}},
+			},
+		},
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "explicit-and-auto", Namespace: "default"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				AutoReloadAll:    true,
+				WatchedResources: &reloaderv1alpha1.WatchedResources{Secrets: []string{"db"}},
+				Targets:          []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: "app"}},
+			},
+		},
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "auto", Namespace: "default"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				AutoReloadAll: true,
+				Targets:       []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: "app"}},
+			},
+		},
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-auto", Namespace: "default"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				Targets: []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: "app"}},
+			},
+		},
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "ignored",
+				Namespace:   "default",
+				Annotations: map[string]string{util.AnnotationIgnore: "true"},
+			},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				WatchedResources: &reloaderv1alpha1.WatchedResources{Secrets: []string{"db"}},
+			},
+		},
+		&reloaderv1alpha1.ReloaderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+			Spec: reloaderv1alpha1.ReloaderConfigSpec{
+				AutoReloadAll: true,
+				Targets:       []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: "app", Namespace: "default"}},
+			},
+		},
+		newIndexedDeployment("app", nil, secretEnvPodSpec("db")),
+	}
+
+	var results [][]string
+	for _, indexed := range []bool{false, true} {
+		finder := NewFinder(newIndexedClientBuilder().WithObjects(objects...).Build())
+		finder.Indexed = indexed
+
+		configs, err := finder.FindReloaderConfigsWatchingResource(context.Background(), util.KindSecret, "db", "default")
+		if err != nil {
+			t.Fatalf("indexed=%v: unexpected error: %v", indexed, err)
+		}
+
+		names := []string{}
+		for _, config := range configs {
+			names = append(names, config.Name)
+		}
+		slices.Sort(names)
+		results = append(results, names)
+	}
+
+	expected := []string{"auto", "explicit", "explicit-and-auto"}
+	if !slices.Equal(results[0], expected) || !slices.Equal(results[1], expected) {
+		t.Errorf("full scan = %v, indexed = %v, want %v", results[0], results[1], expected)
+	}
+}
+
+func TestFindReloaderConfigsTargetingWorkload(t *testing.T) {
+	config := &reloaderv1alpha1.ReloaderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+		Spec: reloaderv1alpha1.ReloaderConfigSpec{
+			Targets: []reloaderv1alpha1.TargetWorkload{
+				{Kind: util.KindDeployment, Name: "app"},
+				{Kind: util.KindStatefulSet, Name: "db", Namespace: "data"},
+			},
+		},
+	}
+
+	for _, indexed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("indexed=%v", indexed), func(t *testing.T) {
+			c := newIndexedClientBuilder().WithObjects(config.DeepCopy()).Build()
+			finder := NewFinder(c)
+			finder.Indexed = indexed
+			ctx := context.Background()
+
+			lookup := func(kind, namespace, name string) int {
+				configs, err := finder.FindReloaderConfigsTargetingWorkload(ctx, kind, namespace, name)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return len(configs)
+			}
+
+			if n := lookup(util.KindDeployment, "default", "app"); n != 1 {
+				t.Errorf("Deployment default/app: expected 1 config, got %d", n)
+			}
+			if n := lookup(util.KindStatefulSet, "data", "db"); n != 1 {
+				t.Errorf("StatefulSet data/db: expected 1 config, got %d", n)
+			}
+			if n := lookup(util.KindStatefulSet, "default", "db"); n != 0 {
+				t.Errorf("StatefulSet default/db: expected 0 configs, got %d", n)
+			}
+
+			// The lookup follows spec changes
+			current := &reloaderv1alpha1.ReloaderConfig{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(config), current); err != nil {
+				t.Fatalf("failed to get config: %v", err)
+			}
+			current.Spec.Targets = []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: "api"}}
+			if err := c.Update(ctx, current); err != nil {
+				t.Fatalf("failed to update config: %v", err)
+			}
+
+			if n := lookup(util.KindDeployment, "default", "app"); n != 0 {
+				t.Errorf("Deployment default/app after update: expected 0 configs, got %d", n)
+			}
+			if n := lookup(util.KindDeployment, "default", "api"); n != 1 {
+				t.Errorf("Deployment default/api after update: expected 1 config, got %d", n)
+			}
+		})
+	}
+}
+
+// indexerClient serves Lists from client-go Indexers, like the manager's informer cache
+// The fake client evaluates field selectors by scanning every object, so it cannot show the
+// benefit of the indexes.
+type indexerClient struct {
+	client.Client
+	indexers map[reflect.Type]toolscache.Indexer
+}
+
+// allNamespaces prefixes the index values used for cluster-wide Lists
+const allNamespaces = "__all_namespaces"
+
+func newIndexerClient(objects []client.Object) *indexerClient {
+	c := &indexerClient{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		indexers: map[reflect.Type]toolscache.Indexer{},
+	}
+
+	indexed := map[client.ObjectList]map[string]client.IndexerFunc{
+		&appsv1.DeploymentList{}:  {IndexResourceReferences: ResourceReferencesIndexFunc},
+		&appsv1.StatefulSetList{}: {IndexResourceReferences: ResourceReferencesIndexFunc},
+		&appsv1.DaemonSetList{}:   {IndexResourceReferences: ResourceReferencesIndexFunc},
+		&reloaderv1alpha1.ReloaderConfigList{}: {
+			IndexWatchedResources: WatchedResourcesIndexFunc,
+			IndexTargetWorkloads:  TargetWorkloadsIndexFunc,
+		},
+	}
+	for list, fields := range indexed {
+		indexers := toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc}
+		for field, extract := range fields {
+			indexers[field] = func(item interface{}) ([]string, error) {
+				obj := item.(client.Object)
+				keys := []string{}
+				for _, key := range extract(obj) {
+					keys = append(keys, obj.GetNamespace()+"/"+key, allNamespaces+"/"+key)
+				}
+				return keys, nil
+			}
+		}
+		c.indexers[reflect.TypeOf(list)] = toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, indexers)
+	}
+
+	for _, obj := range objects {
+		for listType, indexer := range c.indexers {
+			if listType.Elem().Name() == reflect.TypeOf(obj).Elem().Name()+"List" {
+				_ = indexer.Add(obj)
+			}
+		}
+	}
+	return c
+}
+
+func (c *indexerClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	indexer, ok := c.indexers[reflect.TypeOf(list)]
+	if !ok {
+		return c.Client.List(ctx, list, opts...)
+	}
+
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	var items []interface{}
+	var err error
+	if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
+		requirement := listOpts.FieldSelector.Requirements()[0]
+		namespace := listOpts.Namespace
+		if namespace == "" {
+			namespace = allNamespaces
+		}
+		items, err = indexer.ByIndex(requirement.Field, namespace+"/"+requirement.Value)
+	} else if listOpts.Namespace != "" {
+		items, err = indexer.ByIndex(toolscache.NamespaceIndex, listOpts.Namespace)
+	} else {
+		items = indexer.List()
+	}
+	if err != nil {
+		return err
+	}
+
+	objects := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		objects = append(objects, item.(runtime.Object).DeepCopyObject())
+	}
+	return meta.SetList(list, objects)
+}
+
+// benchmarkFindWorkloadsWithAnnotations measures discovery among many unrelated workloads
+func benchmarkFindWorkloadsWithAnnotations(b *testing.B, indexed bool) {
+	objects := []client.Object{}
+	for i := range 500 {
+		objects = append(objects, newIndexedDeployment(
+			fmt.Sprintf("app-%d", i),
+			map[string]string{util.AnnotationAuto: "true"},
+			secretEnvPodSpec(fmt.Sprintf("secret-%d", i)),
+		))
+	}
+
+	finder := NewFinder(newIndexerClient(objects))
+	finder.Indexed = indexed
+
+	for b.Loop() {
+		targets, err := finder.FindWorkloadsWithAnnotations(context.Background(), util.KindSecret, "secret-42", "default", nil)
+		if err != nil || len(targets) != 1 {
+			b.Fatalf("FindWorkloadsWithAnnotations() = %d targets, err %v", len(targets), err)
+		}
+	}
+}
+
+func BenchmarkFindWorkloadsWithAnnotations_FullScan(b *testing.B) {
+	benchmarkFindWorkloadsWithAnnotations(b, false)
+}
+
+func BenchmarkFindWorkloadsWithAnnotations_Indexed(b *testing.B) {
+	benchmarkFindWorkloadsWithAnnotations(b, true)
+}
+
+// benchmarkFindReloaderConfigsWatchingResource measures discovery among many unrelated ReloaderConfigs
+func benchmarkFindReloaderConfigsWatchingResource(b *testing.B, indexed bool) {
+	objects := []client.Object{}
+	for i := range 250 {
+		objects = append(objects,
+			&reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("watch-%d", i), Namespace: "default"},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					WatchedResources: &reloaderv1alpha1.WatchedResources{Secrets: []string{fmt.Sprintf("watched-%d", i)}},
+					Targets:          []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: fmt.Sprintf("app-%d", i)}},
+				},
+			},
+			&reloaderv1alpha1.ReloaderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("auto-%d", i), Namespace: "default"},
+				Spec: reloaderv1alpha1.ReloaderConfigSpec{
+					AutoReloadAll: true,
+					Targets:       []reloaderv1alpha1.TargetWorkload{{Kind: util.KindDeployment, Name: fmt.Sprintf("app-%d", i)}},
+				},
+			},
+			newIndexedDeployment(fmt.Sprintf("app-%d", i), nil, secretEnvPodSpec(fmt.Sprintf("secret-%d", i))),
+		)
+	}
+
+	finder := NewFinder(newIndexerClient(objects))
+	finder.Indexed = indexed
+
+	for b.Loop() {
+		configs, err := finder.FindReloaderConfigsWatchingResource(context.Background(), util.KindSecret, "secret-42", "default")
+		if err != nil || len(configs) != 1 {
+			b.Fatalf("FindReloaderConfigsWatchingResource() = %d configs, err %v", len(configs), err)
+		}
+	}
+}
+
+func BenchmarkFindReloaderConfigsWatchingResource_FullScan(b *testing.B) {
+	benchmarkFindReloaderConfigsWatchingResource(b, false)
+}
+
+func BenchmarkFindReloaderConfigsWatchingResource_Indexed(b *testing.B) {
+	benchmarkFindReloaderConfigsWatchingResource(b, true)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+const (
+	// DefaultNotifyPath is the reload endpoint used when NotifyConfig.Path is empty
+	DefaultNotifyPath = "/-/reload"
+
+	// DefaultNotifyTimeout bounds each notification request when NotifyConfig.Timeout is empty
+	DefaultNotifyTimeout = 5 * time.Second
+
+	// maxParallelNotifications bounds the requests in flight for one workload
+	maxParallelNotifications = 10
+)
+
+// NotifyPods asks every Ready pod of a workload to reload its configuration in place
+//
+// Business Logic:
+// The "notify" rollout strategy leaves the pod template alone. Each Ready pod
+// receives a POST to http://<pod IP>:<port><path>; a 2xx answer
+// counts as success. Pods that are not Ready are skipped: they read the new
+// configuration when they start. A workload without any Ready pod is an error,
+// like a failed notification. The outcome for each pod is returned, sorted
+// by pod name, together with an error if any pod could not be notified, so the
+// caller can fall back to a rollout (see NotifyConfig.Fallback). NotifyPods
+// does not wait for the configured delay: the caller schedules it (see
+// NotifyDelay), so no worker is held while the kubelet refreshes volumes.
+func (u *Updater) NotifyPods(ctx context.Context, target Target) ([]reloaderv1alpha1.PodNotificationStatus, error) {
+	logger := log.FromContext(ctx)
+
+	config := target.Notify
+	if config == nil {
+		return nil, fmt.Errorf("the %s rollout strategy requires notify settings", util.RolloutStrategyNotify)
+	}
+	_, timeout, err := notifyDurations(config)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := u.readyPods(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing would read the new configuration, so the fallback decides
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no ready pods to notify")
+	}
+
+	path := config.Path
+	if path == "" {
+		path = DefaultNotifyPath
+	}
+
+	results := make([]reloaderv1alpha1.PodNotificationStatus, len(pods))
+	semaphore := make(chan struct{}, maxParallelNotifications)
+	var wg sync.WaitGroup
+	for i := range pods {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			results[i] = reloaderv1alpha1.PodNotificationStatus{Pod: pods[i].Name, Succeeded: true}
+			url := "http://" + net.JoinHostPort(pods[i].Status.PodIP, strconv.Itoa(int(config.Port))) + path
+			if err := u.notifyPod(ctx, url, timeout); err != nil {
+				results[i].Succeeded = false
+				results[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(results, func(a, b reloaderv1alpha1.PodNotificationStatus) int {
+		return cmp.Compare(a.Pod, b.Pod)
+	})
+
+	failed := 0
+	var firstErr string
+	for _, result := range results {
+		if !result.Succeeded {
+			if failed == 0 {
+				firstErr = result.Pod + ": " + result.Error
+			}
+			failed++
+		}
+	}
+
+	logger.Info("Notified pods",
+		"kind", target.Kind,
+		"name", target.Name,
+		"namespace", target.Namespace,
+		"pods", len(results),
+		"failed", failed)
+
+	if failed > 0 {
+		return results, fmt.Errorf("failed to notify %d of %d pods (%s)", failed, len(results), firstErr)
+	}
+	return results, nil
+}
+
+// notifyPod sends the reload request to one pod
+func (u *Updater) notifyPod(ctx context.Context, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+
+	httpClient := u.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("reload endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// readyPods returns the Ready pods of a workload that have an IP
+func (u *Updater) readyPods(ctx context.Context, target Target) ([]corev1.Pod, error) {
+	obj, err := u.getWorkload(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	workloadPods, err := u.listPods(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []corev1.Pod{}
+	for _, pod := range workloadPods {
+		if pod.DeletionTimestamp == nil && pod.Status.PodIP != "" && isPodReady(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// isPodReady reports whether the pod's Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// notifyDurations parses the delay and request timeout of a NotifyConfig
+func notifyDurations(config *reloaderv1alpha1.NotifyConfig) (delay, timeout time.Duration, err error) {
+	if config.Delay != "" {
+		if delay, err = util.ParseDuration(config.Delay); err != nil {
+			return 0, 0, fmt.Errorf("invalid notify delay: %w", err)
+		}
+	}
+
+	timeout = DefaultNotifyTimeout
+	if config.Timeout != "" {
+		if timeout, err = util.ParseDuration(config.Timeout); err != nil {
+			return 0, 0, fmt.Errorf("invalid notify timeout: %w", err)
+		}
+	}
+	return delay, timeout, nil
+}
+
+// NotifyDelay returns how long after a change the pods of a notify target are notified
+// An invalid delay is returned as 0: NotifyPods reports it.
+func NotifyDelay(target Target) time.Duration {
+	if target.Notify == nil {
+		return 0
+	}
+	delay, _, err := notifyDurations(target.Notify)
+	if err != nil {
+		return 0
+	}
+	return delay
+}
+
+// NotifyFallback returns what happens when a target's pods cannot be notified
+func NotifyFallback(target Target) string {
+	if target.Notify == nil || target.Notify.Fallback == "" {
+		return util.NotifyFallbackRollout
+	}
+	return target.Notify.Fallback
+}
+
+// NotifyConfigFromAnnotations builds the notify settings of an annotation-based workload
+// Returns nil when the workload has no valid notify-port annotation.
+func NotifyConfigFromAnnotations(annotations map[string]string) *reloaderv1alpha1.NotifyConfig {
+	port, err := strconv.ParseInt(annotations[util.AnnotationNotifyPort], 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return nil
+	}
+	return &reloaderv1alpha1.NotifyConfig{
+		Port:  int32(port),
+		Path:  annotations[util.AnnotationNotifyPath],
+		Delay: annotations[util.AnnotationNotifyDelay],
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+// notifyServer records the reload requests it receives and fails those on /broken
+type notifyServer struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (s *notifyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	s.mu.Unlock()
+
+	if r.URL.Path == "/broken" {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func newNotifyPod(name, ip string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "test"}},
+		Status: corev1.PodStatus{
+			PodIP:      ip,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func newNotifyUpdater(t *testing.T, objects ...client.Object) *Updater {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+		},
+	}
+	objects = append(objects, deployment)
+	return NewUpdater(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build())
+}
+
+func TestNotifyPods(t *testing.T) {
+	server := &notifyServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	_, portString, _ := net.SplitHostPort(httpServer.Listener.Addr().String())
+	port, _ := strconv.Atoi(portString)
+
+	updater := newNotifyUpdater(t,
+		newNotifyPod("pod-b", "127.0.0.1", true),
+		newNotifyPod("pod-a", "127.0.0.1", true),
+		newNotifyPod("pod-starting", "127.0.0.1", false),
+		newNotifyPod("pod-pending", "", true),
+	)
+	target := Target{
+		Kind:            util.KindDeployment,
+		Name:            "test-app",
+		Namespace:       "default",
+		RolloutStrategy: util.RolloutStrategyNotify,
+		Notify:          &reloaderv1alpha1.NotifyConfig{Port: int32(port)},
+	}
+
+	results, err := updater.NotifyPods(context.Background(), target)
+	if err != nil {
+		t.Fatalf("NotifyPods() error = %v", err)
+	}
+
+	// Only the Ready pods with an IP are notified, in name order, on the default path
+	if len(results) != 2 || results[0].Pod != "pod-a" || results[1].Pod != "pod-b" ||
+		!results[0].Succeeded || !results[1].Succeeded {
+		t.Errorf("NotifyPods() = %+v, want pod-a and pod-b succeeded", results)
+	}
+	if len(server.requests) != 2 || server.requests[0] != "POST "+DefaultNotifyPath {
+		t.Errorf("requests = %v, want 2 x POST %s", server.requests, DefaultNotifyPath)
+	}
+
+	// A failing endpoint is reported per pod and as an error
+	target.Notify = &reloaderv1alpha1.NotifyConfig{Port: int32(port), Path: "/broken"}
+	results, err = updater.NotifyPods(context.Background(), target)
+	if err == nil {
+		t.Fatal("NotifyPods() must fail when a pod answers with an error status")
+	}
+	if len(results) != 2 || results[0].Succeeded || results[0].Error == "" {
+		t.Errorf("NotifyPods() = %+v, want failures with errors", results)
+	}
+}
+
+func TestNotifyPods_NoReadyPods(t *testing.T) {
+	updater := newNotifyUpdater(t,
+		newNotifyPod("pod-starting", "127.0.0.1", false),
+		newNotifyPod("pod-pending", "", true),
+	)
+	target := Target{
+		Kind:            util.KindDeployment,
+		Name:            "test-app",
+		Namespace:       "default",
+		RolloutStrategy: util.RolloutStrategyNotify,
+		Notify:          &reloaderv1alpha1.NotifyConfig{Port: 8080},
+	}
+
+	// No pod was notified, so the caller must run the fallback
+	results, err := updater.NotifyPods(context.Background(), target)
+	if err == nil {
+		t.Fatal("NotifyPods() must fail when no pod is Ready")
+	}
+	if len(results) != 0 {
+		t.Errorf("NotifyPods() = %+v, want no results", results)
+	}
+}
+
+func TestNotifyPods_RequiresSettings(t *testing.T) {
+	updater := newNotifyUpdater(t)
+	target := Target{Kind: util.KindDeployment, Name: "test-app", Namespace: "default", RolloutStrategy: util.RolloutStrategyNotify}
+
+	if _, err := updater.NotifyPods(context.Background(), target); err == nil {
+		t.Error("NotifyPods() must fail without notify settings")
+	}
+
+	target.Notify = &reloaderv1alpha1.NotifyConfig{Port: 9090, Delay: "soon"}
+	if _, err := updater.NotifyPods(context.Background(), target); err == nil {
+		t.Error("NotifyPods() must fail with an invalid delay")
+	}
+}
+
+func TestNotifyConfigFromAnnotations(t *testing.T) {
+	config := NotifyConfigFromAnnotations(map[string]string{
+		util.AnnotationNotifyPort:  "9090",
+		util.AnnotationNotifyPath:  "/reload",
+		util.AnnotationNotifyDelay: "30s",
+	})
+	if config == nil || config.Port != 9090 || config.Path != "/reload" || config.Delay != "30s" {
+		t.Errorf("NotifyConfigFromAnnotations() = %+v", config)
+	}
+
+	for _, port := range []string{"", "http", "0", "70000"} {
+		if config := NotifyConfigFromAnnotations(map[string]string{util.AnnotationNotifyPort: port}); config != nil {
+			t.Errorf("NotifyConfigFromAnnotations(port %q) = %+v, want nil", port, config)
+		}
+	}
+
+	if fallback := NotifyFallback(Target{}); fallback != util.NotifyFallbackRollout {
+		t.Errorf("NotifyFallback() = %q, want %q", fallback, util.NotifyFallbackRollout)
+	}
+}
+
+func TestNotifyDelay(t *testing.T) {
+	target := Target{Notify: &reloaderv1alpha1.NotifyConfig{Port: 9090, Delay: "30s"}}
+	if delay := NotifyDelay(target); delay != 30*time.Second {
+		t.Errorf("NotifyDelay() = %s, want 30s", delay)
+	}
+
+	// Invalid delays are reported by NotifyPods, not scheduled
+	target.Notify.Delay = "soon"
+	if delay := NotifyDelay(target); delay != 0 {
+		t.Errorf("NotifyDelay(invalid) = %s, want 0", delay)
+	}
+	if delay := NotifyDelay(Target{}); delay != 0 {
+		t.Errorf("NotifyDelay(no settings) = %s, want 0", delay)
+	}
+}
+
+func TestReadyPods_SelectorExpressions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}},
+				},
+			},
+		},
+	}
+	canary := newNotifyPod("pod-canary", "127.0.0.1", true)
+	canary.Labels["track"] = "canary"
+
+	// Pods are read through the API reader, not the cached client
+	updater := NewUpdater(fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build())
+	updater.APIReader = fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(newNotifyPod("pod-stable", "127.0.0.1", true), canary).Build()
+
+	target := Target{Kind: util.KindDeployment, Name: "test-app", Namespace: "default"}
+	pods, err := updater.readyPods(context.Background(), target)
+	if err != nil {
+		t.Fatalf("readyPods() error = %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "pod-stable" {
+		t.Errorf("readyPods() = %d pods, want only pod-stable", len(pods))
+	}
+}
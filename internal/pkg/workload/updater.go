@@ -17,22 +17,39 @@ limitations under the License.
 package workload
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
 	"github.com/stakater/Reloader/internal/pkg/util"
 )
 
 // Updater handles workload updates (rolling restarts)
 type Updater struct {
 	client.Client
+
+	// APIReader reads objects around the cache, whose Secrets and ConfigMaps carry no data
+	// (see the versioned-copy reload strategy). Client is used when nil.
+	APIReader client.Reader
+
+	// HTTPClient sends the requests of the notify rollout strategy. http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+
+	// EnvVars holds the operator-wide settings of the env-vars reload strategy (--env-var-* flags)
+	// A ReloaderConfig can override them (see Target.EnvVars).
+	EnvVars reloaderv1alpha1.EnvVarsConfig
 }
 
 // NewUpdater creates a new workload updater
@@ -64,6 +81,18 @@ func (u *Updater) TriggerReload(ctx context.Context, target Target, resourceKind
 		return u.triggerRestartRollout(ctx, target)
 	}
 
+	// Volume-refresh strategy: Let the kubelet resync mounted volumes, or fall back to a rollout
+	if rolloutStrategy == util.RolloutStrategyVolumeRefresh {
+		refreshed, err := u.triggerVolumeRefresh(ctx, target, resourceKind, resourceName)
+		if err != nil || refreshed {
+			return err
+		}
+		logger.Info("Resource is not only mounted as a volume, falling back to a rollout",
+			"kind", target.Kind,
+			"name", target.Name,
+			"triggerResource", util.ReferenceKey(resourceKind, resourceName))
+	}
+
 	// Rollout strategy: Modify template based on reload strategy
 	// Default reload strategy to "env-vars" if not specified
 	reloadStrategy := target.ReloadStrategy
@@ -71,19 +100,24 @@ func (u *Updater) TriggerReload(ctx context.Context, target Target, resourceKind
 		reloadStrategy = util.ReloadStrategyEnvVars
 	}
 
+	envVars := u.envVarsConfig(target)
+
 	// Create reload source JSON annotation
 	reloadSourceJSON := util.CreateReloadSourceAnnotation(resourceKind, resourceName, resourceNamespace, resourceHash)
 
 	var err error
-	switch target.Kind {
-	case util.KindDeployment:
-		err = u.reloadDeployment(ctx, target.Name, target.Namespace, reloadStrategy, reloadSourceJSON, resourceKind, resourceName, resourceHash)
+	switch {
+	case reloadStrategy == util.ReloadStrategyVersionedCopy:
+		err = u.reloadVersionedCopy(ctx, target, resourceKind, resourceName, resourceNamespace, reloadSourceJSON)
 
-	case util.KindStatefulSet:
-		err = u.reloadStatefulSet(ctx, target.Name, target.Namespace, reloadStrategy, reloadSourceJSON, resourceKind, resourceName, resourceHash)
+	case target.Kind == util.KindDeployment:
+		err = u.reloadDeployment(ctx, target.Name, target.Namespace, reloadStrategy, envVars, reloadSourceJSON, resourceKind, resourceName, resourceHash)
 
-	case util.KindDaemonSet:
-		err = u.reloadDaemonSet(ctx, target.Name, target.Namespace, reloadStrategy, reloadSourceJSON, resourceKind, resourceName, resourceHash)
+	case target.Kind == util.KindStatefulSet:
+		err = u.reloadStatefulSet(ctx, target.Name, target.Namespace, reloadStrategy, envVars, reloadSourceJSON, resourceKind, resourceName, resourceHash)
+
+	case target.Kind == util.KindDaemonSet:
+		err = u.reloadDaemonSet(ctx, target.Name, target.Namespace, reloadStrategy, envVars, reloadSourceJSON, resourceKind, resourceName, resourceHash)
 
 	default:
 		return fmt.Errorf("unsupported workload kind: %s", target.Kind)
@@ -143,6 +177,8 @@ func (u *Updater) triggerRestartRollout(ctx context.Context, target Target) erro
 // - If rollout strategy is "rollout": Modify template to trigger reload
 //   - env-vars strategy: Update resource-specific environment variable (e.g., STAKATER_DB_CREDENTIALS_SECRET)
 //   - annotations strategy: Update pod template annotation
+//
+// - If rollout strategy is "notify" or "volume-refresh": Handled like "rollout", as there is no new configuration to deliver to the running pods
 func (u *Updater) TriggerDeleteReload(ctx context.Context, target Target, resourceKind, resourceName string) error {
 	logger := log.FromContext(ctx)
 
@@ -170,17 +206,18 @@ func (u *Updater) TriggerDeleteReload(ctx context.Context, target Target, resour
 	if reloadStrategy == "" {
 		reloadStrategy = util.ReloadStrategyEnvVars
 	}
+	envVars := u.envVarsConfig(target)
 
 	var err error
 	switch target.Kind {
 	case util.KindDeployment:
-		err = u.reloadDeleteDeployment(ctx, target.Name, target.Namespace, reloadStrategy, resourceKind, resourceName)
+		err = u.reloadDeleteDeployment(ctx, target.Name, target.Namespace, reloadStrategy, envVars, resourceKind, resourceName)
 
 	case util.KindStatefulSet:
-		err = u.reloadDeleteStatefulSet(ctx, target.Name, target.Namespace, reloadStrategy, resourceKind, resourceName)
+		err = u.reloadDeleteStatefulSet(ctx, target.Name, target.Namespace, reloadStrategy, envVars, resourceKind, resourceName)
 
 	case util.KindDaemonSet:
-		err = u.reloadDeleteDaemonSet(ctx, target.Name, target.Namespace, reloadStrategy, resourceKind, resourceName)
+		err = u.reloadDeleteDaemonSet(ctx, target.Name, target.Namespace, reloadStrategy, envVars, resourceKind, resourceName)
 
 	default:
 		return fmt.Errorf("unsupported workload kind: %s", target.Kind)
@@ -202,25 +239,31 @@ func (u *Updater) TriggerDeleteReload(ctx context.Context, target Target, resour
 // reloadDeployment triggers a rolling update of a Deployment
 func (u *Updater) reloadDeployment(
 	ctx context.Context,
-	name, namespace, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash string,
+	name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	reloadSourceJSON, resourceKind, resourceName, resourceHash string,
 ) error {
-	return u.reloadWorkloadGeneric(ctx, util.KindDeployment, name, namespace, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash)
+	return u.reloadWorkloadGeneric(ctx, util.KindDeployment, name, namespace, strategy, envVars, reloadSourceJSON, resourceKind, resourceName, resourceHash)
 }
 
 // reloadStatefulSet triggers a rolling update of a StatefulSet
 func (u *Updater) reloadStatefulSet(
 	ctx context.Context,
-	name, namespace, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash string,
+	name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	reloadSourceJSON, resourceKind, resourceName, resourceHash string,
 ) error {
-	return u.reloadWorkloadGeneric(ctx, util.KindStatefulSet, name, namespace, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash)
+	return u.reloadWorkloadGeneric(ctx, util.KindStatefulSet, name, namespace, strategy, envVars, reloadSourceJSON, resourceKind, resourceName, resourceHash)
 }
 
 // reloadDaemonSet triggers a rolling update of a DaemonSet
 func (u *Updater) reloadDaemonSet(
 	ctx context.Context,
-	name, namespace, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash string,
+	name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	reloadSourceJSON, resourceKind, resourceName, resourceHash string,
 ) error {
-	return u.reloadWorkloadGeneric(ctx, util.KindDaemonSet, name, namespace, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash)
+	return u.reloadWorkloadGeneric(ctx, util.KindDaemonSet, name, namespace, strategy, envVars, reloadSourceJSON, resourceKind, resourceName, resourceHash)
 }
 
 // getPodTemplate extracts the pod template from any workload type
@@ -231,33 +274,33 @@ func getPodTemplate(obj client.Object) (*corev1.PodTemplateSpec, error) {
 // reloadWorkloadGeneric is a generic function that handles reload for any workload type
 func (u *Updater) reloadWorkloadGeneric(
 	ctx context.Context,
-	kind, name, namespace, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash string,
+	kind, name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	reloadSourceJSON, resourceKind, resourceName, resourceHash string,
 ) error {
 	logger := log.FromContext(ctx)
 
-	// Fetch the workload
+	// Apply the reload strategy (env-vars or annotations) to the pod template
 	target := Target{Kind: kind, Name: name, Namespace: namespace}
-	obj, err := u.getWorkload(ctx, target)
-	if err != nil {
-		return fmt.Errorf("failed to get %s: %w", kind, err)
-	}
+	err := u.patchWorkload(ctx, target, func(obj client.Object) error {
+		podTemplate, err := getPodTemplate(obj)
+		if err != nil {
+			return err
+		}
+		if err := applyReloadStrategy(podTemplate, strategy, envVars, reloadSourceJSON, resourceKind, resourceName, resourceHash); err != nil {
+			return err
+		}
 
-	// Extract pod template
-	podTemplate, err := getPodTemplate(obj)
+		// restartedAt carries no reload source, so it is recorded on the workload itself
+		if strategy == util.ReloadStrategyRestartedAt {
+			setWorkloadAnnotation(obj, util.AnnotationLastReloadedFrom, reloadSourceJSON)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	// Apply the reload strategy (env-vars or annotations)
-	if err := applyReloadStrategy(podTemplate, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash); err != nil {
-		return err
-	}
-
-	// Update the workload
-	if err := u.Update(ctx, obj); err != nil {
-		return fmt.Errorf("failed to update %s: %w", kind, err)
-	}
-
 	logger.Info("Successfully triggered workload reload",
 		"kind", kind,
 		"name", name,
@@ -269,33 +312,33 @@ func (u *Updater) reloadWorkloadGeneric(
 // reloadDeleteWorkloadGeneric is a generic function that handles delete reload for any workload type
 func (u *Updater) reloadDeleteWorkloadGeneric(
 	ctx context.Context,
-	kind, name, namespace, strategy, resourceKind, resourceName string,
+	kind, name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	resourceKind, resourceName string,
 ) error {
 	logger := log.FromContext(ctx)
 
-	// Fetch the workload
+	// Apply the delete strategy (remove env var or set empty hash annotation) to the pod template
 	target := Target{Kind: kind, Name: name, Namespace: namespace}
-	obj, err := u.getWorkload(ctx, target)
-	if err != nil {
-		return fmt.Errorf("failed to get %s: %w", kind, err)
-	}
+	err := u.patchWorkload(ctx, target, func(obj client.Object) error {
+		podTemplate, err := getPodTemplate(obj)
+		if err != nil {
+			return err
+		}
+		if err := applyDeleteStrategy(podTemplate, strategy, envVars, resourceKind, resourceName); err != nil {
+			return err
+		}
 
-	// Extract pod template
-	podTemplate, err := getPodTemplate(obj)
+		// The deleted resource is no longer the reload source
+		if strategy == util.ReloadStrategyRestartedAt {
+			setWorkloadAnnotation(obj, util.AnnotationLastReloadedFrom, "")
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	// Apply the delete strategy (remove env var or set empty hash annotation)
-	if err := applyDeleteStrategy(podTemplate, strategy, resourceKind, resourceName); err != nil {
-		return err
-	}
-
-	// Update the workload
-	if err := u.Update(ctx, obj); err != nil {
-		return fmt.Errorf("failed to update %s: %w", kind, err)
-	}
-
 	logger.Info("Successfully triggered workload delete reload",
 		"kind", kind,
 		"name", name,
@@ -304,44 +347,85 @@ func (u *Updater) reloadDeleteWorkloadGeneric(
 	return nil
 }
 
+// patchWorkload applies a change to the workload as a strategic merge patch
+//
+// Business Logic:
+// A full Update sends the whole object back: it fails whenever another
+// controller (HPA, Argo CD, Flux, a mutating webhook) wrote the workload since
+// it was read, and it overwrites fields those controllers own. The change is
+// therefore made on a freshly read copy, and only the difference is sent,
+// under the util.FieldManager field manager. The patch carries the
+// resourceVersion it was computed from, so a write in between is rejected as
+// a conflict and retried on a new copy, recomputing the change against the
+// latest state.
+func (u *Updater) patchWorkload(ctx context.Context, target Target, mutate func(obj client.Object) error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := u.getWorkload(ctx, target)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", target.Kind, err)
+		}
+
+		patch := client.StrategicMergeFrom(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		if err := mutate(obj); err != nil {
+			return err
+		}
+
+		if err := u.Patch(ctx, obj, patch, client.FieldOwner(util.FieldManager)); err != nil {
+			return fmt.Errorf("failed to patch %s: %w", target.Kind, err)
+		}
+		return nil
+	})
+}
+
 // reloadDeleteDeployment triggers a rolling update of a Deployment using delete strategy
 func (u *Updater) reloadDeleteDeployment(
 	ctx context.Context,
 	name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
 	resourceKind, resourceName string,
 ) error {
-	return u.reloadDeleteWorkloadGeneric(ctx, util.KindDeployment, name, namespace, strategy, resourceKind, resourceName)
+	return u.reloadDeleteWorkloadGeneric(ctx, util.KindDeployment, name, namespace, strategy, envVars, resourceKind, resourceName)
 }
 
 // reloadDeleteStatefulSet triggers a rolling update of a StatefulSet using delete strategy
 func (u *Updater) reloadDeleteStatefulSet(
 	ctx context.Context,
 	name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
 	resourceKind, resourceName string,
 ) error {
-	return u.reloadDeleteWorkloadGeneric(ctx, util.KindStatefulSet, name, namespace, strategy, resourceKind, resourceName)
+	return u.reloadDeleteWorkloadGeneric(ctx, util.KindStatefulSet, name, namespace, strategy, envVars, resourceKind, resourceName)
 }
 
 // reloadDeleteDaemonSet triggers a rolling update of a DaemonSet using delete strategy
 func (u *Updater) reloadDeleteDaemonSet(
 	ctx context.Context,
 	name, namespace, strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
 	resourceKind, resourceName string,
 ) error {
-	return u.reloadDeleteWorkloadGeneric(ctx, util.KindDaemonSet, name, namespace, strategy, resourceKind, resourceName)
+	return u.reloadDeleteWorkloadGeneric(ctx, util.KindDaemonSet, name, namespace, strategy, envVars, resourceKind, resourceName)
 }
 
 // applyReloadStrategy applies the chosen reload strategy to a pod template
-func applyReloadStrategy(template *corev1.PodTemplateSpec, strategy, reloadSourceJSON, resourceKind, resourceName, resourceHash string) error {
+func applyReloadStrategy(
+	template *corev1.PodTemplateSpec,
+	strategy string,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	reloadSourceJSON, resourceKind, resourceName, resourceHash string,
+) error {
 	timestamp := time.Now().Format(time.RFC3339)
 
 	switch strategy {
 	case util.ReloadStrategyEnvVars:
-		return applyEnvVarsStrategy(template, resourceKind, resourceName, resourceHash)
+		return applyEnvVarsStrategy(template, envVars, resourceKind, resourceName, resourceHash)
 
 	case util.ReloadStrategyAnnotations:
 		return applyAnnotationsStrategy(template, timestamp, reloadSourceJSON)
 
+	case util.ReloadStrategyRestartedAt:
+		return applyRestartedAtStrategy(template, timestamp)
+
 	default:
 		return fmt.Errorf("unknown reload strategy: %s", strategy)
 	}
@@ -349,38 +433,141 @@ func applyReloadStrategy(template *corev1.PodTemplateSpec, strategy, reloadSourc
 
 // applyEnvVarsStrategy updates environment variable based on the changed resource to trigger pod restart
 // This matches the original Reloader's behavior of creating resource-specific env vars
-func applyEnvVarsStrategy(template *corev1.PodTemplateSpec, resourceKind, resourceName, resourceHash string) error {
-	// Ensure we have at least one container
-	if len(template.Spec.Containers) == 0 {
-		return fmt.Errorf("no containers found in pod template")
+func applyEnvVarsStrategy(
+	template *corev1.PodTemplateSpec,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	resourceKind, resourceName, resourceHash string,
+) error {
+	containers, err := selectEnvVarContainers(template, envVars, resourceKind, resourceName)
+	if err != nil {
+		return err
 	}
 
 	// Generate the environment variable name based on the resource
-	// Format: STAKATER_<RESOURCE_NAME>_<TYPE>
+	// Format: <PREFIX><RESOURCE_NAME>_<TYPE>
 	// Example: STAKATER_DB_CREDENTIALS_SECRET or STAKATER_APP_CONFIG_CONFIGMAP
-	envVarName := util.GetEnvVarName(resourceKind, resourceName)
+	envVarName := util.GetPrefixedEnvVarName(envVars.Prefix, resourceKind, resourceName)
+
+	for _, container := range containers {
+		setContainerEnvVar(container, envVarName, resourceHash)
+	}
+
+	return nil
+}
+
+// selectEnvVarContainers returns the containers that receive the variable of the env-vars strategy
+//
+// Business Logic:
+// The policy comes from EnvVarsConfig.Containers:
+// - first (default): The first container, like the original Reloader
+// - all: Every container
+// - referencing: The containers that reference the resource (env, envFrom or a mounted volume)
+// - named: The containers listed in ContainerNames
+//
+// With "referencing", the first container is used when none references the
+// resource (e.g., an explicit target, or a Secret synced by a
+// SecretProviderClass), so the reload still happens. With "named", no
+// matching container is an error, since the reload would silently do nothing.
+func selectEnvVarContainers(
+	template *corev1.PodTemplateSpec,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	resourceKind, resourceName string,
+) ([]*corev1.Container, error) {
+	// Ensure we have at least one container
+	if len(template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("no containers found in pod template")
+	}
 
-	// Update the first container's environment variable
-	container := &template.Spec.Containers[0]
+	containers := []*corev1.Container{}
+	switch envVars.Containers {
+	case "", util.EnvVarContainersFirst:
+		containers = append(containers, &template.Spec.Containers[0])
+
+	case util.EnvVarContainersAll:
+		for i := range template.Spec.Containers {
+			containers = append(containers, &template.Spec.Containers[i])
+		}
+
+	case util.EnvVarContainersReferencing:
+		for i := range template.Spec.Containers {
+			if util.CheckContainerReferencesResource(&template.Spec, template.Spec.Containers[i], resourceKind, resourceName) {
+				containers = append(containers, &template.Spec.Containers[i])
+			}
+		}
+		if len(containers) == 0 {
+			containers = append(containers, &template.Spec.Containers[0])
+		}
+
+	case util.EnvVarContainersNamed:
+		for i := range template.Spec.Containers {
+			if util.ContainsString(envVars.ContainerNames, template.Spec.Containers[i].Name) {
+				containers = append(containers, &template.Spec.Containers[i])
+			}
+		}
+		if len(containers) == 0 {
+			return nil, fmt.Errorf("none of the containers %v found in pod template", envVars.ContainerNames)
+		}
 
-	// Find or add the env var
-	found := false
+	default:
+		return nil, fmt.Errorf("unknown env var container policy: %s", envVars.Containers)
+	}
+
+	return containers, nil
+}
+
+// setContainerEnvVar sets an environment variable of a container, adding it if it doesn't exist
+func setContainerEnvVar(container *corev1.Container, name, value string) {
 	for i, env := range container.Env {
-		if env.Name == envVarName {
-			container.Env[i].Value = resourceHash
-			found = true
-			break
+		if env.Name == name {
+			container.Env[i].Value = value
+			return
 		}
 	}
 
-	if !found {
-		container.Env = append(container.Env, corev1.EnvVar{
-			Name:  envVarName,
-			Value: resourceHash,
-		})
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  name,
+		Value: value,
+	})
+}
+
+// envVarPrefixPattern matches the prefixes that keep the variable name valid (see EnvVarsConfig.Prefix)
+var envVarPrefixPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnvVarsConfig checks the settings of the env-vars reload strategy
+func ValidateEnvVarsConfig(envVars reloaderv1alpha1.EnvVarsConfig) error {
+	if envVars.Prefix != "" && !envVarPrefixPattern.MatchString(envVars.Prefix) {
+		return fmt.Errorf("invalid env var prefix %q: must start with a letter or underscore and contain only letters, digits and underscores", envVars.Prefix)
 	}
 
-	return nil
+	switch envVars.Containers {
+	case "", util.EnvVarContainersFirst, util.EnvVarContainersAll, util.EnvVarContainersReferencing:
+		return nil
+	case util.EnvVarContainersNamed:
+		if len(envVars.ContainerNames) == 0 {
+			return fmt.Errorf("the %s env var container policy requires container names", util.EnvVarContainersNamed)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown env var container policy: %s", envVars.Containers)
+	}
+}
+
+// envVarsConfig returns the env-vars strategy settings of a target
+// Fields its ReloaderConfig leaves empty fall back to the operator-wide settings.
+func (u *Updater) envVarsConfig(target Target) reloaderv1alpha1.EnvVarsConfig {
+	envVars := u.EnvVars
+	if target.EnvVars == nil {
+		return envVars
+	}
+
+	if target.EnvVars.Prefix != "" {
+		envVars.Prefix = target.EnvVars.Prefix
+	}
+	if target.EnvVars.Containers != "" {
+		envVars.Containers = target.EnvVars.Containers
+		envVars.ContainerNames = target.EnvVars.ContainerNames
+	}
+	return envVars
 }
 
 // applyAnnotationsStrategy updates pod template annotations to trigger pod restart
@@ -396,24 +583,64 @@ func applyAnnotationsStrategy(template *corev1.PodTemplateSpec, timestamp, reloa
 	return nil
 }
 
+// applyRestartedAtStrategy sets the pod template annotation `kubectl rollout restart` sets
+//
+// Business Logic:
+// GitOps tools commonly ignore kubectl.kubernetes.io/restartedAt already, so a
+// reload looks exactly like a manual `kubectl rollout restart` (same key, same
+// RFC3339 format) and causes no drift. The annotation carries only a time, so
+// the reload source is recorded on the workload instead (see reloadWorkloadGeneric).
+func applyRestartedAtStrategy(template *corev1.PodTemplateSpec, timestamp string) error {
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+
+	template.Annotations[util.AnnotationRestartedAt] = timestamp
+	return nil
+}
+
+// setWorkloadAnnotation sets an annotation on the workload object itself, removing it when value is empty
+func setWorkloadAnnotation(obj client.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if value == "" {
+		delete(annotations, key)
+		obj.SetAnnotations(annotations)
+		return
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
+
 // applyDeleteStrategy applies the delete reload strategy to a pod template
 //
 // Business Logic:
 // When a Secret/ConfigMap is deleted, we need to trigger a pod restart:
 // - env-vars strategy: Update the resource-specific environment variable with a "deleted" marker
 // - annotations strategy: Set the annotation to timestamp (triggers restart via annotation change)
+// - restarted-at strategy: Set kubectl.kubernetes.io/restartedAt to the timestamp
+// - versioned-copy strategy: Nothing; the pods keep using the last copy, which still exists
 //
-// Both strategies ensure a rolling restart by making a change to the pod template.
-func applyDeleteStrategy(template *corev1.PodTemplateSpec, strategy, resourceKind, resourceName string) error {
+// All other strategies ensure a rolling restart by making a change to the pod template.
+func applyDeleteStrategy(template *corev1.PodTemplateSpec, strategy string, envVars reloaderv1alpha1.EnvVarsConfig, resourceKind, resourceName string) error {
 	timestamp := time.Now().Format(time.RFC3339)
 
 	switch strategy {
 	case util.ReloadStrategyEnvVars:
-		return applyDeleteEnvVarsStrategy(template, resourceKind, resourceName, timestamp)
+		return applyDeleteEnvVarsStrategy(template, envVars, resourceKind, resourceName, timestamp)
 
 	case util.ReloadStrategyAnnotations:
 		return applyDeleteAnnotationsStrategy(template, timestamp)
 
+	case util.ReloadStrategyRestartedAt:
+		return applyRestartedAtStrategy(template, timestamp)
+
+	case util.ReloadStrategyVersionedCopy:
+		return nil
+
 	default:
 		return fmt.Errorf("unknown reload strategy: %s", strategy)
 	}
@@ -422,37 +649,26 @@ func applyDeleteStrategy(template *corev1.PodTemplateSpec, strategy, resourceKin
 // applyDeleteEnvVarsStrategy updates the resource-specific environment variable to indicate deletion
 // to trigger a rolling restart when a resource is deleted
 // This matches the original Reloader's behavior of using resource-specific env vars
-func applyDeleteEnvVarsStrategy(template *corev1.PodTemplateSpec, resourceKind, resourceName, timestamp string) error {
-	// Ensure we have at least one container
-	if len(template.Spec.Containers) == 0 {
-		return fmt.Errorf("no containers found in pod template")
+func applyDeleteEnvVarsStrategy(
+	template *corev1.PodTemplateSpec,
+	envVars reloaderv1alpha1.EnvVarsConfig,
+	resourceKind, resourceName, timestamp string,
+) error {
+	// The variable goes to the same containers as on a change
+	containers, err := selectEnvVarContainers(template, envVars, resourceKind, resourceName)
+	if err != nil {
+		return err
 	}
 
 	// Generate the environment variable name based on the resource
-	// Format: STAKATER_<RESOURCE_NAME>_<TYPE>
+	// Format: <PREFIX><RESOURCE_NAME>_<TYPE>
 	// Example: STAKATER_DB_CREDENTIALS_SECRET or STAKATER_APP_CONFIG_CONFIGMAP
-	envVarName := util.GetEnvVarName(resourceKind, resourceName)
+	envVarName := util.GetPrefixedEnvVarName(envVars.Prefix, resourceKind, resourceName)
 
 	// Set the resource-specific env var to "deleted" marker to trigger a restart
 	// We use "deleted" as the value to distinguish from normal reloads
-	container := &template.Spec.Containers[0]
-
-	// Find and update the env var, or add it if it doesn't exist
-	found := false
-	for i, env := range container.Env {
-		if env.Name == envVarName {
-			container.Env[i].Value = "deleted-" + timestamp
-			found = true
-			break
-		}
-	}
-
-	// If not found, add it
-	if !found {
-		container.Env = append(container.Env, corev1.EnvVar{
-			Name:  envVarName,
-			Value: "deleted-" + timestamp,
-		})
+	for _, container := range containers {
+		setContainerEnvVar(container, envVarName, "deleted-"+timestamp)
 	}
 
 	// Remove hash annotation from pod template
@@ -541,20 +757,92 @@ func (u *Updater) getWorkload(ctx context.Context, target Target) (client.Object
 
 // setLastReloadAnnotation sets the last reload timestamp annotation on the workload
 func (u *Updater) setLastReloadAnnotation(ctx context.Context, target Target) error {
-	obj, err := u.getWorkload(ctx, target)
-	if err != nil {
-		return err
-	}
+	return u.patchWorkload(ctx, target, func(obj client.Object) error {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
 
-	annotations := obj.GetAnnotations()
-	if annotations == nil {
-		annotations = make(map[string]string)
+		annotations[util.AnnotationLastReload] = time.Now().Format(time.RFC3339)
+		obj.SetAnnotations(annotations)
+		return nil
+	})
+}
+
+// MarkReloadRequestHandled records the manual reload request token handled on a workload
+// so the request is not handled again (see util.AnnotationReloadRequested)
+func (u *Updater) MarkReloadRequestHandled(ctx context.Context, target Target, token string) error {
+	return u.patchWorkload(ctx, target, func(obj client.Object) error {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+
+		annotations[util.AnnotationReloadRequestHandled] = token
+		obj.SetAnnotations(annotations)
+		return nil
+	})
+}
+
+// PendingReloads returns the reloads pending on a workload by key (see util.AnnotationReloadPending)
+// The entries are opaque to the updater; an invalid annotation is returned as an error.
+func PendingReloads(annotations map[string]string) (map[string]json.RawMessage, error) {
+	reloads := map[string]json.RawMessage{}
+	value := annotations[util.AnnotationReloadPending]
+	if value == "" {
+		return reloads, nil
+	}
+	if err := json.Unmarshal([]byte(value), &reloads); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", util.AnnotationReloadPending, err)
 	}
+	return reloads, nil
+}
 
-	annotations[util.AnnotationLastReload] = time.Now().Format(time.RFC3339)
-	obj.SetAnnotations(annotations)
+// SetPendingReload stores a reload to be done later on a workload under key (see util.AnnotationReloadPending)
+// It replaces the reload pending under the same key, if any, and an invalid annotation.
+func (u *Updater) SetPendingReload(ctx context.Context, target Target, key string, reload json.RawMessage) error {
+	return u.updatePendingReloads(ctx, target, func(reloads map[string]json.RawMessage) {
+		reloads[key] = reload
+	})
+}
+
+// ClearPendingReload removes a pending reload from a workload once it was done
+// A reload stored under the same key by a later change in the meantime is kept.
+// An invalid annotation is removed.
+func (u *Updater) ClearPendingReload(ctx context.Context, target Target, key string, reload json.RawMessage) error {
+	return u.updatePendingReloads(ctx, target, func(reloads map[string]json.RawMessage) {
+		if bytes.Equal(reloads[key], reload) {
+			delete(reloads, key)
+		}
+	})
+}
+
+// updatePendingReloads applies update to the pending reloads of a workload, removing the annotation once none is left
+func (u *Updater) updatePendingReloads(ctx context.Context, target Target, update func(map[string]json.RawMessage)) error {
+	return u.patchWorkload(ctx, target, func(obj client.Object) error {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
 
-	return u.Client.Update(ctx, obj)
+		reloads, err := PendingReloads(annotations)
+		if err != nil {
+			reloads = map[string]json.RawMessage{}
+		}
+		update(reloads)
+
+		if len(reloads) == 0 {
+			delete(annotations, util.AnnotationReloadPending)
+		} else {
+			encoded, err := json.Marshal(reloads)
+			if err != nil {
+				return err
+			}
+			annotations[util.AnnotationReloadPending] = string(encoded)
+		}
+		obj.SetAnnotations(annotations)
+		return nil
+	})
 }
 
 // restartWorkloadPods deletes all pods for a workload, triggering recreation with updated configs
@@ -18,17 +18,23 @@ package workload
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	reloaderv1alpha1 "github.com/stakater/Reloader/api/v1alpha1"
 	"github.com/stakater/Reloader/internal/pkg/util"
 )
 
@@ -206,6 +212,74 @@ func TestTriggerReloadAnnotationsStrategy(t *testing.T) {
 	}
 }
 
+func TestTriggerReloadRestartedAtStrategy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:latest"}},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(deployment).Build()
+	updater := NewUpdater(fakeClient)
+
+	target := Target{
+		Kind:           util.KindDeployment,
+		Name:           "test-app",
+		Namespace:      "default",
+		ReloadStrategy: util.ReloadStrategyRestartedAt,
+	}
+	key := types.NamespacedName{Name: "test-app", Namespace: "default"}
+
+	if err := updater.TriggerReload(context.Background(), target, util.KindSecret, "db-secret", "default", "test-hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated := &appsv1.Deployment{}
+	if err := fakeClient.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("failed to get updated deployment: %v", err)
+	}
+
+	// The pod template only gets the kubectl annotation, in kubectl's format
+	restartedAt := updated.Spec.Template.Annotations[util.AnnotationRestartedAt]
+	if _, err := time.Parse(time.RFC3339, restartedAt); err != nil {
+		t.Errorf("expected an RFC3339 %s annotation, got %q", util.AnnotationRestartedAt, restartedAt)
+	}
+	if len(updated.Spec.Template.Annotations) != 1 || len(updated.Spec.Template.Spec.Containers[0].Env) != 0 {
+		t.Errorf("expected only the restartedAt change in the pod template, got annotations %v and env %v",
+			updated.Spec.Template.Annotations, updated.Spec.Template.Spec.Containers[0].Env)
+	}
+
+	// The reload source is recorded on the workload
+	if source := updated.Annotations[util.AnnotationLastReloadedFrom]; !strings.Contains(source, "db-secret") {
+		t.Errorf("expected the reload source on the workload, got %q", source)
+	}
+
+	// A delete reload restarts again and clears the source
+	if err := updater.TriggerDeleteReload(context.Background(), target, util.KindSecret, "db-secret"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("failed to get updated deployment: %v", err)
+	}
+	if updated.Spec.Template.Annotations[util.AnnotationRestartedAt] == "" {
+		t.Errorf("expected the %s annotation after a delete reload", util.AnnotationRestartedAt)
+	}
+	if _, ok := updated.Annotations[util.AnnotationLastReloadedFrom]; ok {
+		t.Error("expected the reload source to be removed after a delete reload")
+	}
+}
+
 func TestTriggerReloadStatefulSet(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -620,3 +694,513 @@ func TestTriggerReloadDefaultReloadStrategy(t *testing.T) {
 		t.Errorf("Expected env var value to be 'test-hash', got '%s'", foundValue)
 	}
 }
+
+func TestTriggerReloadPatchesWithFieldManager(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:latest"}},
+				},
+			},
+		},
+	}
+
+	// The first patch hits a conflict, the retry succeeds
+	var patchTypes []types.PatchType
+	var fieldManagers []string
+	var patchData []string
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(deployment).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				t.Errorf("workloads must be patched, not updated")
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchOpts := &client.PatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				patchTypes = append(patchTypes, patch.Type())
+				fieldManagers = append(fieldManagers, patchOpts.FieldManager)
+				data, err := patch.Data(obj)
+				if err != nil {
+					return err
+				}
+				patchData = append(patchData, string(data))
+				if len(patchTypes) == 1 {
+					return apierrors.NewConflict(appsv1.Resource("deployments"), obj.GetName(), errors.New("modified concurrently"))
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	updater := NewUpdater(fakeClient)
+
+	target := Target{
+		Kind:           util.KindDeployment,
+		Name:           "test-app",
+		Namespace:      "default",
+		ReloadStrategy: util.ReloadStrategyEnvVars,
+		PausePeriod:    "5m",
+	}
+	if err := updater.TriggerReload(context.Background(), target, util.KindSecret, "db-secret", "default", "test-hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Reload (conflict + retry) and the last-reload annotation
+	if len(patchTypes) != 3 {
+		t.Fatalf("expected 3 patches, got %d", len(patchTypes))
+	}
+	for i := range patchTypes {
+		if patchTypes[i] != types.StrategicMergePatchType {
+			t.Errorf("patch %d: expected a strategic merge patch, got %s", i, patchTypes[i])
+		}
+		if fieldManagers[i] != util.FieldManager {
+			t.Errorf("patch %d: expected field manager %q, got %q", i, util.FieldManager, fieldManagers[i])
+		}
+		// Without the resourceVersion the API server would never report a conflict
+		if !strings.Contains(patchData[i], `"resourceVersion"`) {
+			t.Errorf("patch %d: expected the resourceVersion for optimistic locking, got %s", i, patchData[i])
+		}
+	}
+
+	updated := &appsv1.Deployment{}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "test-app", Namespace: "default"}, updated); err != nil {
+		t.Fatalf("failed to get updated deployment: %v", err)
+	}
+	if updated.Annotations[util.AnnotationLastReload] == "" {
+		t.Error("expected the last reload annotation to be set")
+	}
+	if env := updated.Spec.Template.Spec.Containers[0].Env; len(env) != 1 || env[0].Value != "test-hash" {
+		t.Errorf("expected the reload env var after the retry, got %v", env)
+	}
+}
+
+func TestTriggerReloadVersionedCopyStrategy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	ctx := context.Background()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "default"},
+		Data:       map[string]string{"mode": "v1"},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "deployment-uid"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "nginx",
+						Image: "nginx:latest",
+						EnvFrom: []corev1.EnvFromSource{{
+							ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap, deployment).Build()
+	updater := NewUpdater(fakeClient)
+
+	target := Target{
+		Kind:           util.KindDeployment,
+		Name:           "test-app",
+		Namespace:      "default",
+		ReloadStrategy: util.ReloadStrategyVersionedCopy,
+	}
+	key := types.NamespacedName{Name: "test-app", Namespace: "default"}
+
+	// reload changes the ConfigMap data, reloads and returns the copy the deployment now uses
+	reload := func(mode string) (*appsv1.Deployment, *corev1.ConfigMap) {
+		t.Helper()
+		current := &corev1.ConfigMap{}
+		if err := fakeClient.Get(ctx, types.NamespacedName{Name: "app-config", Namespace: "default"}, current); err != nil {
+			t.Fatalf("failed to get configmap: %v", err)
+		}
+		current.Data = map[string]string{"mode": mode}
+		if err := fakeClient.Update(ctx, current); err != nil {
+			t.Fatalf("failed to update configmap: %v", err)
+		}
+		if err := updater.TriggerReload(ctx, target, util.KindConfigMap, "app-config", "default", "hash-"+mode); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := &appsv1.Deployment{}
+		if err := fakeClient.Get(ctx, key, updated); err != nil {
+			t.Fatalf("failed to get updated deployment: %v", err)
+		}
+		copyName := util.GetVersionedCopies(updated.Spec.Template.Annotations)["ConfigMap/app-config"]
+		copyObj := &corev1.ConfigMap{}
+		if err := fakeClient.Get(ctx, types.NamespacedName{Name: copyName, Namespace: "default"}, copyObj); err != nil {
+			t.Fatalf("failed to get versioned copy %q: %v", copyName, err)
+		}
+		return updated, copyObj
+	}
+
+	updated, first := reload("v1")
+	if first.Data["mode"] != "v1" || first.Immutable == nil || !*first.Immutable {
+		t.Errorf("expected an immutable copy of the v1 data, got %+v", first)
+	}
+	if first.Annotations[util.AnnotationIgnore] != "true" || first.Annotations[util.AnnotationCopyOf] != "app-config" {
+		t.Errorf("expected the copy to be ignored and to name its original, got %v", first.Annotations)
+	}
+	if refs := util.GetPodSpecReferences(&updated.Spec.Template.Spec); len(refs) != 1 || refs[0] != "ConfigMap/"+first.Name {
+		t.Errorf("expected every reference to point at %s, got %v", first.Name, refs)
+	}
+	if owners := first.OwnerReferences; len(owners) != 1 || owners[0].UID != "deployment-uid" {
+		t.Errorf("expected the new copy to be owned by the deployment, got %v", owners)
+	}
+
+	// The Deployment controller keeps the rolled-out template in a ReplicaSet
+	controller := true
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-app-1",
+			Namespace: "default",
+			UID:       "replicaset-1",
+			Labels:    map[string]string{"app": "test"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "test-app", UID: "deployment-uid", Controller: &controller,
+			}},
+		},
+		Spec: appsv1.ReplicaSetSpec{Selector: updated.Spec.Selector, Template: updated.Spec.Template},
+	}
+	if err := fakeClient.Create(ctx, replicaSet); err != nil {
+		t.Fatalf("failed to create replicaset: %v", err)
+	}
+
+	updated, second := reload("v2")
+	if second.Name == first.Name || second.Data["mode"] != "v2" {
+		t.Fatalf("expected a new copy of the v2 data, got %s with %v", second.Name, second.Data)
+	}
+	if !util.CheckPodSpecReferencesResource(&updated.Spec.Template.Spec, util.KindConfigMap, second.Name) ||
+		util.CheckPodSpecReferencesResource(&updated.Spec.Template.Spec, util.KindConfigMap, first.Name) {
+		t.Errorf("expected the references to move from %s to %s", first.Name, second.Name)
+	}
+
+	// The first copy now lives as long as the ReplicaSet that can be rolled back to
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(first), first); err != nil {
+		t.Fatalf("failed to get first copy: %v", err)
+	}
+	if owners := first.OwnerReferences; len(owners) != 1 || owners[0].UID != "replicaset-1" {
+		t.Errorf("expected the first copy to be owned by its ReplicaSet, got %v", owners)
+	}
+
+	// No revision kept the second copy, so it is deleted once replaced
+	_, third := reload("v3")
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(second), &corev1.ConfigMap{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the unused second copy to be deleted, got %v", err)
+	}
+
+	// Deleting the original leaves the pods on their copy
+	if err := updater.TriggerDeleteReload(ctx, target, util.KindConfigMap, "app-config"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated = &appsv1.Deployment{}
+	if err := fakeClient.Get(ctx, key, updated); err != nil {
+		t.Fatalf("failed to get updated deployment: %v", err)
+	}
+	if refs := util.GetPodSpecReferences(&updated.Spec.Template.Spec); len(refs) != 1 || refs[0] != "ConfigMap/"+third.Name {
+		t.Errorf("expected the delete reload to keep the reference to %s, got %v", third.Name, refs)
+	}
+}
+
+func TestTriggerReloadVolumeRefreshStrategy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-app",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}},
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "test"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:         "nginx",
+							Image:        "nginx:latest",
+							VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/nginx/conf.d"}},
+							EnvFrom: []corev1.EnvFromSource{{
+								SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-secret"}},
+							}},
+						},
+					},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-app-pod-1",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+	}
+	// Matches the selector's labels but not its expressions: it belongs to another workload
+	canaryPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-app-canary-1",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test", "track": "canary"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(deployment, pod, canaryPod).
+		Build()
+	updater := NewUpdater(fakeClient)
+
+	target := Target{
+		Kind:            util.KindDeployment,
+		Name:            "test-app",
+		Namespace:       "default",
+		RolloutStrategy: util.RolloutStrategyVolumeRefresh,
+	}
+	ctx := context.Background()
+
+	// A ConfigMap mounted as a volume: the running pod is annotated, the template is left alone
+	if err := updater.TriggerReload(ctx, target, util.KindConfigMap, "app-config", "default", "test-hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updatedPod := &corev1.Pod{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), updatedPod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	if updatedPod.Annotations[util.AnnotationVolumeRefresh] == "" {
+		t.Errorf("pod should carry the %s annotation", util.AnnotationVolumeRefresh)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(canaryPod), updatedPod); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	if _, found := updatedPod.Annotations[util.AnnotationVolumeRefresh]; found {
+		t.Error("pods outside the workload's selector must not be annotated")
+	}
+
+	updatedDeployment := &appsv1.Deployment{}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(deployment), updatedDeployment); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if len(updatedDeployment.Spec.Template.Spec.Containers[0].Env) != 0 {
+		t.Error("volume-refresh strategy should not modify the pod template")
+	}
+
+	// A Secret read through envFrom cannot be refreshed in place: fall back to a rollout
+	if err := updater.TriggerReload(ctx, target, util.KindSecret, "app-secret", "default", "test-hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(deployment), updatedDeployment); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	envVarName := util.GetEnvVarName(util.KindSecret, "app-secret")
+	found := false
+	for _, env := range updatedDeployment.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == envVarName && env.Value == "test-hash" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("fallback rollout should set %s in the pod template", envVarName)
+	}
+}
+
+func TestApplyEnvVarsStrategyContainerPolicies(t *testing.T) {
+	newTemplate := func() *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "istio-proxy"},
+					{
+						Name: "app",
+						EnvFrom: []corev1.EnvFromSource{{
+							ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+						}},
+					},
+					{Name: "log-shipper", VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/config"}}},
+				},
+				Volumes: []corev1.Volume{{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+					},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		envVars  reloaderv1alpha1.EnvVarsConfig
+		resource string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "default is the first container", resource: "app-config", expected: []string{"istio-proxy"}},
+		{name: "all", envVars: reloaderv1alpha1.EnvVarsConfig{Containers: util.EnvVarContainersAll}, resource: "app-config", expected: []string{"istio-proxy", "app", "log-shipper"}},
+		{name: "referencing through envFrom and volume", envVars: reloaderv1alpha1.EnvVarsConfig{Containers: util.EnvVarContainersReferencing}, resource: "app-config", expected: []string{"app", "log-shipper"}},
+		{name: "referencing falls back to the first container", envVars: reloaderv1alpha1.EnvVarsConfig{Containers: util.EnvVarContainersReferencing}, resource: "other", expected: []string{"istio-proxy"}},
+		{name: "named", envVars: reloaderv1alpha1.EnvVarsConfig{Containers: util.EnvVarContainersNamed, ContainerNames: []string{"app", "missing"}}, resource: "app-config", expected: []string{"app"}},
+		{name: "named without a match", envVars: reloaderv1alpha1.EnvVarsConfig{Containers: util.EnvVarContainersNamed, ContainerNames: []string{"missing"}}, resource: "app-config", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.envVars.Prefix = "RELOAD_"
+			envVarName := util.GetPrefixedEnvVarName("RELOAD_", util.KindConfigMap, tt.resource)
+
+			for _, deleted := range []bool{false, true} {
+				template := newTemplate()
+				var err error
+				if deleted {
+					err = applyDeleteEnvVarsStrategy(template, tt.envVars, util.KindConfigMap, tt.resource, "2025-01-01T00:00:00Z")
+				} else {
+					err = applyEnvVarsStrategy(template, tt.envVars, util.KindConfigMap, tt.resource, "test-hash")
+				}
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("deleted=%v: error = %v, wantErr %v", deleted, err, tt.wantErr)
+				}
+
+				updated := []string{}
+				for _, container := range template.Spec.Containers {
+					for _, env := range container.Env {
+						if env.Name == envVarName {
+							updated = append(updated, container.Name)
+						}
+					}
+				}
+				if !tt.wantErr && strings.Join(updated, ",") != strings.Join(tt.expected, ",") {
+					t.Errorf("deleted=%v: containers with %s = %v, want %v", deleted, envVarName, updated, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvVarsConfig(t *testing.T) {
+	updater := NewUpdater(nil)
+	updater.EnvVars = reloaderv1alpha1.EnvVarsConfig{Prefix: "GLOBAL_", Containers: util.EnvVarContainersAll}
+
+	envVars := updater.envVarsConfig(Target{EnvVars: &reloaderv1alpha1.EnvVarsConfig{
+		Containers:     util.EnvVarContainersNamed,
+		ContainerNames: []string{"app"},
+	}})
+	if envVars.Prefix != "GLOBAL_" || envVars.Containers != util.EnvVarContainersNamed || len(envVars.ContainerNames) != 1 {
+		t.Errorf("envVarsConfig() = %+v, want the global prefix and the config's named policy", envVars)
+	}
+
+	if err := ValidateEnvVarsConfig(reloaderv1alpha1.EnvVarsConfig{Containers: util.EnvVarContainersNamed}); err == nil {
+		t.Error("ValidateEnvVarsConfig() must reject the named policy without container names")
+	}
+	if err := ValidateEnvVarsConfig(reloaderv1alpha1.EnvVarsConfig{Prefix: "1BAD-"}); err == nil {
+		t.Error("ValidateEnvVarsConfig() must reject an invalid prefix")
+	}
+	if err := ValidateEnvVarsConfig(updater.EnvVars); err != nil {
+		t.Errorf("ValidateEnvVarsConfig() error = %v", err)
+	}
+}
+
+func TestPendingReloads(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus", Namespace: "default"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(deployment).Build()
+	updater := NewUpdater(fakeClient)
+	target := Target{Kind: util.KindDeployment, Name: "prometheus", Namespace: "default"}
+	key := types.NamespacedName{Name: "prometheus", Namespace: "default"}
+
+	pending := func() map[string]json.RawMessage {
+		updated := &appsv1.Deployment{}
+		if err := fakeClient.Get(context.Background(), key, updated); err != nil {
+			t.Fatalf("failed to get deployment: %v", err)
+		}
+		if _, ok := updated.Annotations[util.AnnotationReloadPending]; !ok {
+			return nil
+		}
+		reloads, err := PendingReloads(updated.Annotations)
+		if err != nil {
+			t.Fatalf("PendingReloads() error = %v", err)
+		}
+		return reloads
+	}
+
+	first, second, other := json.RawMessage(`{"n":1}`), json.RawMessage(`{"n":2}`), json.RawMessage(`{"n":3}`)
+	for _, set := range []struct {
+		key    string
+		reload json.RawMessage
+	}{{"default/Secret/db", first}, {"default/Secret/db", second}, {"default/Secret/api", other}} {
+		if err := updater.SetPendingReload(context.Background(), target, set.key, set.reload); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	reloads := pending()
+	if string(reloads["default/Secret/db"]) != string(second) {
+		t.Errorf("expected the later reload to replace the earlier one, got %s", reloads["default/Secret/db"])
+	}
+	if string(reloads["default/Secret/api"]) != string(other) {
+		t.Errorf("expected the reload of another resource to be kept, got %s", reloads["default/Secret/api"])
+	}
+
+	// Clearing a reload that was replaced keeps the newer one
+	if err := updater.ClearPendingReload(context.Background(), target, "default/Secret/db", first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reloads := pending(); string(reloads["default/Secret/db"]) != string(second) {
+		t.Errorf("expected the newer reload to be kept, got %s", reloads["default/Secret/db"])
+	}
+
+	for key, reload := range map[string]json.RawMessage{"default/Secret/db": second, "default/Secret/api": other} {
+		if err := updater.ClearPendingReload(context.Background(), target, key, reload); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if reloads := pending(); reloads != nil {
+		t.Errorf("expected the annotation to be removed, got %v", reloads)
+	}
+
+	// An invalid annotation is an error, and clearing removes it
+	if _, err := PendingReloads(map[string]string{util.AnnotationReloadPending: "not json"}); err == nil {
+		t.Error("PendingReloads() must reject an invalid annotation")
+	}
+}
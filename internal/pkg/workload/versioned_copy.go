@@ -0,0 +1,436 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+// revisionTemplate is a pod template kept by a workload for rollbacks, with the object holding it
+type revisionTemplate struct {
+	owner    metav1.OwnerReference
+	template *corev1.PodTemplateSpec
+}
+
+// reloadVersionedCopy reloads a workload by pointing it at an immutable copy of the changed resource
+//
+// Business Logic:
+//  1. The current data of the Secret/ConfigMap is copied into an immutable
+//     object named "<name>-<hash>" (see util.VersionedCopyName). The name only
+//     depends on the content, so reverting to earlier data reuses its copy.
+//  2. Every reference of the pod template to the original, or to the copy it
+//     used so far, is rewritten to the new copy, and the template records the
+//     mapping (util.AnnotationVersionedCopies). The changed template rolls out
+//     like any other spec change.
+//  3. The copies are re-owned (see collectVersionedCopies): a copy used by the
+//     current template is owned by the workload, a copy used by an older
+//     revision by that ReplicaSet/ControllerRevision. Kubernetes then deletes a
+//     copy together with the last revision using it, i.e., after
+//     revisionHistoryLimit further rollouts, and `kubectl rollout undo` brings
+//     back a template whose copy still exists.
+func (u *Updater) reloadVersionedCopy(
+	ctx context.Context,
+	target Target,
+	resourceKind, resourceName, resourceNamespace, reloadSourceJSON string,
+) error {
+	logger := log.FromContext(ctx)
+
+	if !util.IsSupportedResourceKind(resourceKind) {
+		return fmt.Errorf("the %s reload strategy only supports Secrets and ConfigMaps, not %s",
+			util.ReloadStrategyVersionedCopy, resourceKind)
+	}
+	if target.Namespace != resourceNamespace {
+		return fmt.Errorf("the %s reload strategy requires %s %s to be in the namespace of the workload",
+			util.ReloadStrategyVersionedCopy, resourceKind, resourceName)
+	}
+
+	owner, err := u.getWorkload(ctx, target)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", target.Kind, err)
+	}
+	copyName, err := u.ensureVersionedCopy(ctx, owner, target.Kind, resourceKind, resourceName, resourceNamespace)
+	if err != nil {
+		return err
+	}
+
+	err = u.patchWorkload(ctx, target, func(obj client.Object) error {
+		template, err := getPodTemplate(obj)
+		if err != nil {
+			return err
+		}
+
+		names := []string{resourceName}
+		if previous := util.GetVersionedCopies(template.Annotations)[util.ReferenceKey(resourceKind, resourceName)]; previous != "" {
+			names = append(names, previous)
+		}
+		if !util.RewritePodSpecReferences(&template.Spec, resourceKind, names, copyName) &&
+			!util.CheckPodSpecReferencesResource(&template.Spec, resourceKind, copyName) {
+			return fmt.Errorf("%s %s does not reference %s %s, so there is no reference to point at a copy",
+				target.Kind, target.Name, resourceKind, resourceName)
+		}
+		if err := util.SetVersionedCopy(template, resourceKind, resourceName, copyName); err != nil {
+			return err
+		}
+		template.Annotations[util.AnnotationLastReloadedFrom] = reloadSourceJSON
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The rollout already happened; unowned copies are collected on the next reload
+	if err := u.collectVersionedCopies(ctx, target, resourceKind, resourceName); err != nil {
+		logger.Error(err, "Failed to collect versioned copies",
+			"kind", target.Kind,
+			"name", target.Name,
+			"resource", resourceKind+"/"+resourceName)
+	}
+
+	logger.Info("Successfully triggered workload reload",
+		"kind", target.Kind,
+		"name", target.Name,
+		"strategy", util.ReloadStrategyVersionedCopy,
+		"copy", copyName)
+	return nil
+}
+
+// ensureVersionedCopy creates the immutable copy of the current data of a resource and returns its name
+// The copy is owned by the workload until collectVersionedCopies hands it over to a revision.
+func (u *Updater) ensureVersionedCopy(
+	ctx context.Context,
+	owner client.Object,
+	workloadKind, resourceKind, resourceName, namespace string,
+) (string, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: resourceName}
+	immutable := true
+
+	var copyObj client.Object
+	switch resourceKind {
+	case util.KindSecret:
+		original := &corev1.Secret{}
+		if err := u.reader().Get(ctx, key, original); err != nil {
+			return "", fmt.Errorf("failed to get Secret %s/%s: %w", namespace, resourceName, err)
+		}
+		copyObj = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: util.VersionedCopyName(resourceName, contentHash(original.Data))},
+			Type:       original.Type,
+			Data:       original.Data,
+			Immutable:  &immutable,
+		}
+
+	case util.KindConfigMap:
+		original := &corev1.ConfigMap{}
+		if err := u.reader().Get(ctx, key, original); err != nil {
+			return "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, resourceName, err)
+		}
+		content := make(map[string][]byte, len(original.Data)+len(original.BinaryData))
+		for k, v := range original.Data {
+			content[k] = []byte(v)
+		}
+		for k, v := range original.BinaryData {
+			content[k] = v
+		}
+		copyObj = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: util.VersionedCopyName(resourceName, contentHash(content))},
+			Data:       original.Data,
+			BinaryData: original.BinaryData,
+			Immutable:  &immutable,
+		}
+	}
+
+	copyObj.SetNamespace(namespace)
+	copyObj.SetLabels(map[string]string{util.LabelVersionedCopy: "true"})
+	// Copies are never a reload source themselves
+	copyObj.SetAnnotations(map[string]string{
+		util.AnnotationCopyOf: resourceName,
+		util.AnnotationIgnore: "true",
+	})
+	copyObj.SetOwnerReferences([]metav1.OwnerReference{ownerReference(owner, workloadKind)})
+
+	err := u.Create(ctx, copyObj, client.FieldOwner(util.FieldManager))
+	if apierrors.IsAlreadyExists(err) {
+		// Same content as an earlier copy, which may only be owned by an old revision by now
+		return copyObj.GetName(), u.addCopyOwner(ctx, copyObj, ownerReference(owner, workloadKind))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create versioned copy %s/%s: %w", namespace, copyObj.GetName(), err)
+	}
+	return copyObj.GetName(), nil
+}
+
+// addCopyOwner adds an owner to an existing copy
+func (u *Updater) addCopyOwner(ctx context.Context, copyObj client.Object, owner metav1.OwnerReference) error {
+	return u.updateCopyOwners(ctx, copyObj, func(refs []metav1.OwnerReference) []metav1.OwnerReference {
+		return setOwnerReference(refs, owner, true)
+	})
+}
+
+// collectVersionedCopies hands the copies of a resource over to the revisions using them
+//
+// Business Logic:
+// For each copy of the resource, the references this workload manages are
+// recomputed: the workload owns the copy while its current template uses it,
+// and each ReplicaSet (Deployment) or ControllerRevision (StatefulSet,
+// DaemonSet) of the workload owns it while its template uses it. Owners set by
+// other workloads sharing the copy are kept. A copy left without any owner
+// cannot be rolled back to and is deleted.
+//
+// The revision of the rollout just triggered usually does not exist yet; its
+// copy stays owned by the workload and is handed over on the next reload.
+func (u *Updater) collectVersionedCopies(ctx context.Context, target Target, resourceKind, resourceName string) error {
+	// Read around the cache: the template must include the patch just made
+	obj, err := util.GetWorkload(ctx, u.reader(), target.Kind, target.Name, target.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", target.Kind, err)
+	}
+	template, err := getPodTemplate(obj)
+	if err != nil {
+		return err
+	}
+	revisions, err := u.listRevisionTemplates(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	copies, err := u.listVersionedCopies(ctx, target.Namespace, resourceKind, resourceName)
+	if err != nil {
+		return err
+	}
+
+	for _, copyObj := range copies {
+		copyName := copyObj.GetName()
+		err := u.updateCopyOwners(ctx, copyObj, func(refs []metav1.OwnerReference) []metav1.OwnerReference {
+			refs = setOwnerReference(refs, ownerReference(obj, target.Kind),
+				util.CheckPodSpecReferencesResource(&template.Spec, resourceKind, copyName))
+			for _, revision := range revisions {
+				refs = setOwnerReference(refs, revision.owner,
+					util.CheckPodSpecReferencesResource(&revision.template.Spec, resourceKind, copyName))
+			}
+			return refs
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateCopyOwners recomputes the owner references of a copy, deleting it once no owner remains
+// The patch is guarded by the resourceVersion, as workloads sharing a copy may update it concurrently.
+func (u *Updater) updateCopyOwners(
+	ctx context.Context,
+	copyObj client.Object,
+	update func([]metav1.OwnerReference) []metav1.OwnerReference,
+) error {
+	key := client.ObjectKeyFromObject(copyObj)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := copyObj.DeepCopyObject().(client.Object)
+		if err := u.reader().Get(ctx, key, current); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		refs := update(append([]metav1.OwnerReference{}, current.GetOwnerReferences()...))
+		if len(refs) == 0 {
+			log.FromContext(ctx).Info("Deleting versioned copy no revision uses", "copy", key.String())
+			resourceVersion := current.GetResourceVersion()
+			precondition := client.Preconditions{ResourceVersion: &resourceVersion}
+			return client.IgnoreNotFound(u.Delete(ctx, current, precondition))
+		}
+		if equalOwnerReferences(refs, current.GetOwnerReferences()) {
+			return nil
+		}
+
+		patch := client.MergeFromWithOptions(current.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		current.SetOwnerReferences(refs)
+		return u.Patch(ctx, current, patch, client.FieldOwner(util.FieldManager))
+	})
+}
+
+// listVersionedCopies returns the copies of a resource
+func (u *Updater) listVersionedCopies(ctx context.Context, namespace, resourceKind, resourceName string) ([]client.Object, error) {
+	opts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{util.LabelVersionedCopy: "true"},
+	}
+
+	var objects []client.Object
+	switch resourceKind {
+	case util.KindSecret:
+		list := &corev1.SecretList{}
+		if err := u.reader().List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list versioned copies: %w", err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case util.KindConfigMap:
+		list := &corev1.ConfigMapList{}
+		if err := u.reader().List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list versioned copies: %w", err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+
+	copies := objects[:0]
+	for _, obj := range objects {
+		if obj.GetAnnotations()[util.AnnotationCopyOf] == resourceName {
+			copies = append(copies, obj)
+		}
+	}
+	return copies, nil
+}
+
+// listRevisionTemplates returns the pod templates a workload keeps for rollbacks
+// Deployments keep them in ReplicaSets, StatefulSets and DaemonSets in ControllerRevisions.
+func (u *Updater) listRevisionTemplates(ctx context.Context, obj client.Object) ([]revisionTemplate, error) {
+	var selector *metav1.LabelSelector
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		selector = workload.Spec.Selector
+	case *appsv1.StatefulSet:
+		selector = workload.Spec.Selector
+	case *appsv1.DaemonSet:
+		selector = workload.Spec.Selector
+	}
+	if selector == nil {
+		return nil, nil
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload selector: %w", err)
+	}
+	opts := []client.ListOption{
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: labelSelector},
+	}
+
+	revisions := []revisionTemplate{}
+	if _, ok := obj.(*appsv1.Deployment); ok {
+		replicaSets := &appsv1.ReplicaSetList{}
+		if err := u.reader().List(ctx, replicaSets, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list ReplicaSets: %w", err)
+		}
+		for i := range replicaSets.Items {
+			rs := &replicaSets.Items[i]
+			if metav1.IsControlledBy(rs, obj) {
+				revisions = append(revisions, revisionTemplate{
+					owner:    ownerReference(rs, "ReplicaSet"),
+					template: &rs.Spec.Template,
+				})
+			}
+		}
+		return revisions, nil
+	}
+
+	controllerRevisions := &appsv1.ControllerRevisionList{}
+	if err := u.reader().List(ctx, controllerRevisions, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list ControllerRevisions: %w", err)
+	}
+	for i := range controllerRevisions.Items {
+		revision := &controllerRevisions.Items[i]
+		if !metav1.IsControlledBy(revision, obj) {
+			continue
+		}
+		// The revision data is the patch restoring the template
+		var data struct {
+			Spec struct {
+				Template corev1.PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		}
+		if err := json.Unmarshal(revision.Data.Raw, &data); err != nil {
+			continue
+		}
+		revisions = append(revisions, revisionTemplate{
+			owner:    ownerReference(revision, "ControllerRevision"),
+			template: &data.Spec.Template,
+		})
+	}
+	return revisions, nil
+}
+
+// reader returns the reader for objects that must be read in full and up to date
+func (u *Updater) reader() client.Reader {
+	if u.APIReader != nil {
+		return u.APIReader
+	}
+	return u.Client
+}
+
+// ownerReference returns the owner reference of a copy to a workload, ReplicaSet or ControllerRevision
+func ownerReference(obj client.Object, kind string) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: appsv1.SchemeGroupVersion.String(),
+		Kind:       kind,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}
+}
+
+// setOwnerReference adds (present) or removes (!present) an owner reference, matched by UID
+func setOwnerReference(refs []metav1.OwnerReference, owner metav1.OwnerReference, present bool) []metav1.OwnerReference {
+	for i, ref := range refs {
+		if ref.UID == owner.UID {
+			if present {
+				return refs
+			}
+			return append(refs[:i], refs[i+1:]...)
+		}
+	}
+	if present {
+		refs = append(refs, owner)
+	}
+	return refs
+}
+
+// equalOwnerReferences reports whether two owner reference lists hold the same owners
+func equalOwnerReferences(a, b []metav1.OwnerReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].UID != b[i].UID {
+			return false
+		}
+	}
+	return true
+}
+
+// contentHash returns the hash naming the copy of some data
+// Empty data still yields a hash, so every copy has a content suffix.
+func contentHash(data map[string][]byte) string {
+	if hash := util.CalculateHash(data); hash != "" {
+		return hash
+	}
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/stakater/Reloader/internal/pkg/util"
+)
+
+// triggerVolumeRefresh handles the volume-refresh rollout strategy
+//
+// Business Logic:
+// The kubelet refreshes mounted Secret/ConfigMap volumes on its own, but only
+// on its periodic sync (a minute or more with default settings). Any update of
+// a pod makes the kubelet sync that pod right away, so bumping an annotation
+// on the running pods (not the template) delivers the new files within
+// seconds, without a restart. This only helps when the pods consume the
+// resource purely through volumes without subPath (see
+// util.CheckPodSpecReferencesResourceOnlyAsVolume): in that case true is
+// returned. Otherwise nothing is touched and false is returned, so the caller
+// falls back to a rollout.
+func (u *Updater) triggerVolumeRefresh(ctx context.Context, target Target, resourceKind, resourceName string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if resourceKind != util.KindSecret && resourceKind != util.KindConfigMap {
+		return false, nil
+	}
+
+	obj, err := u.getWorkload(ctx, target)
+	if err != nil {
+		return false, err
+	}
+	template, err := util.GetPodTemplate(obj)
+	if err != nil {
+		return false, err
+	}
+	if !util.CheckPodSpecReferencesResourceOnlyAsVolume(&template.Spec, resourceKind, resourceName) {
+		return false, nil
+	}
+
+	pods, err := u.listPods(ctx, obj)
+	if err != nil {
+		return false, err
+	}
+
+	refreshedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	refreshedCount, failedCount := 0, 0
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[util.AnnotationVolumeRefresh] = refreshedAt
+		if err := u.Patch(ctx, pod, patch, client.FieldOwner(util.FieldManager)); err != nil {
+			logger.Error(err, "Failed to annotate pod for volume refresh",
+				"pod", pod.Name,
+				"namespace", target.Namespace)
+			// Continue with other pods: the kubelet still refreshes this one on its next sync
+			failedCount++
+			continue
+		}
+		refreshedCount++
+	}
+
+	logger.Info("Triggered volume refresh",
+		"kind", target.Kind,
+		"name", target.Name,
+		"namespace", target.Namespace,
+		"triggerResource", util.ReferenceKey(resourceKind, resourceName),
+		"podsRefreshed", refreshedCount,
+		"totalPods", len(pods))
+
+	if refreshedCount == 0 && failedCount > 0 {
+		return false, fmt.Errorf("failed to annotate any pods")
+	}
+	return true, nil
+}
+
+// podSelector returns the label selector of a workload's pods
+func podSelector(obj client.Object) *metav1.LabelSelector {
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		return workload.Spec.Selector
+	case *appsv1.StatefulSet:
+		return workload.Spec.Selector
+	case *appsv1.DaemonSet:
+		return workload.Spec.Selector
+	}
+	return nil
+}
+
+// listPods lists the pods of a workload from the API server
+//
+// Business Logic:
+// Both matchLabels and matchExpressions of the workload's selector apply, so
+// pods of other workloads sharing some labels are left alone. An empty
+// selector is rejected rather than matching every pod of the namespace. Pods
+// are read through the API reader: the cached client would start an informer
+// caching every Pod of the cluster for these occasional lookups.
+func (u *Updater) listPods(ctx context.Context, obj client.Object) ([]corev1.Pod, error) {
+	labelSelector := podSelector(obj)
+	if labelSelector == nil || (len(labelSelector.MatchLabels) == 0 && len(labelSelector.MatchExpressions) == 0) {
+		return nil, fmt.Errorf("workload has no label selector")
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	podList := &corev1.PodList{}
+	if err := u.reader().List(ctx, podList, client.InNamespace(obj.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	return podList.Items, nil
+}